@@ -0,0 +1,195 @@
+package haozpay
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type AccountService struct {
+	executor *apiExecutor
+}
+
+func NewAccountService(client *resty.Client, config *Config) *AccountService {
+	return &AccountService{
+		executor: newAPIExecutor(client, config),
+	}
+}
+
+// BindWithdrawCard 绑定提现银行卡
+// 户名、卡号、身份证号和手机号在发送前使用平台公钥加密，需配置 Config.PublicKey
+//
+// 平台类商户填写 SubMerchantNo 为子商户绑卡，为空时为商户自身绑卡；
+// 绑卡需经过银行验证，返回的 BindStatus 为验证中时可通过 ListBoundCards 查询结果
+//
+// 参数:
+//   - ctx: 上下文
+//   - req: 绑卡请求
+//   - opts: 单次调用的请求选项
+//
+// 返回:
+//   - *BindWithdrawCardResponse: 绑卡结果，包含绑卡ID和脱敏卡号
+//   - error: 参数不合法、未配置平台公钥或绑卡失败时返回错误
+//
+// 示例:
+//
+//	resp, err := client.Account.BindWithdrawCard(ctx, &haozpay.BindWithdrawCardRequest{
+//	    SubMerchantNo: "SM20240101001",
+//	    ReqSeqId:      "BC20240101001",
+//	    AccountType:   haozpay.SettlementAccountTypePersonal,
+//	    AccountName:   "张三",
+//	    CardNo:        "6222021234567890123",
+//	    IdCardNo:      "110101199001011234",
+//	    Mobile:        "13800138000",
+//	    BankCode:      "ICBC",
+//	    BankName:      "中国工商银行",
+//	    IsDefault:     true,
+//	})
+func (s *AccountService) BindWithdrawCard(ctx context.Context, req *BindWithdrawCardRequest, opts ...RequestOption) (*BindWithdrawCardResponse, error) {
+	if !req.AccountType.IsValid() {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid accountType: %d", req.AccountType),
+			StatusCode: 0,
+		}
+	}
+	if req.CardNo == "" || req.AccountName == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "cardNo and accountName are required",
+			StatusCode: 0,
+		}
+	}
+
+	var resp *BindWithdrawCardResponse
+	if err := s.executor.post(ctx, "/pay-core/account/card/bind", req, &resp, "failed to bind withdraw card", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *AccountService) UnbindWithdrawCard(ctx context.Context, req *UnbindWithdrawCardRequest, opts ...RequestOption) (*UnbindWithdrawCardResponse, error) {
+	if req.BindId == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "bindId is required",
+			StatusCode: 0,
+		}
+	}
+
+	var resp *UnbindWithdrawCardResponse
+	if err := s.executor.post(ctx, "/pay-core/account/card/unbind", req, &resp, "failed to unbind withdraw card", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListBoundCards 查询已绑定的提现银行卡，卡号和户名均为脱敏后的值
+//
+// 参数:
+//   - ctx: 上下文
+//   - subMerchantNo: 子商户号，为空时查询商户自身绑定的银行卡
+//   - opts: 单次调用的请求选项
+//
+// 返回:
+//   - *BoundCardListResponse: 绑卡列表，包含验证中、验证失败的记录，不包含已解绑的银行卡
+//   - error: 查询失败时返回错误
+func (s *AccountService) ListBoundCards(ctx context.Context, subMerchantNo string, opts ...RequestOption) (*BoundCardListResponse, error) {
+	var resp *BoundCardListResponse
+	if err := s.executor.post(ctx, "/pay-core/account/card/list", &ListBoundCardsRequest{SubMerchantNo: subMerchantNo}, &resp, "failed to list bound cards", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListTransactionsPage 查询单页账户流水，通常使用 ListTransactions 自动分页遍历
+func (s *AccountService) ListTransactionsPage(ctx context.Context, req *ListTransactionsRequest, opts ...RequestOption) (*ListTransactionsResponse, error) {
+	if req.TransactionType != nil && !req.TransactionType.IsValid() {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid transactionType: %d", *req.TransactionType),
+			StatusCode: 0,
+		}
+	}
+
+	resp, err := call[ListTransactionsResponse](ctx, s.executor, "/pay-core/account/transaction/list", req, "failed to list account transactions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		resp = &ListTransactionsResponse{}
+	}
+	for i := range resp.Transactions {
+		resp.Transactions[i].Currency = resp.Transactions[i].Currency.OrDefault()
+	}
+	return resp, nil
+}
+
+// ListTransactions 按条件遍历账户流水（交易收入、退款、手续费、提现、转账、调账），自动处理分页
+// 流水金额带符号，入账为正数、出账为负数，BalanceAfter 为该笔流水入账后的可用余额
+//
+// 迭代器按需逐页查询，调用方提前结束遍历时不会继续请求下一页；
+// 查询被平台限流（HTTP 429）时按指数退避等待后重试当前页
+//
+// 参数:
+//   - ctx: 上下文
+//   - filter: 查询条件，时间范围格式为 yyyy-MM-dd HH:mm:ss，PageToken 为空时从第一页开始
+//   - opts: 每页查询使用的请求选项
+//
+// 返回:
+//   - iter.Seq2[*AccountTransaction, error]: 流水迭代器，查询失败时产出错误并结束遍历
+//
+// 示例:
+//
+//	fee := haozpay.AccountTransactionTypeFee
+//	for txn, err := range client.Account.ListTransactions(ctx, &haozpay.ListTransactionsRequest{
+//	    StartTime:       "2024-01-01 00:00:00",
+//	    EndTime:         "2024-02-01 00:00:00",
+//	    TransactionType: &fee,
+//	}) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(txn.TransactionNo, txn.Amount, txn.BalanceAfter)
+//	}
+func (s *AccountService) ListTransactions(ctx context.Context, filter *ListTransactionsRequest, opts ...RequestOption) iter.Seq2[*AccountTransaction, error] {
+	return func(yield func(*AccountTransaction, error) bool) {
+		var page ListTransactionsRequest
+		if filter != nil {
+			page = *filter
+		}
+
+		for {
+			resp, err := withRateLimitBackoff(ctx, func() (*ListTransactionsResponse, error) {
+				return s.ListTransactionsPage(ctx, &page, opts...)
+			})
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for i := range resp.Transactions {
+				if !yield(&resp.Transactions[i], nil) {
+					return
+				}
+			}
+
+			if resp.NextPageToken == "" || len(resp.Transactions) == 0 {
+				return
+			}
+			page.PageToken = resp.NextPageToken
+		}
+	}
+}
+
+// DefaultCard 返回默认提现银行卡，未设置默认卡或默认卡尚未绑定成功时返回 nil
+func (r *BoundCardListResponse) DefaultCard() *BoundCard {
+	for i := range r.Cards {
+		if r.Cards[i].IsDefault && r.Cards[i].BindStatus.IsBound() {
+			return &r.Cards[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package haozpay
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultWechatAppPackage 微信 App 支付调起参数中 package 字段的固定值
+const defaultWechatAppPackage = "Sign=WXPay"
+
+// AppPayParams App 支付调起参数
+// 由服务端生成后下发给 iOS/Android 客户端，客户端使用对应支付 SDK 调起支付
+type AppPayParams struct {
+	// PayType 支付方式
+	PayType PayType `json:"payType"`
+	// AppId 移动应用 AppID（微信 App 支付）
+	AppId string `json:"appId,omitempty"`
+	// PartnerId 渠道商户号（微信 App 支付）
+	PartnerId string `json:"partnerId,omitempty"`
+	// PrepayId 预支付交易会话标识（微信 App 支付）
+	PrepayId string `json:"prepayId,omitempty"`
+	// Package 扩展字段（微信 App 支付），固定为 Sign=WXPay
+	Package string `json:"package,omitempty"`
+	// NonceStr 随机字符串（微信 App 支付）
+	NonceStr string `json:"nonceStr,omitempty"`
+	// Timestamp 时间戳，单位为秒（微信 App 支付）
+	Timestamp string `json:"timestamp,omitempty"`
+	// SignType 二次签名的算法类型（微信 App 支付）
+	SignType SignType `json:"signType,omitempty"`
+	// Sign 二次签名（微信 App 支付）
+	Sign string `json:"sign,omitempty"`
+	// OrderString 渠道已签名的订单串（支付宝 App 支付），客户端原样传给支付宝 SDK
+	OrderString string `json:"orderString,omitempty"`
+}
+
+// wechatAppPayInfo 微信 App 支付下单返回的 PayInfo
+type wechatAppPayInfo struct {
+	AppId     string `json:"appId"`
+	PartnerId string `json:"partnerId"`
+	PrepayId  string `json:"prepayId"`
+	Package   string `json:"package"`
+}
+
+// BuildAppPayParams 根据 App 支付下单（PayTypeWechatApp、PayTypeAlipayApp）的响应生成客户端调起参数
+//
+// 微信 App 支付：解析 PayInfo 中的预支付信息，生成随机字符串和时间戳，
+// 对 appId、partnerId、prepayId、package、nonceStr、timestamp 按与接口请求相同的规则进行二次签名
+// 支付宝 App 支付：PayInfo 为渠道已签名的订单串，原样放入 OrderString
+//
+// 参数:
+//   - order: 下单响应
+//
+// 返回:
+//   - *AppPayParams: 客户端调起参数，可直接序列化为 JSON 下发
+//   - error: 订单不是 App 支付方式、PayInfo 无法解析或签名失败时返回错误
+//
+// 示例:
+//
+//	order, err := client.Payment.CreateOrder(ctx, &haozpay.CreatePaymentOrderRequest{
+//	    OrderTitle:  "测试订单",
+//	    OrderAmount: haozpay.Fen(100),
+//	    PayType:     haozpay.PayTypeWechatApp,
+//	    NotifyUrl:   "https://yourdomain.com/callback",
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//
+//	params, err := client.BuildAppPayParams(order)
+//	if err != nil {
+//	    return err
+//	}
+//	// 将 params 序列化为 JSON 返回给客户端
+func (c *Client) BuildAppPayParams(order *PaymentOrderResponse) (*AppPayParams, error) {
+	if order == nil {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "order is required to build app pay params",
+			StatusCode: 0,
+		}
+	}
+
+	payInfo := strings.TrimSpace(order.PayInfo)
+	if payInfo == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "payInfo is required to build app pay params",
+			StatusCode: 0,
+		}
+	}
+
+	switch order.PayType {
+	case PayTypeAlipayApp:
+		return &AppPayParams{PayType: order.PayType, OrderString: payInfo}, nil
+	case PayTypeWechatApp:
+		return c.buildWechatAppPayParams(payInfo)
+	}
+
+	return nil, &SDKError{
+		Code:       ErrInvalidParameter.Code,
+		Message:    fmt.Sprintf("payType %s is not an app payment", order.PayType),
+		StatusCode: 0,
+	}
+}
+
+// buildWechatAppPayParams 生成微信 App 支付调起参数并进行二次签名
+func (c *Client) buildWechatAppPayParams(payInfo string) (*AppPayParams, error) {
+	var info wechatAppPayInfo
+	if err := json.Unmarshal([]byte(payInfo), &info); err != nil {
+		return nil, &SDKError{
+			Code:       ErrInvalidResponse.Code,
+			Message:    fmt.Sprintf("failed to parse wechat app payInfo: %v", err),
+			StatusCode: 0,
+			Err:        err,
+		}
+	}
+	if info.PrepayId == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidResponse.Code,
+			Message:    "wechat app payInfo is missing prepayId",
+			StatusCode: 0,
+		}
+	}
+	if info.Package == "" {
+		info.Package = defaultWechatAppPackage
+	}
+
+	nonceStr, err := randomNonce()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	state := c.state.Load()
+	params := &AppPayParams{
+		PayType:   PayTypeWechatApp,
+		AppId:     info.AppId,
+		PartnerId: info.PartnerId,
+		PrepayId:  info.PrepayId,
+		Package:   info.Package,
+		NonceStr:  nonceStr,
+		Timestamp: strconv.FormatInt(time.Now().Unix(), 10),
+		SignType:  state.signType,
+	}
+
+	sign, err := GenerateSignWithSignType(map[string]interface{}{
+		"appId":     params.AppId,
+		"partnerId": params.PartnerId,
+		"prepayId":  params.PrepayId,
+		"package":   params.Package,
+		"nonceStr":  params.NonceStr,
+		"timestamp": params.Timestamp,
+	}, state.signer, state.signType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign app pay params: %w", err)
+	}
+	params.Sign = sign
+
+	return params, nil
+}
+
+// randomNonce 生成 32 位十六进制随机字符串
+func randomNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
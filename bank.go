@@ -0,0 +1,131 @@
+package haozpay
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	// minCardBINLength 卡 BIN 查询的最少位数
+	minCardBINLength = 6
+	// maxCardBINLength 卡 BIN 查询发送的最多位数，超出部分不发送给平台，避免传输完整卡号
+	maxCardBINLength = 10
+)
+
+type BankService struct {
+	executor *apiExecutor
+	banks    *ttlCache[*BankListResponse]
+	cardBINs *ttlCache[*CardBINResponse]
+}
+
+func NewBankService(client *resty.Client, config *Config) *BankService {
+	return &BankService{
+		executor: newAPIExecutor(client, config),
+		banks:    newTTLCache[*BankListResponse](config.BankInfoCacheTTL),
+		cardBINs: newTTLCache[*CardBINResponse](config.BankInfoCacheTTL),
+	}
+}
+
+// QueryBankList 查询平台支持提现、转账的银行列表
+// 结果按 Config.BankInfoCacheTTL 缓存，缓存有效期内重复查询不会请求平台
+//
+// 参数:
+//   - ctx: 上下文
+//   - opts: 单次调用的请求选项
+//
+// 返回:
+//   - *BankListResponse: 银行列表，多次调用可能返回同一缓存实例，调用方不应修改
+//   - error: 查询失败时返回错误
+//
+// 示例:
+//
+//	banks, err := client.Bank.QueryBankList(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	for _, bank := range banks.Banks {
+//	    fmt.Println(bank.BankCode, bank.BankName)
+//	}
+func (s *BankService) QueryBankList(ctx context.Context, opts ...RequestOption) (*BankListResponse, error) {
+	if resp, ok := s.banks.get(""); ok {
+		return resp, nil
+	}
+
+	var resp *BankListResponse
+	if err := s.executor.post(ctx, "/pay-core/bank/list/query", &QueryBankListRequest{}, &resp, "failed to query bank list", opts...); err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		resp = &BankListResponse{}
+	}
+
+	s.banks.set("", resp)
+	return resp, nil
+}
+
+// QueryCardBIN 按卡号前缀（卡 BIN）识别发卡银行和卡类型，用于提现表单填写卡号后自动带出开户银行
+// 结果按卡号前缀缓存，缓存时间由 Config.BankInfoCacheTTL 控制
+//
+// 参数:
+//   - ctx: 上下文
+//   - cardPrefix: 卡号前缀，至少 6 位数字，可包含空格；传入完整卡号时只发送前 10 位
+//   - opts: 单次调用的请求选项
+//
+// 返回:
+//   - *CardBINResponse: 发卡银行和卡类型，多次调用可能返回同一缓存实例，调用方不应修改
+//   - error: 卡号前缀不合法或查询失败时返回错误
+//
+// 示例:
+//
+//	bin, err := client.Bank.QueryCardBIN(ctx, "6222 0212 3456")
+//	if err != nil {
+//	    return err
+//	}
+//	if bin.CardType != haozpay.BankCardTypeDebit {
+//	    // 提现只支持借记卡
+//	}
+func (s *BankService) QueryCardBIN(ctx context.Context, cardPrefix string, opts ...RequestOption) (*CardBINResponse, error) {
+	cardBIN := strings.ReplaceAll(cardPrefix, " ", "")
+	if len(cardBIN) < minCardBINLength || strings.Trim(cardBIN, "0123456789") != "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "cardPrefix must contain at least 6 digits",
+			StatusCode: 0,
+		}
+	}
+	if len(cardBIN) > maxCardBINLength {
+		cardBIN = cardBIN[:maxCardBINLength]
+	}
+
+	if resp, ok := s.cardBINs.get(cardBIN); ok {
+		return resp, nil
+	}
+
+	var resp *CardBINResponse
+	if err := s.executor.post(ctx, "/pay-core/bank/card-bin/query", &QueryCardBINRequest{CardBin: cardBIN}, &resp, "failed to query card BIN", opts...); err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.BankCode == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidResponse.Code,
+			Message:    fmt.Sprintf("issuing bank of card BIN %s is not recognized", cardBIN),
+			StatusCode: 0,
+		}
+	}
+
+	s.cardBINs.set(cardBIN, resp)
+	return resp, nil
+}
+
+// Bank 返回指定银行编码的银行信息，平台不支持该银行时返回 nil
+func (r *BankListResponse) Bank(bankCode string) *BankInfo {
+	for i := range r.Banks {
+		if r.Banks[i].BankCode == bankCode {
+			return &r.Banks[i]
+		}
+	}
+	return nil
+}
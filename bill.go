@@ -0,0 +1,357 @@
+package haozpay
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/emmansun/gmsm/sm3"
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	// statementDateLayout 对账单日期格式
+	statementDateLayout = "20060102"
+	// fileTokenHeader 下载对账单、电子回单等文件时携带文件令牌的请求头
+	fileTokenHeader = "X-HaozPay-File-Token"
+)
+
+// ErrStatementHashMismatch 对账单文件摘要与平台返回的摘要不一致
+var ErrStatementHashMismatch = errors.New("statement file hash mismatch")
+
+// DownloadProgressFunc 文件下载进度回调
+//
+// 参数:
+//   - written: 已下载的字节数
+//   - total: 文件总字节数，平台未返回文件大小时为 -1
+type DownloadProgressFunc func(written, total int64)
+
+// WithDownloadProgress 设置本次调用的文件下载进度回调，适用于 DownloadStatement、DownloadStatementTo 和 DownloadReceipt
+// 回调在读取文件的 goroutine 中随每次读取执行，应尽快返回，耗时的处理（如更新界面）请自行限频
+//
+// 参数:
+//   - fn: 进度回调，为 nil 时忽略
+//
+// 示例:
+//
+//	_, err := client.Bill.DownloadStatementTo(ctx, billDate, haozpay.BillTypeTrade, f,
+//	    haozpay.WithDownloadProgress(func(written, total int64) {
+//	        log.Printf("statement downloaded %d/%d bytes", written, total)
+//	    }))
+func WithDownloadProgress(fn DownloadProgressFunc) RequestOption {
+	return func(o *requestOptions) {
+		if fn != nil {
+			o.progress = fn
+		}
+	}
+}
+
+type BillService struct {
+	executor *apiExecutor
+}
+
+func NewBillService(client *resty.Client, config *Config) *BillService {
+	return &BillService{
+		executor: newAPIExecutor(client, config),
+	}
+}
+
+// DownloadStatement 下载指定日期的对账单文件
+//
+// 处理流程:
+//  1. 申请对账单，获取文件令牌、下载地址和文件元数据
+//  2. 携带文件令牌下载文件，自动跟随平台返回的重定向，跳转到其他主机时不携带文件令牌
+//  3. 读取文件内容时同步计算摘要，读取完毕后与平台返回的文件摘要比对
+//
+// 参数:
+//   - ctx: 上下文，同时控制文件下载过程
+//   - date: 账单日期，按本地时区取日期部分
+//   - billType: 对账单类型
+//   - opts: 单次调用的请求选项，例如 WithRequestTimeout 延长大文件的下载超时
+//
+// 返回:
+//   - *Statement: 对账单文件，调用方读取完毕后必须调用 Close
+//   - error: 申请或下载失败时返回错误
+//
+// 示例:
+//
+//	statement, err := client.Bill.DownloadStatement(ctx, time.Now().AddDate(0, 0, -1), haozpay.BillTypeTrade)
+//	if err != nil {
+//	    return err
+//	}
+//	defer statement.Close()
+//
+//	// 文件摘要不一致时 io.Copy 返回 ErrStatementHashMismatch
+//	_, err = io.Copy(file, statement)
+func (s *BillService) DownloadStatement(ctx context.Context, date time.Time, billType BillType, opts ...RequestOption) (*Statement, error) {
+	if !billType.IsValid() {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid billType: %d", billType),
+			StatusCode: 0,
+		}
+	}
+
+	req := &ApplyStatementRequest{
+		BillDate: date.Format(statementDateLayout),
+		BillType: billType,
+	}
+
+	var file *StatementFileResponse
+	if err := s.executor.post(ctx, "/pay-core/bill/statement/apply", req, &file, "failed to apply statement", opts...); err != nil {
+		return nil, err
+	}
+	if file == nil || file.DownloadUrl == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidResponse.Code,
+			Message:    "statement download url is missing",
+			StatusCode: 0,
+		}
+	}
+
+	body, err := s.executor.download(ctx, file.DownloadUrl, file.FileToken, file.FileSize, newRequestOptions(opts), "failed to download statement")
+	if err != nil {
+		return nil, err
+	}
+
+	return newStatement(file, body)
+}
+
+// DownloadStatementTo 下载指定日期的对账单文件并写入 w
+// 文件边下载边写入，不在内存中缓存整个文件，适用于数百 MB 的大文件；下载进度可通过 WithDownloadProgress 获取
+//
+// 参数:
+//   - ctx: 上下文，同时控制文件下载过程
+//   - date: 账单日期，按本地时区取日期部分
+//   - billType: 对账单类型
+//   - w: 对账单文件的写入目标，例如 *os.File
+//   - opts: 单次调用的请求选项
+//
+// 返回:
+//   - *StatementFileResponse: 平台返回的文件元数据
+//   - error: 申请、下载或写入失败时返回错误，文件摘要不一致时返回 ErrStatementHashMismatch
+//
+// 注意: 返回 ErrStatementHashMismatch 时 w 中已写入的内容不可信，应丢弃
+//
+// 示例:
+//
+//	f, err := os.Create("statement-20250101.csv")
+//	if err != nil {
+//	    return err
+//	}
+//	defer f.Close()
+//
+//	file, err := client.Bill.DownloadStatementTo(ctx, billDate, haozpay.BillTypeTrade, f,
+//	    haozpay.WithRequestTimeout(10*time.Minute))
+func (s *BillService) DownloadStatementTo(ctx context.Context, date time.Time, billType BillType, w io.Writer, opts ...RequestOption) (*StatementFileResponse, error) {
+	if w == nil {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "writer is required",
+			StatusCode: 0,
+		}
+	}
+
+	statement, err := s.DownloadStatement(ctx, date, billType, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer statement.Close()
+
+	file := statement.StatementFileResponse
+	if _, err := io.Copy(w, statement); err != nil {
+		return &file, err
+	}
+	return &file, nil
+}
+
+// download 携带文件令牌下载平台生成的文件（对账单、电子回单等）
+// 文件内容不是 JSON 报文，因此绕过 resty 中间件直接使用底层 http.Client，
+// 复用客户端的代理、TLS 配置和公共请求头；返回的响应体按需读取，不缓存整个文件
+// 跳转到其他主机（例如对象存储的签名地址）时不携带文件令牌，参见 downloadRedirectPolicy
+// size 为平台返回的文件大小，响应未携带 Content-Length 时作为下载进度的总字节数
+func (e *apiExecutor) download(ctx context.Context, rawURL, fileToken string, size int64, options *requestOptions, errMessage string) (io.ReadCloser, error) {
+	state := e.current()
+	downloadURL, err := resolveDownloadURL(state.config.BaseURL, rawURL)
+	if err != nil {
+		return nil, &SDKError{
+			Code:       ErrInvalidResponse.Code,
+			Message:    fmt.Sprintf("%s: invalid download url: %v", errMessage, err),
+			StatusCode: 0,
+			Err:        err,
+		}
+	}
+
+	ctx, requestID := ensureRequestID(ctx)
+	req, err := http.NewRequestWithContext(options.context(ctx), http.MethodGet, downloadURL, nil)
+	if err != nil {
+		return nil, attachRequestID(requestError(err, errMessage), requestID)
+	}
+	for key, values := range state.restyClient.Header {
+		if key == "Content-Type" {
+			continue
+		}
+		req.Header[key] = values
+	}
+	req.Header.Set(RequestIDHeader, requestID)
+	options.setHeaders(req.Header)
+	if fileToken != "" {
+		req.Header.Set(fileTokenHeader, fileToken)
+	}
+
+	httpClient := *state.restyClient.GetClient()
+	httpClient.CheckRedirect = downloadRedirectPolicy(httpClient.CheckRedirect)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, attachRequestID(requestError(err, errMessage), requestID)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, NewSDKErrorWithRequestID(
+			httpStatusError(resp.StatusCode).Code,
+			fmt.Sprintf("%s: unexpected status %d", errMessage, resp.StatusCode),
+			resp.StatusCode,
+			requestID,
+		)
+	}
+
+	if options.progress != nil {
+		total := resp.ContentLength
+		if total < 0 && size > 0 {
+			total = size
+		}
+		return &progressReader{ReadCloser: resp.Body, total: total, progress: options.progress}, nil
+	}
+	return resp.Body, nil
+}
+
+// downloadRedirectPolicy 返回下载文件时的跳转策略，跳转地址的主机与下载地址不同时删除文件令牌请求头，
+// 文件令牌不会随跳转发送给平台以外的主机；其余规则沿用 next，next 为 nil 时与 http.Client 的默认策略一致
+func downloadRedirectPolicy(next func(req *http.Request, via []*http.Request) error) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if !strings.EqualFold(req.URL.Host, via[0].URL.Host) {
+			req.Header.Del(fileTokenHeader)
+		}
+		if next != nil {
+			return next(req, via)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+}
+
+// progressReader 读取时回调下载进度的响应体
+type progressReader struct {
+	io.ReadCloser
+	written  int64
+	total    int64
+	progress DownloadProgressFunc
+}
+
+// Read 实现 io.Reader 接口
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.written += int64(n)
+		r.progress(r.written, r.total)
+	}
+	return n, err
+}
+
+// resolveDownloadURL 解析下载地址，相对地址基于 BaseURL 解析
+func resolveDownloadURL(baseURL, downloadURL string) (string, error) {
+	ref, err := url.Parse(downloadURL)
+	if err != nil {
+		return "", err
+	}
+	if ref.IsAbs() {
+		return ref.String(), nil
+	}
+
+	base, err := url.Parse(strings.TrimRight(baseURL, "/") + "/")
+	if err != nil {
+		return "", err
+	}
+	return base.ResolveReference(&url.URL{Path: strings.TrimLeft(ref.Path, "/"), RawQuery: ref.RawQuery}).String(), nil
+}
+
+// Statement 对账单文件
+// 实现 io.ReadCloser 接口，读取到文件末尾时校验文件摘要，
+// 不一致时 Read 返回 ErrStatementHashMismatch 而不是 io.EOF
+type Statement struct {
+	// StatementFileResponse 平台返回的文件元数据（记录数、文件大小、文件摘要等）
+	StatementFileResponse
+
+	body io.ReadCloser
+	hash hash.Hash
+	err  error
+}
+
+// newStatement 创建对账单文件，根据平台返回的摘要算法计算文件摘要
+func newStatement(file *StatementFileResponse, body io.ReadCloser) (*Statement, error) {
+	statement := &Statement{
+		StatementFileResponse: *file,
+		body:                  body,
+	}
+
+	if file.FileHash != "" {
+		h, err := newFileHash(file.HashType)
+		if err != nil {
+			body.Close()
+			return nil, err
+		}
+		statement.hash = h
+	}
+
+	return statement, nil
+}
+
+// newFileHash 根据平台返回的摘要算法创建文件摘要计算器，摘要算法为空时使用 SHA256
+func newFileHash(hashType string) (hash.Hash, error) {
+	switch strings.ToUpper(hashType) {
+	case "", "SHA256":
+		return sha256.New(), nil
+	case "SM3":
+		return sm3.New(), nil
+	default:
+		return nil, &SDKError{
+			Code:       ErrInvalidResponse.Code,
+			Message:    fmt.Sprintf("unsupported file hash type: %s", hashType),
+			StatusCode: 0,
+		}
+	}
+}
+
+// Read 实现 io.Reader 接口
+func (s *Statement) Read(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	n, err := s.body.Read(p)
+	if s.hash != nil {
+		s.hash.Write(p[:n])
+	}
+	if err == io.EOF && s.hash != nil && !strings.EqualFold(hex.EncodeToString(s.hash.Sum(nil)), s.FileHash) {
+		err = ErrStatementHashMismatch
+	}
+	if err != nil {
+		s.err = err
+	}
+	return n, err
+}
+
+// Close 关闭文件下载连接
+func (s *Statement) Close() error {
+	return s.body.Close()
+}
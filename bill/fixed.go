@@ -0,0 +1,159 @@
+package bill
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// maxFixedLineSize 定长格式对账单单行的最大长度
+const maxFixedLineSize = 1 << 20
+
+// FixedColumn 定长格式对账单的列定义
+type FixedColumn struct {
+	// Name 字段名，与 CSV 表头列名相同（例如 merchantOrderNo 或 商户订单号）
+	Name string
+	// Start 列起始位置（从 0 开始），按字符计算
+	Start int
+	// Width 列宽度，按字符计算
+	Width int
+}
+
+// FixedLayout 定长格式对账单的布局
+type FixedLayout struct {
+	// Columns 列定义，必须包含 tradeType、merchantOrderNo、amount
+	Columns []FixedColumn
+	// SkipLines 文件开头需要跳过的行数（例如表头、文件头）
+	SkipLines int
+	// CommentPrefix 注释或汇总行前缀，为空时使用 #
+	CommentPrefix string
+}
+
+// FixedReader 定长格式对账单读取器
+// 通过 NewFixedReader 函数创建实例
+// 每行一条记录，字段按列定义截取后去除首尾空白
+type FixedReader struct {
+	scanner       *bufio.Scanner
+	columns       []fixedColumn
+	skipLines     int
+	commentPrefix string
+	line          int
+	err           error
+}
+
+// fixedColumn 已解析的列定义
+type fixedColumn struct {
+	field      field
+	start, end int
+}
+
+// NewFixedReader 创建定长格式对账单读取器
+//
+// 参数:
+//   - r: 对账单内容，必须为 UTF-8 编码
+//   - layout: 对账单布局
+//
+// 返回:
+//   - *FixedReader: 对账单读取器
+//   - error: 布局中存在未知字段、列位置非法或缺少必需字段时返回错误
+//
+// 示例:
+//
+//	reader, err := bill.NewFixedReader(statement, bill.FixedLayout{
+//	    Columns: []bill.FixedColumn{
+//	        {Name: "tradeTime", Start: 0, Width: 19},
+//	        {Name: "tradeType", Start: 19, Width: 2},
+//	        {Name: "merchantOrderNo", Start: 21, Width: 32},
+//	        {Name: "amount", Start: 53, Width: 15},
+//	    },
+//	    SkipLines: 1,
+//	})
+func NewFixedReader(r io.Reader, layout FixedLayout) (*FixedReader, error) {
+	columns := make([]fixedColumn, 0, len(layout.Columns))
+	found := make(map[field]bool)
+	for _, column := range layout.Columns {
+		f, ok := lookupField(column.Name)
+		if !ok {
+			return nil, fmt.Errorf("unknown fixed-width column: %s", column.Name)
+		}
+		if column.Start < 0 || column.Width <= 0 {
+			return nil, fmt.Errorf("invalid fixed-width column %s: start=%d, width=%d", column.Name, column.Start, column.Width)
+		}
+		columns = append(columns, fixedColumn{field: f, start: column.Start, end: column.Start + column.Width})
+		found[f] = true
+	}
+	if err := checkRequiredFields(found); err != nil {
+		return nil, fmt.Errorf("fixed-width layout is missing required column: %w", err)
+	}
+
+	commentPrefix := layout.CommentPrefix
+	if commentPrefix == "" {
+		commentPrefix = "#"
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxFixedLineSize)
+
+	return &FixedReader{
+		scanner:       scanner,
+		columns:       columns,
+		skipLines:     layout.SkipLines,
+		commentPrefix: commentPrefix,
+	}, nil
+}
+
+// Read 读取下一条交易记录，没有更多记录时返回 io.EOF
+// 发生错误后再次调用返回同一错误
+func (r *FixedReader) Read() (*BillRecord, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	record, err := r.read()
+	if err != nil {
+		r.err = err
+	}
+	return record, err
+}
+
+func (r *FixedReader) read() (*BillRecord, error) {
+	for r.scanner.Scan() {
+		r.line++
+		text := strings.TrimSuffix(r.scanner.Text(), "\r")
+		if r.line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if r.line <= r.skipLines || strings.TrimSpace(text) == "" || strings.HasPrefix(text, r.commentPrefix) {
+			continue
+		}
+
+		runes := []rune(text)
+		record := &BillRecord{}
+		for _, column := range r.columns {
+			if err := record.setField(column.field, cleanValue(substring(runes, column.start, column.end))); err != nil {
+				return nil, fmt.Errorf("statement line %d: %w", r.line, err)
+			}
+		}
+		return record, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, fmt.Errorf("statement line %d: line too long", r.line+1)
+		}
+		return nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+	return nil, io.EOF
+}
+
+// substring 截取 [start, end) 范围的字符，超出行长度的部分视为空
+func substring(runes []rune, start, end int) string {
+	if start >= len(runes) {
+		return ""
+	}
+	if end > len(runes) {
+		end = len(runes)
+	}
+	return string(runes[start:end])
+}
@@ -0,0 +1,162 @@
+package bill
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+// testFixedLayout 测试使用的定长布局：交易类型 2 位、商户订单号 10 位、金额 10 位、备注 8 位
+var testFixedLayout = FixedLayout{
+	Columns: []FixedColumn{
+		{Name: "tradeType", Start: 0, Width: 2},
+		{Name: "商户订单号", Start: 2, Width: 10},
+		{Name: "amount", Start: 12, Width: 10},
+		{Name: "remark", Start: 22, Width: 8},
+	},
+	SkipLines: 1,
+}
+
+// fixedLine 按 testFixedLayout 拼接一行，宽度按字符计算
+func fixedLine(tradeType, merchantOrderNo, amount, remark string) string {
+	return fmt.Sprintf("%-2s%-10s%10s%-8s", tradeType, merchantOrderNo, amount, remark)
+}
+
+func TestFixedReader(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+	}{
+		{
+			name: "LF with trailing newline",
+			statement: "\ufeffTYPE ORDER NO       AMOUNT\n" +
+				fixedLine("0", "M1", "12.30", "中文备注") + "\n" +
+				"# 汇总\n" +
+				"\n" +
+				strings.TrimRight(fixedLine("1", "`M1", "0.5", ""), " ") + "\n",
+		},
+		{
+			name: "CRLF without trailing newline",
+			statement: "HEADER\r\n" +
+				fixedLine("0", "M1", "12.30", "中文备注") + "\r\n" +
+				strings.TrimRight(fixedLine("1", "`M1", "0.5", ""), " "),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader, err := NewFixedReader(strings.NewReader(tt.statement), testFixedLayout)
+			if err != nil {
+				t.Fatalf("NewFixedReader() error = %v", err)
+			}
+
+			first, err := reader.Read()
+			if err != nil {
+				t.Fatalf("first Read() error = %v", err)
+			}
+			// 按字符截取：备注中的中文不影响后续列的位置
+			if first.TradeType != TradeTypePayment || first.MerchantOrderNo != "M1" || first.Amount != haozpay.Fen(1230) || first.Remark != "中文备注" {
+				t.Errorf("first record = %+v", first)
+			}
+
+			// 行末没有备注列，超出行长度的列视为空
+			second, err := reader.Read()
+			if err != nil {
+				t.Fatalf("second Read() error = %v", err)
+			}
+			if second.TradeType != TradeTypeRefund || second.MerchantOrderNo != "M1" || second.Amount != haozpay.Fen(50) || second.Remark != "" {
+				t.Errorf("second record = %+v", second)
+			}
+
+			for i := 0; i < 2; i++ {
+				if record, err := reader.Read(); !errors.Is(err, io.EOF) {
+					t.Fatalf("Read() after last record = %+v, %v, want io.EOF", record, err)
+				}
+			}
+		})
+	}
+}
+
+func TestFixedReaderOffsets(t *testing.T) {
+	// 列可以不连续，也可以不按位置顺序定义；列之间的字符被忽略
+	layout := FixedLayout{
+		Columns: []FixedColumn{
+			{Name: "amount", Start: 10, Width: 6},
+			{Name: "merchantOrderNo", Start: 3, Width: 4},
+			{Name: "tradeType", Start: 0, Width: 1},
+		},
+		CommentPrefix: "TOTAL",
+	}
+	const statement = "0||M123|||  9.99\n" +
+		"TOTAL 1\n"
+
+	records, err := readAllFixed(t, statement, layout)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(records) != 1 || records[0].MerchantOrderNo != "M123" || records[0].Amount != haozpay.Fen(999) {
+		t.Errorf("records = %+v", records)
+	}
+}
+
+func TestFixedReaderErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		want      string
+	}{
+		{name: "unknown trade type", statement: "H\n" + fixedLine("9", "M1", "1.00", ""), want: "statement line 2: invalid trade type"},
+		{name: "invalid amount", statement: "H\n" + fixedLine("0", "M1", "1.00", "") + "\n" + fixedLine("0", "M2", "1.0.0", ""), want: "statement line 3"},
+		{name: "line too long", statement: "H\n" + strings.Repeat("0", maxFixedLineSize+1), want: "statement line 2: line too long"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := readAllFixed(t, tt.statement, testFixedLayout)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Read() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewFixedReaderLayoutErrors(t *testing.T) {
+	required := []FixedColumn{
+		{Name: "tradeType", Start: 0, Width: 1},
+		{Name: "merchantOrderNo", Start: 1, Width: 10},
+		{Name: "amount", Start: 11, Width: 10},
+	}
+	tests := []struct {
+		name    string
+		columns []FixedColumn
+		want    string
+	}{
+		{name: "unknown column", columns: append(required[:3:3], FixedColumn{Name: "channel", Start: 21, Width: 2}), want: "unknown fixed-width column: channel"},
+		{name: "negative start", columns: append(required[:3:3], FixedColumn{Name: "remark", Start: -1, Width: 2}), want: "invalid fixed-width column remark"},
+		{name: "zero width", columns: append(required[:3:3], FixedColumn{Name: "remark", Start: 21}), want: "invalid fixed-width column remark"},
+		{name: "missing required column", columns: required[:2], want: "missing required column: amount"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewFixedReader(strings.NewReader(""), FixedLayout{Columns: tt.columns})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("NewFixedReader() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// readAllFixed 按布局读取全部定长记录
+func readAllFixed(t *testing.T, statement string, layout FixedLayout) ([]BillRecord, error) {
+	t.Helper()
+	reader, err := NewFixedReader(strings.NewReader(statement), layout)
+	if err != nil {
+		t.Fatalf("NewFixedReader() error = %v", err)
+	}
+	return readAll(reader)
+}
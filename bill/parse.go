@@ -0,0 +1,215 @@
+package bill
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Reader 对账单记录读取器
+// 逐条读取交易记录，不会将整个对账单文件载入内存
+type Reader interface {
+	// Read 读取下一条交易记录，没有更多记录时返回 io.EOF
+	Read() (*BillRecord, error)
+}
+
+// ParseStatement 解析 CSV 格式的对账单，返回全部交易记录
+// 对账单较大时建议使用 NewCSVReader 逐条读取
+//
+// 参数:
+//   - r: 对账单内容，例如 haozpay.Statement
+//
+// 返回:
+//   - []BillRecord: 交易记录
+//   - error: 格式错误时返回错误，错误信息包含行号
+func ParseStatement(r io.Reader) ([]BillRecord, error) {
+	return readAll(NewCSVReader(r))
+}
+
+// readAll 读取全部交易记录
+func readAll(reader Reader) ([]BillRecord, error) {
+	var records []BillRecord
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, *record)
+	}
+}
+
+// CSVReader CSV 格式对账单读取器
+// 通过 NewCSVReader 函数创建实例
+//
+// 对账单格式:
+//   - 第一行为表头，按列名识别字段，列顺序不限，未知列忽略
+//   - 以 # 开头的行为注释或汇总信息，解析时跳过
+//   - 字段值可以带有 ` 前缀（防止表格软件将长数字转为科学计数法），解析时去除
+type CSVReader struct {
+	reader  *csv.Reader
+	columns []field
+	err     error
+}
+
+// NewCSVReader 创建 CSV 格式对账单读取器
+//
+// 参数:
+//   - r: 对账单内容，例如 haozpay.Statement
+//
+// 返回:
+//   - *CSVReader: 对账单读取器
+//
+// 示例:
+//
+//	reader := bill.NewCSVReader(statement)
+//	for {
+//	    record, err := reader.Read()
+//	    if err == io.EOF {
+//	        break
+//	    }
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(record.MerchantOrderNo, record.Amount)
+//	}
+func NewCSVReader(r io.Reader) *CSVReader {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	return &CSVReader{reader: reader}
+}
+
+// Read 读取下一条交易记录，没有更多记录时返回 io.EOF
+// 发生错误后再次调用返回同一错误
+func (r *CSVReader) Read() (*BillRecord, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	record, err := r.read()
+	if err != nil {
+		r.err = err
+	}
+	return record, err
+}
+
+func (r *CSVReader) read() (*BillRecord, error) {
+	if r.columns == nil {
+		header, err := r.reader.Read()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read statement header: %w", err)
+		}
+		columns, err := parseHeader(header)
+		if err != nil {
+			return nil, err
+		}
+		r.columns = columns
+	}
+
+	for {
+		row, err := r.reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read statement: %w", err)
+		}
+		if isBlankRow(row) {
+			continue
+		}
+
+		line, _ := r.reader.FieldPos(0)
+		if len(row) < len(r.columns) {
+			return nil, fmt.Errorf("statement line %d: expected %d fields, got %d", line, len(r.columns), len(row))
+		}
+
+		record := &BillRecord{}
+		for i, f := range r.columns {
+			if f < 0 {
+				continue
+			}
+			if err := record.setField(f, cleanValue(row[i])); err != nil {
+				return nil, fmt.Errorf("statement line %d: %w", line, err)
+			}
+		}
+		return record, nil
+	}
+}
+
+// parseHeader 解析表头，返回每一列对应的字段，未知列为 -1
+func parseHeader(header []string) ([]field, error) {
+	columns := make([]field, len(header))
+	found := make(map[field]bool)
+	for i, name := range header {
+		f, ok := lookupField(name)
+		if !ok {
+			columns[i] = -1
+			continue
+		}
+		columns[i] = f
+		found[f] = true
+	}
+
+	if err := checkRequiredFields(found); err != nil {
+		return nil, fmt.Errorf("statement header is missing required column: %w", err)
+	}
+	return columns, nil
+}
+
+// lookupField 按列名查找字段，忽略大小写、首尾空白和 UTF-8 BOM
+func lookupField(name string) (field, bool) {
+	name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))
+	f, ok := fieldNames[strings.ToLower(name)]
+	return f, ok
+}
+
+// requiredFields 对账必需的字段
+var requiredFields = []field{fieldTradeType, fieldMerchantOrderNo, fieldAmount}
+
+// checkRequiredFields 检查必需字段是否齐全，缺失时返回缺失字段的英文字段名
+func checkRequiredFields(found map[field]bool) error {
+	for _, required := range requiredFields {
+		if !found[required] {
+			return errors.New(requiredColumnName(required))
+		}
+	}
+	return nil
+}
+
+// requiredColumnName 返回必需列的英文字段名，用于错误信息
+func requiredColumnName(f field) string {
+	switch f {
+	case fieldTradeType:
+		return "tradeType"
+	case fieldMerchantOrderNo:
+		return "merchantOrderNo"
+	case fieldAmount:
+		return "amount"
+	}
+	return fmt.Sprintf("field(%d)", int(f))
+}
+
+// cleanValue 去除字段值的空白和 ` 前缀
+func cleanValue(value string) string {
+	return strings.TrimPrefix(strings.TrimSpace(value), "`")
+}
+
+// isBlankRow 判断是否为空行
+func isBlankRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
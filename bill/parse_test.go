@@ -0,0 +1,108 @@
+package bill
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+func TestCSVReader(t *testing.T) {
+	// 表头带 UTF-8 BOM，列顺序与默认不同，包含未知列、注释行、空行、引号字段和 ` 前缀
+	const statement = "\ufeff商户订单号,交易类型,unknown,amount,feeAmount,tradeTime,交易流水号,remark\n" +
+		"# 2024-01-01 交易明细\n" +
+		"`M1,PAY,x,12.30,0.07,2024-01-01 10:00:00,`2024010100001,\"a, \"\"quoted\"\" remark\"\n" +
+		"\n" +
+		"M1,退款,x,0.5,,2024-01-01 11:00:00,R1,\n" +
+		"# 合计: 2 笔\n"
+
+	reader := NewCSVReader(strings.NewReader(statement))
+	first, err := reader.Read()
+	if err != nil {
+		t.Fatalf("first Read() error = %v", err)
+	}
+	wantTime := time.Date(2024, 1, 1, 10, 0, 0, 0, Location)
+	if first.MerchantOrderNo != "M1" || first.TradeType != TradeTypePayment || first.SeqId != "2024010100001" || !first.TradeTime.Equal(wantTime) {
+		t.Errorf("first record = %+v", first)
+	}
+	if first.Amount != haozpay.Fen(1230) || first.FeeAmount != haozpay.Fen(7) {
+		t.Errorf("first amount/fee = %s/%s", first.Amount, first.FeeAmount)
+	}
+	if first.Remark != `a, "quoted" remark` {
+		t.Errorf("first remark = %q", first.Remark)
+	}
+
+	second, err := reader.Read()
+	if err != nil {
+		t.Fatalf("second Read() error = %v", err)
+	}
+	if second.TradeType != TradeTypeRefund || second.SeqId != "R1" || second.Amount != haozpay.Fen(50) || second.FeeAmount != 0 {
+		t.Errorf("second record = %+v", second)
+	}
+
+	// 最后一条记录之后返回 io.EOF，再次调用仍返回 io.EOF
+	for i := 0; i < 2; i++ {
+		if record, err := reader.Read(); !errors.Is(err, io.EOF) {
+			t.Fatalf("Read() after last record = %+v, %v, want io.EOF", record, err)
+		}
+	}
+}
+
+func TestCSVReaderEmpty(t *testing.T) {
+	for _, statement := range []string{"", "tradeType,merchantOrderNo,amount\n", "tradeType,merchantOrderNo,amount\n# 无交易\n"} {
+		if _, err := NewCSVReader(strings.NewReader(statement)).Read(); !errors.Is(err, io.EOF) {
+			t.Errorf("Read(%q) error = %v, want io.EOF", statement, err)
+		}
+	}
+}
+
+func TestCSVReaderErrors(t *testing.T) {
+	const header = "tradeType,merchantOrderNo,amount,tradeTime\n"
+	tests := []struct {
+		name      string
+		statement string
+		// want 错误信息应包含的文本
+		want string
+	}{
+		{name: "missing required column", statement: "tradeType,amount\nPAY,1.00\n", want: "merchantOrderNo"},
+		{name: "too few fields", statement: header + "PAY,M1,1.00,2024-01-01 10:00:00\nPAY,M2\n", want: "line 3: expected 4 fields, got 2"},
+		{name: "unterminated quote", statement: header + "PAY,\"M1,1.00,\n", want: "failed to read statement"},
+		{name: "bare quote", statement: header + "PAY,M\"1,1.00,\n", want: "failed to read statement"},
+		{name: "too many decimal places", statement: header + "PAY,M1,1.001,\n", want: "line 2"},
+		{name: "amount is not a number", statement: header + "PAY,M1,abc,\n", want: "line 2"},
+		{name: "unknown trade type", statement: header + "CHARGE,M1,1.00,\n", want: "invalid trade type"},
+		{name: "invalid trade time", statement: header + "PAY,M1,1.00,2024/01/01\n", want: "invalid trade time"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := readAll(NewCSVReader(strings.NewReader(tt.statement)))
+			if err == nil {
+				t.Fatalf("readAll() = %+v, want error", records)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+
+	// 发生错误后再次调用返回同一错误，不会跳过错误行继续读取
+	reader := NewCSVReader(strings.NewReader(header + "PAY,M1,abc,\nPAY,M2,1.00,\n"))
+	_, first := reader.Read()
+	if _, again := reader.Read(); first == nil || again != first {
+		t.Errorf("Read() after error = %v, want %v", again, first)
+	}
+}
+
+func TestParseStatement(t *testing.T) {
+	records, err := ParseStatement(strings.NewReader("tradeType,merchantOrderNo,amount\r\nPAY,M1,1.00\r\nREFUND,M1,0.30\r\n"))
+	if err != nil {
+		t.Fatalf("ParseStatement() error = %v", err)
+	}
+	if len(records) != 2 || records[0].Amount != haozpay.Fen(100) || records[1].TradeType != TradeTypeRefund {
+		t.Errorf("records = %+v", records)
+	}
+}
@@ -0,0 +1,348 @@
+package bill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+// LocalOrder 本地交易记录
+// 支付记录按商户订单号与对账单匹配，退款记录按平台退款流水号匹配
+type LocalOrder struct {
+	// TradeType 交易类型
+	TradeType TradeType
+	// MerchantOrderNo 商户订单号
+	MerchantOrderNo string
+	// SeqId 平台退款流水号，退款记录必填（CreateRefund 返回的 SeqId）
+	SeqId string
+	// Amount 交易金额
+	Amount haozpay.Money
+}
+
+// Key 返回记录的对账键，与 BillRecord.Key 规则一致
+func (o *LocalOrder) Key() string {
+	return recordKey(o.TradeType, o.MerchantOrderNo, o.SeqId)
+}
+
+// LocalOrderIterator 本地交易记录迭代器
+// 由调用方实现，通常按账单日期分页查询本地数据库
+type LocalOrderIterator interface {
+	// Next 返回下一条本地交易记录，没有更多记录时返回 io.EOF
+	Next(ctx context.Context) (*LocalOrder, error)
+}
+
+// SliceOrders 使用切片创建本地交易记录迭代器
+func SliceOrders(orders []LocalOrder) LocalOrderIterator {
+	return &sliceIterator{orders: orders}
+}
+
+// sliceIterator 基于切片的本地交易记录迭代器
+type sliceIterator struct {
+	orders []LocalOrder
+	index  int
+}
+
+func (it *sliceIterator) Next(ctx context.Context) (*LocalOrder, error) {
+	if it.index >= len(it.orders) {
+		return nil, io.EOF
+	}
+	order := &it.orders[it.index]
+	it.index++
+	return order, nil
+}
+
+// DiffType 对账差异类型
+type DiffType int
+
+const (
+	// DiffMissingLocal 平台有记录，本地缺失（例如回调丢失导致本地未记账）
+	DiffMissingLocal DiffType = 0
+	// DiffMissingRemote 本地有记录，平台对账单缺失（例如本地误记账或跨日交易）
+	DiffMissingRemote DiffType = 1
+	// DiffAmountMismatch 双方均有记录，但金额不一致
+	DiffAmountMismatch DiffType = 2
+	// DiffStatusMismatch 双方均有记录，但本地订单未处于支付成功状态（仅 ReconcileStore）
+	DiffStatusMismatch DiffType = 3
+)
+
+// diffTypeNames 差异类型名称
+var diffTypeNames = map[DiffType]string{
+	DiffMissingLocal:   "本地缺失",
+	DiffMissingRemote:  "平台缺失",
+	DiffAmountMismatch: "金额不一致",
+	DiffStatusMismatch: "状态不一致",
+}
+
+// String 返回差异类型名称，未知类型返回 DiffType(n)
+func (t DiffType) String() string {
+	if name, ok := diffTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("DiffType(%d)", int(t))
+}
+
+// Diff 对账差异
+type Diff struct {
+	// Type 差异类型
+	Type DiffType
+	// Key 对账键
+	Key string
+	// Remote 平台对账单记录，DiffMissingRemote 时为 nil
+	Remote *BillRecord
+	// Local 本地交易记录，DiffMissingLocal 时为 nil；ReconcileStore 生成的差异为 nil
+	Local *LocalOrder
+	// Snapshot 本地订单快照，仅 ReconcileStore 生成的 DiffAmountMismatch 和 DiffStatusMismatch 差异返回
+	Snapshot *haozpay.OrderSnapshot
+}
+
+// Report 对账报告
+type Report struct {
+	// RemoteCount 对账单记录数
+	RemoteCount int
+	// RemoteAmount 对账单交易金额合计（退款为负）
+	RemoteAmount haozpay.Money
+	// LocalCount 本地记录数
+	LocalCount int
+	// LocalAmount 本地交易金额合计（退款为负）
+	LocalAmount haozpay.Money
+	// MatchedCount 金额一致的记录数
+	MatchedCount int
+	// Diffs 差异明细，按差异类型和对账键排序
+	Diffs []Diff
+}
+
+// Balanced 判断对账是否平账（没有任何差异）
+func (r *Report) Balanced() bool {
+	return len(r.Diffs) == 0
+}
+
+// DiffsOf 返回指定类型的差异
+func (r *Report) DiffsOf(diffType DiffType) []Diff {
+	var diffs []Diff
+	for _, diff := range r.Diffs {
+		if diff.Type == diffType {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
+
+// Reconcile 将对账单记录与本地交易记录逐条比对，生成对账报告
+//
+// 对账单记录全部载入内存建立索引，本地记录通过迭代器逐条读取，
+// 因此本地记录量较大时也不会占用过多内存
+//
+// 参数:
+//   - ctx: 上下文，传给本地记录迭代器
+//   - records: 对账单记录，通常来自 ParseStatement
+//   - local: 本地交易记录迭代器
+//
+// 返回:
+//   - *Report: 对账报告
+//   - error: 对账单存在重复记录或迭代本地记录失败时返回错误
+//
+// 示例:
+//
+//	statement, err := client.Bill.DownloadStatement(ctx, billDate, haozpay.BillTypeTrade)
+//	if err != nil {
+//	    return err
+//	}
+//	defer statement.Close()
+//
+//	records, err := bill.ParseStatement(statement)
+//	if err != nil {
+//	    return err
+//	}
+//	report, err := bill.Reconcile(ctx, records, localOrders)
+//	if err != nil {
+//	    return err
+//	}
+//	for _, diff := range report.Diffs {
+//	    log.Printf("%s: %s", diff.Type, diff.Key)
+//	}
+func Reconcile(ctx context.Context, records []BillRecord, local LocalOrderIterator) (*Report, error) {
+	return ReconcileReader(ctx, &sliceReader{records: records}, local)
+}
+
+// ReconcileReader 从对账单读取器逐条读取记录并与本地交易记录比对，生成对账报告
+// 规则与 Reconcile 一致，适用于 NewCSVReader、NewFixedReader 等流式读取器
+//
+// 示例:
+//
+//	report, err := bill.ReconcileReader(ctx, bill.NewCSVReader(statement), localOrders)
+func ReconcileReader(ctx context.Context, reader Reader, local LocalOrderIterator) (*Report, error) {
+	report := &Report{}
+
+	remote := make(map[string]*BillRecord)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		key := record.Key()
+		if _, ok := remote[key]; ok {
+			return nil, fmt.Errorf("duplicate statement record: %s", key)
+		}
+		remote[key] = record
+		report.RemoteCount++
+		report.RemoteAmount += signedAmount(record.TradeType, record.Amount)
+	}
+
+	matched := make(map[string]bool, len(remote))
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		order, err := local.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read local orders: %w", err)
+		}
+
+		report.LocalCount++
+		report.LocalAmount += signedAmount(order.TradeType, order.Amount)
+
+		key := order.Key()
+		record, ok := remote[key]
+		if !ok || matched[key] {
+			report.Diffs = append(report.Diffs, Diff{Type: DiffMissingRemote, Key: key, Local: order})
+			continue
+		}
+		matched[key] = true
+
+		if record.Amount != order.Amount {
+			report.Diffs = append(report.Diffs, Diff{Type: DiffAmountMismatch, Key: key, Remote: record, Local: order})
+			continue
+		}
+		report.MatchedCount++
+	}
+
+	for key, record := range remote {
+		if !matched[key] {
+			report.Diffs = append(report.Diffs, Diff{Type: DiffMissingLocal, Key: key, Remote: record})
+		}
+	}
+
+	sortDiffs(report.Diffs)
+	return report, nil
+}
+
+// sortDiffs 按差异类型和对账键排序
+func sortDiffs(diffs []Diff) {
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Type != diffs[j].Type {
+			return diffs[i].Type < diffs[j].Type
+		}
+		return diffs[i].Key < diffs[j].Key
+	})
+}
+
+// ReconcileStore 将对账单的支付记录与 OrderStore 中的本地订单快照逐条比对，生成对账报告
+// 适用于本地订单状态由 WaitForPayment 或回调处理器保存到 OrderStore 的场景
+//
+// 比对规则:
+//   - 按平台订单号读取快照，不存在时为 DiffMissingLocal
+//   - 快照的订单状态不是支付成功（OrderStatus.IsSuccess）时为 DiffStatusMismatch
+//   - 快照的实付金额与对账单金额不一致时为 DiffAmountMismatch
+//
+// OrderStore 只能按订单号读取，无法遍历本地订单，因此不会生成 DiffMissingRemote 差异；
+// 退款记录不参与比对，也不计入报告的记录数和金额；LocalCount 和 LocalAmount 为找到快照的记录数和实付金额合计
+//
+// 参数:
+//   - ctx: 上下文，传给 OrderStore
+//   - reader: 对账单读取器，例如 NewCSVReader
+//   - store: 本地订单快照存储
+//
+// 返回:
+//   - *Report: 对账报告
+//   - error: 读取对账单或快照失败时返回错误
+//
+// 示例:
+//
+//	report, err := bill.ReconcileStore(ctx, bill.NewCSVReader(statement), orderStore)
+func ReconcileStore(ctx context.Context, reader Reader, store haozpay.OrderStore) (*Report, error) {
+	report := &Report{}
+
+	seen := make(map[string]bool)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if record.TradeType != TradeTypePayment {
+			continue
+		}
+
+		key := record.Key()
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate statement record: %s", key)
+		}
+		seen[key] = true
+		report.RemoteCount++
+		report.RemoteAmount += record.Amount
+
+		snapshot, err := store.GetOrder(ctx, record.OrderNo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get local order %s: %w", record.OrderNo, err)
+		}
+		if snapshot == nil {
+			report.Diffs = append(report.Diffs, Diff{Type: DiffMissingLocal, Key: key, Remote: record})
+			continue
+		}
+		report.LocalCount++
+		report.LocalAmount += snapshot.PaidAmount
+
+		switch {
+		case !snapshot.OrderStatus.IsSuccess():
+			report.Diffs = append(report.Diffs, Diff{Type: DiffStatusMismatch, Key: key, Remote: record, Snapshot: snapshot})
+		case snapshot.PaidAmount != record.Amount:
+			report.Diffs = append(report.Diffs, Diff{Type: DiffAmountMismatch, Key: key, Remote: record, Snapshot: snapshot})
+		default:
+			report.MatchedCount++
+		}
+	}
+
+	sortDiffs(report.Diffs)
+	return report, nil
+}
+
+// sliceReader 基于切片的对账单读取器
+type sliceReader struct {
+	records []BillRecord
+	index   int
+}
+
+func (r *sliceReader) Read() (*BillRecord, error) {
+	if r.index >= len(r.records) {
+		return nil, io.EOF
+	}
+	record := &r.records[r.index]
+	r.index++
+	return record, nil
+}
+
+// signedAmount 返回带方向的金额，退款为负
+func signedAmount(tradeType TradeType, amount haozpay.Money) haozpay.Money {
+	if tradeType == TradeTypeRefund {
+		return -amount
+	}
+	return amount
+}
@@ -0,0 +1,241 @@
+package bill
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+// payment 返回按商户订单号对账的支付记录
+func payment(merchantOrderNo string, amount int64) BillRecord {
+	return BillRecord{TradeType: TradeTypePayment, OrderNo: "P" + merchantOrderNo, MerchantOrderNo: merchantOrderNo, Amount: haozpay.Fen(amount)}
+}
+
+// refund 返回按平台退款流水号对账的退款记录，金额为正数
+func refund(merchantOrderNo, seqId string, amount int64) BillRecord {
+	return BillRecord{TradeType: TradeTypeRefund, OrderNo: "P" + merchantOrderNo, MerchantOrderNo: merchantOrderNo, SeqId: seqId, Amount: haozpay.Fen(amount)}
+}
+
+// diffKeys 返回差异的类型和对账键，便于比较
+func diffKeys(diffs []Diff) []string {
+	keys := make([]string, 0, len(diffs))
+	for _, diff := range diffs {
+		keys = append(keys, diff.Type.String()+" "+diff.Key)
+	}
+	return keys
+}
+
+func TestReconcileReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		remote  []BillRecord
+		local   []LocalOrder
+		want    []string
+		matched int
+		// remoteAmount、localAmount 退款为负的金额合计
+		remoteAmount int64
+		localAmount  int64
+		wantErr      bool
+	}{
+		{
+			name:   "balanced payment and refund",
+			remote: []BillRecord{payment("M1", 1000), refund("M1", "R1", 300)},
+			local: []LocalOrder{
+				{TradeType: TradeTypePayment, MerchantOrderNo: "M1", Amount: haozpay.Fen(1000)},
+				{TradeType: TradeTypeRefund, MerchantOrderNo: "M1", SeqId: "R1", Amount: haozpay.Fen(300)},
+			},
+			want:         []string{},
+			matched:      2,
+			remoteAmount: 700,
+			localAmount:  700,
+		},
+		{
+			name:         "missing locally",
+			remote:       []BillRecord{payment("M1", 1000), payment("M2", 500)},
+			local:        []LocalOrder{{TradeType: TradeTypePayment, MerchantOrderNo: "M1", Amount: haozpay.Fen(1000)}},
+			want:         []string{"本地缺失 payment:M2"},
+			matched:      1,
+			remoteAmount: 1500,
+			localAmount:  1000,
+		},
+		{
+			name:   "missing remotely",
+			remote: []BillRecord{payment("M1", 1000)},
+			local: []LocalOrder{
+				{TradeType: TradeTypePayment, MerchantOrderNo: "M1", Amount: haozpay.Fen(1000)},
+				{TradeType: TradeTypeRefund, MerchantOrderNo: "M1", SeqId: "R1", Amount: haozpay.Fen(200)},
+			},
+			want:         []string{"平台缺失 refund:R1"},
+			matched:      1,
+			remoteAmount: 1000,
+			localAmount:  800,
+		},
+		{
+			name:         "amount mismatch",
+			remote:       []BillRecord{payment("M1", 1000)},
+			local:        []LocalOrder{{TradeType: TradeTypePayment, MerchantOrderNo: "M1", Amount: haozpay.Fen(900)}},
+			want:         []string{"金额不一致 payment:M1"},
+			remoteAmount: 1000,
+			localAmount:  900,
+		},
+		{
+			name:   "refund is matched by SeqId, not by merchant order number",
+			remote: []BillRecord{refund("M1", "R1", 300)},
+			local:  []LocalOrder{{TradeType: TradeTypeRefund, MerchantOrderNo: "M1", SeqId: "R2", Amount: haozpay.Fen(300)}},
+			want:   []string{"本地缺失 refund:R1", "平台缺失 refund:R2"},
+			// 双方的退款均计为负数
+			remoteAmount: -300,
+			localAmount:  -300,
+		},
+		{
+			name:   "duplicate local order is missing remotely",
+			remote: []BillRecord{payment("M1", 1000)},
+			local: []LocalOrder{
+				{TradeType: TradeTypePayment, MerchantOrderNo: "M1", Amount: haozpay.Fen(1000)},
+				{TradeType: TradeTypePayment, MerchantOrderNo: "M1", Amount: haozpay.Fen(1000)},
+			},
+			want:         []string{"平台缺失 payment:M1"},
+			matched:      1,
+			remoteAmount: 1000,
+			localAmount:  2000,
+		},
+		{
+			name:    "duplicate statement row",
+			remote:  []BillRecord{payment("M1", 1000), payment("M1", 1000)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := ReconcileReader(context.Background(), &sliceReader{records: tt.remote}, SliceOrders(tt.local))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("ReconcileReader() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReconcileReader() error = %v", err)
+			}
+
+			if got := diffKeys(report.Diffs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffs = %q, want %q", got, tt.want)
+			}
+			if report.Balanced() != (len(tt.want) == 0) {
+				t.Errorf("Balanced() = %v", report.Balanced())
+			}
+			if report.RemoteCount != len(tt.remote) || report.LocalCount != len(tt.local) || report.MatchedCount != tt.matched {
+				t.Errorf("counts remote/local/matched = %d/%d/%d", report.RemoteCount, report.LocalCount, report.MatchedCount)
+			}
+			if report.RemoteAmount != haozpay.Fen(tt.remoteAmount) || report.LocalAmount != haozpay.Fen(tt.localAmount) {
+				t.Errorf("amounts remote/local = %s/%s, want %s/%s", report.RemoteAmount, report.LocalAmount, haozpay.Fen(tt.remoteAmount), haozpay.Fen(tt.localAmount))
+			}
+		})
+	}
+}
+
+func TestReconcileStore(t *testing.T) {
+	ctx := context.Background()
+	store := haozpay.NewMemoryOrderStore()
+	for _, snapshot := range []haozpay.OrderSnapshot{
+		{OrderNo: "PM1", OrderStatus: haozpay.OrderStatusPaid, PaidAmount: haozpay.Fen(1000)},
+		{OrderNo: "PM2", OrderStatus: haozpay.OrderStatusPaying, PaidAmount: haozpay.Fen(500)},
+		{OrderNo: "PM3", OrderStatus: haozpay.OrderStatusPaid, PaidAmount: haozpay.Fen(200)},
+		{OrderNo: "PM4", OrderStatus: haozpay.OrderStatusRefunded, PaidAmount: haozpay.Fen(800)},
+	} {
+		snapshot := snapshot
+		if err := store.SaveOrder(ctx, &snapshot); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		remote  []BillRecord
+		want    []string
+		matched int
+		wantErr bool
+	}{
+		{
+			name:    "paid and refunded orders match",
+			remote:  []BillRecord{payment("M1", 1000), payment("M4", 800)},
+			want:    []string{},
+			matched: 2,
+		},
+		{
+			name:   "missing locally",
+			remote: []BillRecord{payment("M9", 100)},
+			want:   []string{"本地缺失 payment:M9"},
+		},
+		{
+			name:   "status mismatch",
+			remote: []BillRecord{payment("M2", 500)},
+			want:   []string{"状态不一致 payment:M2"},
+		},
+		{
+			name:   "amount mismatch",
+			remote: []BillRecord{payment("M3", 300)},
+			want:   []string{"金额不一致 payment:M3"},
+		},
+		{
+			name:    "refund rows are skipped",
+			remote:  []BillRecord{payment("M1", 1000), refund("M1", "R1", 300)},
+			want:    []string{},
+			matched: 1,
+		},
+		{
+			name:    "duplicate statement row",
+			remote:  []BillRecord{payment("M1", 1000), payment("M1", 1000)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := ReconcileStore(ctx, &sliceReader{records: tt.remote}, store)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("ReconcileStore() succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReconcileStore() error = %v", err)
+			}
+
+			if got := diffKeys(report.Diffs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffs = %q, want %q", got, tt.want)
+			}
+			if report.MatchedCount != tt.matched {
+				t.Errorf("MatchedCount = %d, want %d", report.MatchedCount, tt.matched)
+			}
+			for _, diff := range report.Diffs {
+				if diff.Type != DiffMissingLocal && diff.Snapshot == nil {
+					t.Errorf("%s diff has no snapshot", diff.Type)
+				}
+			}
+		})
+	}
+}
+
+func TestSignedAmount(t *testing.T) {
+	tests := []struct {
+		tradeType TradeType
+		amount    int64
+		want      int64
+	}{
+		{tradeType: TradeTypePayment, amount: 1230, want: 1230},
+		{tradeType: TradeTypeRefund, amount: 1230, want: -1230},
+		// 只按交易类型取反，不检查金额本身的符号
+		{tradeType: TradeTypeRefund, amount: -1230, want: 1230},
+		{tradeType: TradeTypeRefund, amount: 0, want: 0},
+	}
+	for _, tt := range tests {
+		if got := signedAmount(tt.tradeType, haozpay.Fen(tt.amount)); got != haozpay.Fen(tt.want) {
+			t.Errorf("signedAmount(%s, %d) = %d, want %d", tt.tradeType, tt.amount, int64(got), tt.want)
+		}
+	}
+}
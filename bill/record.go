@@ -0,0 +1,198 @@
+// Package bill 提供对账单解析和对账功能
+//
+// 对账单通过 haozpay.BillService.DownloadStatement 下载，
+// 使用 NewCSVReader 或 NewFixedReader 逐条解析为 BillRecord 记录，
+// 再通过 ReconcileReader 与本地订单比对生成差异报告
+package bill
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+// TimeLayout 对账单中交易时间的格式
+const TimeLayout = "2006-01-02 15:04:05"
+
+// Location 对账单交易时间所在时区（北京时间）
+var Location = time.FixedZone("CST", 8*60*60)
+
+// TradeType 对账单交易类型
+type TradeType int
+
+const (
+	// TradeTypePayment 支付
+	TradeTypePayment TradeType = 0
+	// TradeTypeRefund 退款
+	TradeTypeRefund TradeType = 1
+)
+
+// tradeTypeNames 交易类型名称
+var tradeTypeNames = map[TradeType]string{
+	TradeTypePayment: "支付",
+	TradeTypeRefund:  "退款",
+}
+
+// String 返回交易类型名称，未知类型返回 TradeType(n)
+func (t TradeType) String() string {
+	if name, ok := tradeTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("TradeType(%d)", int(t))
+}
+
+// ParseTradeType 解析对账单中的交易类型
+// 支持数字（0、1）、英文（PAY、PAYMENT、REFUND）和中文（支付、退款）
+func ParseTradeType(s string) (TradeType, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "0", "PAY", "PAYMENT", "支付":
+		return TradeTypePayment, nil
+	case "1", "REFUND", "退款":
+		return TradeTypeRefund, nil
+	}
+	return 0, fmt.Errorf("invalid trade type %q", s)
+}
+
+// BillRecord 对账单交易记录
+type BillRecord struct {
+	// TradeTime 交易时间
+	TradeTime time.Time
+	// TradeType 交易类型
+	TradeType TradeType
+	// OrderNo 平台订单号
+	OrderNo string
+	// MerchantOrderNo 商户订单号
+	MerchantOrderNo string
+	// SeqId 平台交易流水号，退款记录为平台退款流水号
+	SeqId string
+	// PayType 支付方式
+	PayType haozpay.PayType
+	// Amount 交易金额
+	Amount haozpay.Money
+	// FeeAmount 手续费
+	FeeAmount haozpay.Money
+	// TradeStatus 交易状态
+	TradeStatus string
+	// Remark 备注
+	Remark string
+}
+
+// Key 返回记录的对账键
+// 支付记录使用商户订单号，退款记录使用平台退款流水号
+func (r *BillRecord) Key() string {
+	return recordKey(r.TradeType, r.MerchantOrderNo, r.SeqId)
+}
+
+// recordKey 构建对账键，交易类型作为前缀避免支付和退款的编号冲突
+func recordKey(tradeType TradeType, merchantOrderNo, seqId string) string {
+	if tradeType == TradeTypeRefund {
+		return "refund:" + seqId
+	}
+	return "payment:" + merchantOrderNo
+}
+
+// field 对账单字段
+type field int
+
+const (
+	fieldTradeTime field = iota
+	fieldTradeType
+	fieldOrderNo
+	fieldMerchantOrderNo
+	fieldSeqId
+	fieldPayType
+	fieldAmount
+	fieldFeeAmount
+	fieldTradeStatus
+	fieldRemark
+)
+
+// fieldNames 对账单表头名称，同时支持英文字段名和中文列名
+var fieldNames = map[string]field{
+	"tradetime":       fieldTradeTime,
+	"交易时间":            fieldTradeTime,
+	"tradetype":       fieldTradeType,
+	"交易类型":            fieldTradeType,
+	"orderno":         fieldOrderNo,
+	"平台订单号":           fieldOrderNo,
+	"merchantorderno": fieldMerchantOrderNo,
+	"商户订单号":           fieldMerchantOrderNo,
+	"seqid":           fieldSeqId,
+	"交易流水号":           fieldSeqId,
+	"paytype":         fieldPayType,
+	"支付方式":            fieldPayType,
+	"amount":          fieldAmount,
+	"交易金额":            fieldAmount,
+	"feeamount":       fieldFeeAmount,
+	"手续费":             fieldFeeAmount,
+	"tradestatus":     fieldTradeStatus,
+	"交易状态":            fieldTradeStatus,
+	"remark":          fieldRemark,
+	"备注":              fieldRemark,
+}
+
+// setField 解析字段值并写入记录
+func (r *BillRecord) setField(f field, value string) error {
+	value = strings.TrimSpace(value)
+
+	switch f {
+	case fieldTradeTime:
+		if value == "" {
+			return nil
+		}
+		t, err := time.ParseInLocation(TimeLayout, value, Location)
+		if err != nil {
+			return fmt.Errorf("invalid trade time %q", value)
+		}
+		r.TradeTime = t
+	case fieldTradeType:
+		tradeType, err := ParseTradeType(value)
+		if err != nil {
+			return err
+		}
+		r.TradeType = tradeType
+	case fieldOrderNo:
+		r.OrderNo = value
+	case fieldMerchantOrderNo:
+		r.MerchantOrderNo = value
+	case fieldSeqId:
+		r.SeqId = value
+	case fieldPayType:
+		if value == "" {
+			return nil
+		}
+		payType, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid pay type %q", value)
+		}
+		r.PayType = haozpay.PayType(payType)
+	case fieldAmount:
+		amount, err := parseAmount(value)
+		if err != nil {
+			return err
+		}
+		r.Amount = amount
+	case fieldFeeAmount:
+		amount, err := parseAmount(value)
+		if err != nil {
+			return err
+		}
+		r.FeeAmount = amount
+	case fieldTradeStatus:
+		r.TradeStatus = value
+	case fieldRemark:
+		r.Remark = value
+	}
+	return nil
+}
+
+// parseAmount 解析金额，空值视为 0
+func parseAmount(value string) (haozpay.Money, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return haozpay.ParseMoney(value)
+}
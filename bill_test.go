@@ -0,0 +1,105 @@
+package haozpay_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+// fileServer 记录每次下载请求携带的文件令牌的文件服务器
+type fileServer struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+func newFileServer(t *testing.T, handler func(s *fileServer, w http.ResponseWriter, r *http.Request)) *fileServer {
+	t.Helper()
+	s := &fileServer{tokens: make(map[string]string)}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.tokens[r.URL.Path] = r.Header.Get("X-HaozPay-File-Token")
+		s.mu.Unlock()
+		handler(s, w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// token 返回请求 path 时携带的文件令牌，未收到请求时 ok 为 false
+func (s *fileServer) token(path string) (token string, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok = s.tokens[path]
+	return token, ok
+}
+
+func TestDownloadStatementRedirect(t *testing.T) {
+	const content = "tradeType,merchantOrderNo,amount\n"
+	storage := newFileServer(t, func(s *fileServer, w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, content)
+	})
+	files := newFileServer(t, func(s *fileServer, w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/same-host":
+			http.Redirect(w, r, "/file", http.StatusFound)
+		case "/cross-host":
+			http.Redirect(w, r, storage.URL+"/signed", http.StatusFound)
+		default:
+			_, _ = io.WriteString(w, content)
+		}
+	})
+
+	tests := []struct {
+		name string
+		path string
+		// server、target 最终提供文件的服务器和路径
+		server *fileServer
+		target string
+		// token 最终请求携带的文件令牌
+		token string
+	}{
+		{name: "no redirect", path: "/file", server: files, target: "/file", token: "T1"},
+		{name: "same host redirect keeps the token", path: "/same-host", server: files, target: "/file", token: "T1"},
+		{name: "cross host redirect drops the token", path: "/cross-host", server: storage, target: "/signed", token: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := newTestGateway(t)
+			server.Respond("/pay-core/bill/statement/apply", &haozpay.StatementFileResponse{
+				FileToken:   "T1",
+				DownloadUrl: files.URL + tt.path,
+			})
+
+			statement, err := client.Bill.DownloadStatement(context.Background(), time.Now(), haozpay.BillTypeTrade)
+			if err != nil {
+				t.Fatalf("DownloadStatement() error = %v", err)
+			}
+			defer statement.Close()
+			if data, err := io.ReadAll(statement); err != nil || string(data) != content {
+				t.Fatalf("statement = %q, %v", data, err)
+			}
+
+			token, ok := tt.server.token(tt.target)
+			if !ok {
+				t.Fatalf("%s was not requested", tt.target)
+			}
+			if token != tt.token {
+				t.Errorf("file token sent to %s = %q, want %q", tt.target, token, tt.token)
+			}
+			if tt.path != tt.target {
+				if token, _ := files.token(tt.path); token != "T1" {
+					t.Errorf("file token sent to %s = %q, want %q", tt.path, token, "T1")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package haozpay
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+)
+
+// 收银台语言
+const (
+	// CashierLocaleZhCN 简体中文
+	CashierLocaleZhCN = "zh-CN"
+	// CashierLocaleEnUS 英文
+	CashierLocaleEnUS = "en-US"
+)
+
+// 收银台主题
+const (
+	// CashierThemeLight 浅色主题
+	CashierThemeLight = "light"
+	// CashierThemeDark 深色主题
+	CashierThemeDark = "dark"
+)
+
+// CashierOptions 收银台跳转参数
+// 各字段为空时不添加对应参数，由收银台使用默认值
+type CashierOptions struct {
+	// ReturnURL 支付完成（或用户取消）后跳回的商户页面地址
+	ReturnURL string
+	// Locale 收银台语言，例如 CashierLocaleZhCN
+	Locale string
+	// Theme 收银台主题，例如 CashierThemeLight
+	Theme string
+}
+
+// Cashier 皓臻收银台链接生成器
+// 下单时 UseHaozPayCashier 为 true 时，响应的 PayInfo 为收银台地址，
+// 需要附加跳转参数并签名后才能交给前端跳转
+// 通过 NewCashier 函数创建实例，客户端创建时会自动初始化 Client.Cashier
+type Cashier struct {
+	merchantNo string
+	signer     Signer
+	signType   SignType
+	// state 客户端的当前状态，由客户端创建时不为 nil，使用客户端当前的商户编号和签名器
+	state *atomic.Pointer[clientState]
+}
+
+// NewCashier 创建收银台链接生成器
+//
+// 参数:
+//   - merchantNo: 商户编号
+//   - signer: 请求签名器，与接口请求使用同一签名器
+//   - signType: 签名算法类型
+//
+// 返回:
+//   - *Cashier: 收银台链接生成器
+func NewCashier(merchantNo string, signer Signer, signType SignType) *Cashier {
+	return &Cashier{
+		merchantNo: merchantNo,
+		signer:     signer,
+		signType:   signType,
+	}
+}
+
+// credentials 返回生成链接使用的商户编号、签名器和签名算法类型
+func (c *Cashier) credentials() (string, Signer, SignType) {
+	if c.state != nil {
+		state := c.state.Load()
+		return state.config.MerchantNo, state.signer, state.signType
+	}
+	return c.merchantNo, c.signer, c.signType
+}
+
+// BuildURL 根据下单响应生成签名后的收银台跳转链接
+//
+// 链接在 PayInfo 的基础上附加 merchantNo、seqId、timestamp 和跳转参数，
+// 对全部查询参数（包括 PayInfo 自带的参数）按与接口请求相同的规则签名，签名放在 sign 参数中
+//
+// 参数:
+//   - order: 下单响应，需使用皓臻收银台下单（UseHaozPayCashier 为 true）
+//   - opts: 跳转参数，为 nil 时不添加
+//
+// 返回:
+//   - string: 可直接跳转的收银台链接
+//   - error: PayInfo 为空或不是有效的 URL、签名失败时返回错误
+//
+// 示例:
+//
+//	order, err := client.Payment.CreateOrder(ctx, &haozpay.CreatePaymentOrderRequest{
+//	    OrderTitle:        "测试订单",
+//	    OrderAmount:       haozpay.Fen(100),
+//	    PayType:           haozpay.PayTypeWechat,
+//	    UseHaozPayCashier: true,
+//	    NotifyUrl:         "https://yourdomain.com/callback",
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//
+//	cashierURL, err := client.Cashier.BuildURL(order, &haozpay.CashierOptions{
+//	    ReturnURL: "https://yourdomain.com/orders/" + order.MerchantOrderNo,
+//	    Locale:    haozpay.CashierLocaleZhCN,
+//	})
+func (c *Cashier) BuildURL(order *PaymentOrderResponse, opts *CashierOptions) (string, error) {
+	if order == nil || order.PayInfo == "" {
+		return "", &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "payInfo is required to build cashier url",
+			StatusCode: 0,
+		}
+	}
+
+	cashierURL, err := url.Parse(order.PayInfo)
+	if err != nil || !cashierURL.IsAbs() {
+		return "", &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("payInfo is not a valid cashier url: %s", order.PayInfo),
+			StatusCode: 0,
+		}
+	}
+
+	query := cashierURL.Query()
+	merchantNo, signer, signType := c.credentials()
+	query.Set("merchantNo", merchantNo)
+	query.Set("timestamp", strconv.FormatInt(currentTimestampMillis(), 10))
+	if order.SeqId != "" {
+		query.Set("seqId", order.SeqId)
+	}
+	if opts != nil {
+		setQueryParam(query, "returnUrl", opts.ReturnURL)
+		setQueryParam(query, "locale", opts.Locale)
+		setQueryParam(query, "theme", opts.Theme)
+	}
+	query.Del("sign")
+
+	params := make(map[string]interface{}, len(query))
+	for key := range query {
+		params[key] = query.Get(key)
+	}
+
+	sign, err := GenerateSignWithSignType(params, signer, signType)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign cashier url: %w", err)
+	}
+	query.Set("sign", sign)
+
+	cashierURL.RawQuery = query.Encode()
+	return cashierURL.String(), nil
+}
+
+// setQueryParam 设置查询参数，值为空时跳过
+func setQueryParam(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
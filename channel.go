@@ -0,0 +1,164 @@
+package haozpay
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type ChannelService struct {
+	executor *apiExecutor
+	cache    *channelStatusCache
+}
+
+func NewChannelService(client *resty.Client, config *Config) *ChannelService {
+	return &ChannelService{
+		executor: newAPIExecutor(client, config),
+		cache:    &channelStatusCache{ttl: config.ChannelStatusCacheTTL},
+	}
+}
+
+// QueryChannelStatus 查询各支付渠道的状态和维护计划
+//
+// 配置了 Config.ChannelStatusCacheTTL 时结果会被缓存，缓存有效期内重复查询不会请求平台，
+// 适用于在每次打开收银台时判断支付方式是否可用
+//
+// 参数:
+//   - ctx: 上下文
+//   - opts: 单次调用的请求选项
+//
+// 返回:
+//   - *ChannelStatusResponse: 渠道状态列表，多次调用可能返回同一缓存实例，调用方不应修改
+//   - error: 查询失败时返回错误
+//
+// 示例:
+//
+//	status, err := client.Channel.QueryChannelStatus(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	for _, channel := range status.Channels {
+//	    if channel.Notice != "" {
+//	        log.Printf("%s: %s", channel.PayType, channel.Notice)
+//	    }
+//	}
+func (s *ChannelService) QueryChannelStatus(ctx context.Context, opts ...RequestOption) (*ChannelStatusResponse, error) {
+	if resp, ok := s.cache.get(); ok {
+		return resp, nil
+	}
+
+	var resp *ChannelStatusResponse
+	if err := s.executor.post(ctx, "/pay-core/channel/status/query", &QueryChannelStatusRequest{}, &resp, "failed to query channel status", opts...); err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		resp = &ChannelStatusResponse{}
+	}
+
+	s.cache.set(resp)
+	return resp, nil
+}
+
+// IsChannelAvailable 判断支付方式当前是否可以下单，用于在收银台隐藏维护中的支付方式
+// 渠道状态通过 QueryChannelStatus 查询，遵循相同的缓存策略
+//
+// 参数:
+//   - ctx: 上下文
+//   - payType: 支付方式
+//   - opts: 单次调用的请求选项
+//
+// 返回:
+//   - bool: 渠道状态正常且当前不在维护时间段内时返回 true，平台未返回该支付方式时返回 false
+//   - error: 查询失败时返回错误
+//
+// 示例:
+//
+//	ok, err := client.Channel.IsChannelAvailable(ctx, haozpay.PayTypeUnionPay)
+//	if err == nil && !ok {
+//	    // 隐藏银联支付
+//	}
+func (s *ChannelService) IsChannelAvailable(ctx context.Context, payType PayType, opts ...RequestOption) (bool, error) {
+	resp, err := s.QueryChannelStatus(ctx, opts...)
+	if err != nil {
+		return false, err
+	}
+	return resp.IsChannelAvailable(payType), nil
+}
+
+// Channel 返回指定支付方式的渠道状态，平台未返回该支付方式时返回 nil
+func (r *ChannelStatusResponse) Channel(payType PayType) *ChannelStatus {
+	for i := range r.Channels {
+		if r.Channels[i].PayType == payType {
+			return &r.Channels[i]
+		}
+	}
+	return nil
+}
+
+// IsChannelAvailable 判断支付方式当前是否可以下单，平台未返回该支付方式时返回 false
+func (r *ChannelStatusResponse) IsChannelAvailable(payType PayType) bool {
+	channel := r.Channel(payType)
+	return channel != nil && channel.AvailableAt(time.Now())
+}
+
+// MaintenanceWindow 返回计划维护的起止时间
+// 平台未返回维护计划时 ok 为 false；未返回结束时间时 end 为零值，表示结束时间待定
+func (c *ChannelStatus) MaintenanceWindow() (start, end time.Time, ok bool) {
+	if c.MaintenanceStartTime.IsZero() {
+		return time.Time{}, time.Time{}, false
+	}
+	return c.MaintenanceStartTime.Time, c.MaintenanceEndTime.Time, true
+}
+
+// AvailableAt 判断渠道在指定时间是否可以下单
+// 渠道状态不是正常时不可用；状态正常但 t 落在计划维护时间段内时同样不可用，
+// 可在维护开始前提前隐藏支付方式，例如 t 传入当前时间加上订单的支付超时时间
+func (c *ChannelStatus) AvailableAt(t time.Time) bool {
+	if !c.Status.IsAvailable() {
+		return false
+	}
+	start, end, ok := c.MaintenanceWindow()
+	if !ok || t.Before(start) {
+		return true
+	}
+	return !end.IsZero() && !t.Before(end)
+}
+
+// channelStatusCache 渠道状态缓存
+// ttl 小于等于 0 时不缓存
+type channelStatusCache struct {
+	ttl       time.Duration
+	mu        sync.Mutex
+	resp      *ChannelStatusResponse
+	expiresAt time.Time
+}
+
+// get 返回未过期的缓存渠道状态
+func (c *channelStatusCache) get() (*ChannelStatusResponse, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.resp == nil || time.Now().After(c.expiresAt) {
+		return nil, false
+	}
+	return c.resp, true
+}
+
+// set 缓存渠道状态
+func (c *channelStatusCache) set(resp *ChannelStatusResponse) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.resp = resp
+	c.expiresAt = time.Now().Add(c.ttl)
+}
@@ -1,6 +1,14 @@
 package haozpay
 
 import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
 	"github.com/go-resty/resty/v2"
 )
 
@@ -12,16 +20,89 @@ const (
 )
 
 // Client SDK 客户端，提供皓臻支付业务服务的访问入口
-// 通过 NewClient 函数创建实例
+// 通过 NewClient 函数创建实例，客户端及其业务服务可在多个 goroutine 中并发使用
+//
+// 客户端创建时复制传入的配置，之后修改原配置不影响客户端；
+// 需要使用不同配置（例如不同的超时或商户号）调用接口时，通过 Clone 派生新的客户端，
+// 轮换密钥等需要替换当前客户端配置时，使用 UpdateConfig
 type Client struct {
-	// config SDK 配置信息
-	config *Config
-	// restyClient 底层 HTTP 客户端
-	restyClient *resty.Client
+	// state 由配置派生的客户端状态，业务服务和收银台链接生成器共享，UpdateConfig 时整体替换
+	state *atomic.Pointer[clientState]
+	// updateMu 串行执行 UpdateConfig
+	updateMu sync.Mutex
+	// transport 底层传输层（连接池），Clone 派生的客户端和 UpdateConfig 更新后的状态共享同一传输层
+	transport http.RoundTripper
+	// hooks 接口调用钩子，由所有业务服务共享
+	hooks *callHooks
+	// executor 通用请求执行器，供 Do 调用 SDK 未封装的接口
+	executor *apiExecutor
 
 	// Payment 支付服务，提供皓臻支付相关的 API 操作
 	// 包含统一下单、订单取消、退款、退款查询、账户提现等功能
 	Payment *PaymentService
+
+	// Transfer 转账服务，提供向银行卡、支付宝、微信零钱付款（代付）的 API 操作
+	Transfer *TransferService
+
+	// Bill 账单服务，提供对账单下载等 API 操作
+	Bill *BillService
+
+	// Contract 代扣协议服务，提供委托代扣的签约、查询、解约等 API 操作
+	Contract *ContractService
+
+	// PreAuth 预授权服务，提供押金类业务的资金冻结、扣款、解冻等 API 操作
+	PreAuth *PreAuthService
+
+	// Merchant 子商户服务，提供平台类商户的子商户进件、资质上传、结算账户修改等 API 操作
+	Merchant *MerchantService
+
+	// ExchangeRate 汇率服务，提供跨境结算使用的平台日汇率查询
+	ExchangeRate *ExchangeRateService
+
+	// Channel 渠道服务，提供支付渠道状态和维护计划查询
+	Channel *ChannelService
+
+	// Bank 银行信息服务，提供提现表单使用的银行列表和卡 BIN 查询
+	Bank *BankService
+
+	// Account 账户服务，提供提现银行卡的绑定、解绑、查询和账户流水查询
+	Account *AccountService
+
+	// Invoice 电子发票服务，提供订单发票的开具、红冲和查询
+	Invoice *InvoiceService
+
+	// Marketing 营销服务，提供优惠券活动的创建、查询和订单优惠明细查询
+	Marketing *MarketingService
+
+	// Risk 风控服务，提供订单风险评分和挂起状态查询
+	Risk *RiskService
+
+	// Upload 文件上传服务，以 multipart 格式流式上传资质图片等文件
+	Upload *UploadService
+
+	// Cashier 皓臻收银台链接生成器，将收银台下单返回的 PayInfo 转换为签名后的跳转链接
+	Cashier *Cashier
+}
+
+// clientState 客户端由配置派生的状态
+// 通过 UpdateConfig 更新配置时整体替换，已发出的请求继续使用替换前的状态
+type clientState struct {
+	// config SDK 配置信息，归该状态所有，之后不再修改
+	config *Config
+	// restyClient 底层 HTTP 客户端
+	restyClient *resty.Client
+	// endpoints 多网关地址的切换状态，未配置多个地址时为 nil
+	endpoints *endpointPool
+	// signer 请求签名器，同时用于收银台链接和 App 调起参数的签名；单独创建的业务服务为 nil
+	signer Signer
+	// signType 签名算法类型
+	signType SignType
+	// encryptor 敏感字段加密器
+	encryptor *fieldEncryptor
+	// logger 日志实例
+	logger Logger
+	// codec JSON 编解码器
+	codec Codec
 }
 
 // NewClient 创建并初始化一个新的 SDK 客户端
@@ -34,20 +115,22 @@ type Client struct {
 //   - error: 配置验证失败时返回错误
 //
 // 功能说明:
+//   - 复制配置，之后修改 cfg 不影响已创建的客户端
 //   - 验证配置的有效性
 //   - 创建并配置底层 HTTP 客户端
 //   - 注册请求签名和日志中间件
+//   - 配置了平台公钥时注册响应验签中间件
 //   - 初始化支付服务
 //
 // 示例:
 //
-//	config := sdk.DefaultConfig().
+//	config := haozpay.DefaultConfig().
 //	    WithBaseURL("https://gate.haozpay.com").
 //	    WithMerchantNo("HZ1971294971928846336").
 //	    WithPrivateKey(privateKeyPEM).
 //	    WithPublicKey(platformPublicKeyPEM)
 //
-//	client, err := sdk.NewClient(config)
+//	client, err := haozpay.NewClient(config)
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
@@ -55,67 +138,405 @@ type Client struct {
 //	// 使用客户端调用支付接口
 //	order, err := client.Payment.CreateOrder(ctx, req)
 func NewClient(cfg *Config) (*Client, error) {
+	return newClient(cfg.Clone(), nil, &callHooks{})
+}
+
+// newClient 使用 cfg 创建客户端，cfg 归客户端所有
+// transport 不为 nil 时复用该传输层，不再应用代理和 TLS 配置；hooks 为客户端的调用钩子
+func newClient(cfg *Config, transport http.RoundTripper, hooks *callHooks) (*Client, error) {
+	state, transport, err := newClientState(cfg, transport)
+	if err != nil {
+		return nil, err
+	}
+
+	// 创建客户端实例
+	client := &Client{
+		state:     &atomic.Pointer[clientState]{},
+		transport: transport,
+		hooks:     hooks,
+	}
+	client.state.Store(state)
+	restyClient := state.restyClient
+
+	// 初始化支付服务
+	// PaymentService 提供以下功能：
+	//   - CreateOrder: 统一下单
+	//   - QueryOrder: 订单查询
+	//   - ListOrders: 订单列表（分页迭代）
+	//   - CancelOrder: 订单取消
+	//   - CreateRefund: 退款
+	//   - QueryRefund: 退款查询
+	//   - ListRefunds: 订单退款记录列表
+	//   - CreateDeductOrder: 代扣扣款（需已签约的代扣协议）
+	//   - CreateWithdraw: 账户提现
+	//   - QueryWithdraw: 提现查询
+	//   - ApplyReceipt / QueryReceipt: 电子回单申请和查询
+	//   - DownloadReceipt: 下载订单的电子回单（等待生成完毕后流式写入）
+	client.Payment = NewPaymentService(restyClient, cfg)
+
+	// 初始化转账服务
+	// TransferService 提供以下功能：
+	//   - CreateTransfer: 单笔转账（代付）
+	//   - QueryTransfer: 转账查询
+	//   - CreateBatchTransfer / CreateBatchTransfers: 批量转账
+	//   - QueryBatchTransfer: 批量转账查询（含明细结果）
+	client.Transfer = NewTransferService(restyClient, cfg)
+
+	// 初始化账单服务
+	// BillService 提供以下功能：
+	//   - DownloadStatement: 下载日对账单
+	client.Bill = NewBillService(restyClient, cfg)
+
+	// 初始化代扣协议服务
+	// ContractService 提供以下功能：
+	//   - SignContract: 发起签约
+	//   - QueryContract: 协议查询
+	//   - TerminateContract: 解约
+	client.Contract = NewContractService(restyClient, cfg)
+
+	// 初始化预授权服务
+	// PreAuthService 提供以下功能：
+	//   - CreatePreAuth: 预授权冻结
+	//   - CapturePreAuth: 预授权扣款（冻结转支付）
+	//   - ReleasePreAuth: 预授权解冻
+	//   - QueryPreAuth: 预授权查询（含冻结、已扣款、已解冻金额）
+	client.PreAuth = NewPreAuthService(restyClient, cfg)
+
+	// 初始化子商户服务
+	// MerchantService 提供以下功能：
+	//   - CreateSubMerchant: 提交子商户进件申请
+	//   - UploadQualification: 上传资质材料
+	//   - QuerySubMerchant: 查询进件审核状态
+	//   - ModifySettlementAccount: 修改结算账户
+	//   - QueryConfig: 查询已开通的支付方式、费率和限额
+	client.Merchant = NewMerchantService(restyClient, cfg)
+
+	// 初始化汇率服务
+	// ExchangeRateService 提供以下功能：
+	//   - QueryExchangeRate: 日汇率查询（按 Config.ExchangeRateCacheTTL 缓存）
+	client.ExchangeRate = NewExchangeRateService(restyClient, cfg)
+
+	// 初始化渠道服务
+	// ChannelService 提供以下功能：
+	//   - QueryChannelStatus: 渠道状态和维护计划查询（按 Config.ChannelStatusCacheTTL 缓存）
+	//   - IsChannelAvailable: 判断支付方式当前是否可以下单
+	client.Channel = NewChannelService(restyClient, cfg)
+
+	// 初始化银行信息服务
+	// BankService 提供以下功能：
+	//   - QueryBankList: 支持的银行列表查询（按 Config.BankInfoCacheTTL 缓存）
+	//   - QueryCardBIN: 按卡号前缀识别发卡银行和卡类型（按 Config.BankInfoCacheTTL 缓存）
+	client.Bank = NewBankService(restyClient, cfg)
+
+	// 初始化账户服务
+	// AccountService 提供以下功能：
+	//   - BindWithdrawCard: 绑定提现银行卡（户名、卡号等敏感字段自动加密）
+	//   - UnbindWithdrawCard: 解绑提现银行卡
+	//   - ListBoundCards: 查询已绑定的提现银行卡
+	//   - ListTransactions: 账户流水（分页迭代）
+	client.Account = NewAccountService(restyClient, cfg)
+
+	// 初始化电子发票服务
+	// InvoiceService 提供以下功能：
+	//   - ApplyInvoice: 为已支付的订单开具电子发票
+	//   - RedFlushInvoice: 红冲已开具的发票
+	//   - QueryInvoice: 发票查询
+	client.Invoice = NewInvoiceService(restyClient, cfg)
+
+	// 初始化营销服务
+	// MarketingService 提供以下功能：
+	//   - CreateCouponActivity: 创建代金券、折扣券活动
+	//   - QueryCouponActivity: 优惠活动查询（发放、核销数量）
+	//   - QueryOrderCoupons: 查询订单使用的优惠券和优惠明细
+	client.Marketing = NewMarketingService(restyClient, cfg)
+
+	// 初始化风控服务
+	// RiskService 提供以下功能：
+	//   - QueryOrderRisk: 查询订单的风险评分、命中规则和挂起状态
+	client.Risk = NewRiskService(restyClient, cfg)
+
+	// 初始化文件上传服务
+	// UploadService 提供以下功能：
+	//   - UploadQualification: 以 multipart 格式上传资质材料，文件摘要参与签名，不将整个文件读入内存
+	client.Upload = NewUploadService(restyClient, cfg)
+
+	// 初始化通用请求执行器，供 Do 调用 SDK 未封装的接口
+	client.executor = newAPIExecutor(restyClient, cfg)
+
+	// 业务服务共享客户端的状态和调用钩子（OnBeforeCall / OnAfterCall）
+	for _, executor := range []*apiExecutor{
+		client.Payment.executor,
+		client.Transfer.executor,
+		client.Bill.executor,
+		client.Contract.executor,
+		client.PreAuth.executor,
+		client.Merchant.executor,
+		client.ExchangeRate.executor,
+		client.Channel.executor,
+		client.Bank.executor,
+		client.Account.executor,
+		client.Invoice.executor,
+		client.Marketing.executor,
+		client.Risk.executor,
+		client.Upload.executor,
+		client.executor,
+	} {
+		executor.state = client.state
+		executor.hooks = client.hooks
+	}
+
+	// 初始化收银台链接生成器，与接口请求使用同一签名器和商户编号
+	client.Cashier = &Cashier{state: client.state}
+
+	return client, nil
+}
+
+// newClientState 使用 cfg 创建客户端状态，cfg 归该状态所有
+// transport 不为 nil 时复用该传输层，不再应用代理和 TLS 配置；返回的传输层为状态使用的底层传输层
+func newClientState(cfg *Config, transport http.RoundTripper) (*clientState, http.RoundTripper, error) {
 	// 验证配置的有效性
 	if err := cfg.Validate(); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// 开启了密钥校验时，在本地完成签名和验签，确认密钥配置正确
+	if cfg.ValidateKeysOnStart {
+		if err := cfg.ValidateKeys(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 未配置自定义签名器时，使用商户私钥创建默认签名器
+	// 私钥仅在此处解析一次，之后的请求复用解析结果
+	signType := cfg.signType()
+	signer := cfg.Signer
+	if signer == nil {
+		defaultSigner, err := newPrivateKeySigner(cfg.PrivateKey, signType)
+		if err != nil {
+			return nil, nil, ErrInvalidConfig(fmt.Sprintf("PrivateKey is invalid: %v", err))
+		}
+		signer = defaultSigner
+	}
+
+	// 如果配置了平台公钥，则预先解析，用于响应验签
+	var platformVerifier signatureVerifier
+	if cfg.PublicKey != "" {
+		verifier, err := newVerifier(cfg.PublicKey, signType)
+		if err != nil {
+			return nil, nil, ErrInvalidConfig(fmt.Sprintf("PublicKey is invalid: %v", err))
+		}
+		platformVerifier = verifier
+	}
+
+	// 配置了 mTLS 客户端证书时加载证书链，确认证书和私钥可用
+	clientCert, err := cfg.loadClientCertificate()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 未配置日志实例时使用默认日志
+	logger := cfg.Logger
+	if logger == nil {
+		logger = defaultLogger(cfg.Debug)
+	}
+
+	// 设置了证书到期提醒时检查一次证书有效期
+	expiring, err := cfg.CheckCertificateExpiry()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, expiry := range expiring {
+		logger.Warn("[SDK] certificate is about to expire",
+			"field", expiry.Field,
+			"subject", expiry.Subject,
+			"notAfter", expiry.NotAfter.Format(time.RFC3339),
+			"daysLeft", expiry.DaysUntilExpiry(),
+		)
 	}
 
 	// 创建并配置底层 HTTP 客户端
+	codec := codecOrDefault(cfg.Codec)
+	unmarshal := unmarshalResponse(codec, cfg.StrictDecoding)
 	restyClient := resty.New().
-		SetBaseURL(cfg.BaseURL).                      // 设置 API 基础地址
-		SetTimeout(cfg.Timeout).                      // 设置请求超时时间
-		SetDebug(cfg.Debug).                          // 设置调试模式
-		SetRetryCount(cfg.RetryCount).                // 设置重试次数
-		SetRetryWaitTime(cfg.RetryWaitTime).          // 设置重试等待时间
-		SetRetryMaxWaitTime(cfg.RetryMaxWait).        // 设置最大重试等待时间
-		SetHeader("User-Agent", UserAgent).           // 设置 User-Agent
-		SetHeader("Content-Type", "application/json") // 设置内容类型
+		SetBaseURL(cfg.BaseURL).                       // 设置 API 基础地址
+		SetDebug(cfg.Debug).                           // 设置调试模式
+		SetLogger(&restyLogger{logger: logger}).       // 设置日志输出
+		SetRetryCount(cfg.RetryCount).                 // 设置重试次数
+		SetRetryWaitTime(cfg.RetryWaitTime).           // 设置重试等待时间
+		SetRetryMaxWaitTime(cfg.RetryMaxWait).         // 设置最大重试等待时间
+		AddRetryCondition(retryCondition).             // 设置重试条件（仅重试幂等接口的可恢复错误）
+		SetHeader("User-Agent", UserAgent).            // 设置 User-Agent
+		SetHeader("Content-Type", "application/json"). // 设置内容类型
+		SetJSONMarshaler(codec.Marshal).               // 使用配置的编解码器序列化请求报文
+		SetJSONUnmarshaler(unmarshal)                  // 使用配置的编解码器解析响应报文
+
+	// 沙箱环境：请求携带环境标记
+	if cfg.Environment == EnvSandbox {
+		restyClient.SetHeader(EnvironmentHeader, string(EnvSandbox))
+	}
+
+	// Clone 派生的客户端复用原客户端的传输层和连接池
+	if transport != nil {
+		restyClient.SetTransport(transport)
+	} else {
+		// 如果配置了代理，则设置代理
+		var proxyURL *url.URL
+		if cfg.Proxy != "" {
+			u, err := parseProxyURL(cfg.Proxy, cfg.ProxyUsername, cfg.ProxyPassword)
+			if err != nil {
+				return nil, nil, ErrInvalidConfig(fmt.Sprintf("Proxy is invalid: %v", err))
+			}
+			useProxy(restyClient.GetClient().Transport.(*http.Transport), u)
+			proxyURL = u
+		}
+
+		// 如果配置了 TLS 或 mTLS 客户端证书，则应用 TLS 配置
+		// 沙箱环境按配置放宽 TLS 校验
+		tlsConfig := cfg.TLSConfig
+		if clientCert != nil || cfg.SandboxInsecureSkipVerify {
+			if tlsConfig != nil {
+				tlsConfig = tlsConfig.Clone()
+			} else {
+				tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+			}
+			if clientCert != nil {
+				tlsConfig.Certificates = append(tlsConfig.Certificates, *clientCert)
+			}
+			if cfg.SandboxInsecureSkipVerify {
+				tlsConfig.InsecureSkipVerify = true
+			}
+		}
+		if tlsConfig != nil {
+			restyClient.SetTLSClientConfig(tlsConfig)
+		}
+
+		transport = restyClient.GetClient().Transport
+		// 连接代理失败的错误包装为 ProxyError
+		if proxyURL != nil {
+			transport = &proxyTransport{base: transport, proxy: proxyURL.Redacted()}
+		}
+	}
 
-	// 如果配置了代理，则设置代理
-	if cfg.Proxy != "" {
-		restyClient.SetProxy(cfg.Proxy)
+	// 配置了多个网关地址时，按地址的可用状态选择请求发往的地址
+	roundTripper := transport
+	var endpoints *endpointPool
+	if len(cfg.Endpoints) > 1 {
+		pool, err := newEndpointPool(transport, cfg.Endpoints, cfg.EndpointProbeInterval, logger)
+		if err != nil {
+			return nil, nil, ErrInvalidConfig(err.Error())
+		}
+		endpoints = pool
+		roundTripper = pool
 	}
 
-	// 如果配置了 TLS，则应用 TLS 配置
-	if cfg.TLSConfig != nil {
-		restyClient.SetTLSClientConfig(cfg.TLSConfig)
+	// 使用按请求计时的传输层实现超时，单次调用可通过 WithRequestTimeout 覆盖
+	// resty 的代理和 TLS 配置要求传输层为 *http.Transport，因此需在上述配置之后设置
+	// 演练模式在最外层拦截请求，请求不会发送到平台
+	restyClient.SetTransport(newDryRunTransport(
+		newTimeoutTransport(roundTripper, cfg.Timeout),
+		logger,
+		cfg.DryRun,
+	))
+
+	// 如果配置了限流，则首先注册限流中间件，等待令牌后再签名和发送
+	if limiter := newRateLimiter(cfg.RateLimit, cfg.RateBurst); limiter != nil {
+		restyClient.OnBeforeRequest(rateLimitMiddleware(limiter)) // 限流中间件（令牌桶，支持 context 取消）
 	}
 
 	// 注册请求和响应中间件
-	restyClient.OnBeforeRequest(requestLogMiddleware(cfg.Debug))    // 请求日志中间件（调试模式时打印请求详情）
-	restyClient.OnBeforeRequest(signatureMiddleware(cfg.PrivateKey)) // 请求签名中间件（使用RSA私钥自动签名）
-	restyClient.OnAfterResponse(responseLogMiddleware(cfg.Debug))   // 响应日志中间件（调试模式时打印响应详情）
-	restyClient.OnAfterResponse(errorHandlerMiddleware())           // 错误处理中间件（统一处理错误响应）
+	restyClient.OnBeforeRequest(requestLogMiddleware(logger, cfg.Debug))  // 请求日志中间件（调试模式时输出请求详情）
+	restyClient.OnBeforeRequest(signatureMiddleware(signer, signType))    // 请求签名中间件（使用签名器自动签名）
+	restyClient.OnAfterResponse(responseLogMiddleware(logger, cfg.Debug)) // 响应日志中间件（调试模式时输出响应详情）
+	restyClient.OnAfterResponse(errorHandlerMiddleware())                 // 错误处理中间件（统一处理错误响应）
 
-	// 创建客户端实例
-	client := &Client{
+	// 如果配置了平台公钥，则注册响应验签中间件
+	if platformVerifier != nil {
+		restyClient.OnAfterResponse(responseSignatureMiddleware(platformVerifier, cfg.DryRun)) // 响应验签中间件（使用平台公钥验证响应签名）
+	}
+
+	return &clientState{
 		config:      cfg,
 		restyClient: restyClient,
-	}
+		endpoints:   endpoints,
+		signer:      signer,
+		signType:    signType,
+		encryptor:   newFieldEncryptor(cfg.PublicKey),
+		logger:      logger,
+		codec:       codec,
+	}, transport, nil
+}
 
-	// 初始化支付服务
-	// PaymentService 提供以下功能：
-	//   - CreateOrder: 统一下单
-	//   - CancelOrder: 订单取消
-	//   - CreateRefund: 退款
-	//   - QueryRefund: 退款查询
-	//   - CreateWithdraw: 账户提现
-	client.Payment = NewPaymentService(client.restyClient, cfg)
+// Clone 派生一个使用调整后配置的新客户端
+// 新客户端复用原客户端的传输层和连接池，并继承原客户端已注册的调用钩子，
+// 之后两个客户端各自注册的钩子互不影响；限流令牌桶不共享，两个客户端分别按各自的配置限流
+//
+// 参数:
+//   - modify: 调整配置的函数，参数为原客户端配置的副本；为 nil 时使用相同的配置
+//
+// 返回:
+//   - *Client: 新的客户端实例
+//   - error: 调整后的配置验证失败，或修改了 Proxy、TLSConfig、客户端证书、SandboxInsecureSkipVerify 等传输层配置时返回错误
+//
+// 注意:
+//   - 传输层配置无法在共享连接池的客户端之间区分，需要不同的代理或 TLS 配置时使用 NewClient 创建客户端
+//
+// 示例:
+//
+//	// 对账任务使用更长的超时，不影响在线交易使用的客户端
+//	batchClient, err := client.Clone(func(cfg *haozpay.Config) {
+//	    cfg.WithTimeout(2 * time.Minute).WithRetry(5, 2*time.Second, 30*time.Second)
+//	})
+func (c *Client) Clone(modify func(cfg *Config)) (*Client, error) {
+	current := c.state.Load().config
+	cfg := current.Clone()
+	if modify != nil {
+		modify(cfg)
+	}
+	if transportConfigChanged(current, cfg) {
+		return nil, ErrInvalidConfig("Proxy, proxy credentials, TLSConfig, client certificate and SandboxInsecureSkipVerify cannot be changed by Clone")
+	}
+	return newClient(cfg, c.transport, c.hooks.clone())
+}
 
-	return client, nil
+// transportConfigChanged 判断传输层相关的配置是否不同
+// 共享连接池的客户端之间无法区分这些配置
+func transportConfigChanged(a, b *Config) bool {
+	return a.Proxy != b.Proxy ||
+		a.ProxyUsername != b.ProxyUsername ||
+		a.ProxyPassword != b.ProxyPassword ||
+		a.TLSConfig != b.TLSConfig ||
+		a.SandboxInsecureSkipVerify != b.SandboxInsecureSkipVerify ||
+		a.ClientCertFile != b.ClientCertFile ||
+		a.ClientKeyFile != b.ClientKeyFile ||
+		a.ClientCertPEM != b.ClientCertPEM ||
+		a.ClientKeyPEM != b.ClientKeyPEM
 }
 
 // GetConfig 获取客户端的配置信息
 //
 // 返回:
-//   - *Config: 当前客户端使用的配置对象
+//   - *Config: 当前客户端配置的副本，修改副本不影响客户端，需要调整配置时使用 Clone 或 UpdateConfig
 //
 // 示例:
 //
 //	config := client.GetConfig()
 //	fmt.Println("MerchantNo:", config.MerchantNo)
 func (c *Client) GetConfig() *Config {
-	return c.config
+	return c.state.Load().config.Clone()
+}
+
+// ActiveEndpoint 返回当前请求优先发往的网关地址
+// 未通过 WithEndpoints 配置多个地址时返回 BaseURL
+//
+// 示例:
+//
+//	metrics.SetLabel("haozpay_endpoint", client.ActiveEndpoint())
+func (c *Client) ActiveEndpoint() string {
+	state := c.state.Load()
+	if state.endpoints == nil {
+		return state.config.BaseURL
+	}
+	return state.endpoints.active()
 }
 
 // GetRestyClient 获取底层的 resty HTTP 客户端
@@ -127,11 +548,13 @@ func (c *Client) GetConfig() *Config {
 // 注意:
 //   - 此方法供高级用户使用，一般情况下不需要直接操作底层客户端
 //   - 直接使用底层客户端可能会绕过SDK的签名和错误处理机制
+//   - resty 客户端的设置方法不是并发安全的，不要在发起请求后修改底层客户端的设置
+//   - UpdateConfig 会替换底层客户端，之前取得的实例继续使用更新前的配置
 //
 // 示例:
 //
 //	restyClient := client.GetRestyClient()
 //	resp, err := restyClient.R().Get("/custom/endpoint")
 func (c *Client) GetRestyClient() *resty.Client {
-	return c.restyClient
-}
\ No newline at end of file
+	return c.state.Load().restyClient
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+// newFlagSet 创建子命令的参数集
+func (e *cliEnv) newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet("haozpay "+name, flag.ContinueOnError)
+	flags.SetOutput(e.stderr)
+	return flags
+}
+
+// newClient 按配置创建 SDK 客户端
+func (e *cliEnv) newClient() (*haozpay.Client, error) {
+	cfg := e.config.sdkConfig().
+		WithDryRun(e.dryRun).
+		WithDebug(e.debug)
+	return haozpay.NewClient(cfg)
+}
+
+// requireFlags 检查必填参数
+func requireFlags(values map[string]string) error {
+	var missing []string
+	for name, value := range values {
+		if value == "" {
+			missing = append(missing, "-"+name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required flags: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// runCreateOrder 统一下单
+func runCreateOrder(ctx context.Context, env *cliEnv, args []string) error {
+	flags := env.newFlagSet("create-order")
+	title := flags.String("title", "", "订单标题（必填）")
+	amount := flags.String("amount", "", "订单金额，单位元，例如 0.01（必填）")
+	payType := flags.Int("pay-type", int(haozpay.PayTypeAlipay), "支付方式，参见 haozpay.PayType")
+	currency := flags.String("currency", "", "币种，默认 CNY")
+	notifyURL := flags.String("notify-url", "", "支付结果通知地址（必填）")
+	returnURL := flags.String("return-url", "", "支付完成后的跳转地址")
+	cashier := flags.Bool("cashier", false, "使用皓臻收银台")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(map[string]string{"title": *title, "amount": *amount, "notify-url": *notifyURL}); err != nil {
+		return err
+	}
+
+	orderAmount, err := haozpay.FromYuanString(*amount)
+	if err != nil {
+		return err
+	}
+	client, err := env.newClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Payment.CreateOrder(ctx, &haozpay.CreatePaymentOrderRequest{
+		OrderTitle:        *title,
+		OrderAmount:       orderAmount,
+		Currency:          haozpay.Currency(strings.ToUpper(*currency)),
+		PayType:           haozpay.PayType(*payType),
+		UseHaozPayCashier: *cashier,
+		NotifyUrl:         *notifyURL,
+		ReturnUrl:         *returnURL,
+	})
+	if err != nil {
+		return err
+	}
+	return env.printJSON(resp)
+}
+
+// runPing 检查网关连通性和签名配置
+func runPing(ctx context.Context, env *cliEnv, args []string) error {
+	flags := env.newFlagSet("ping")
+	timeout := flags.Duration("timeout", 5*time.Second, "等待网关响应的最长时间")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := env.newClient()
+	if err != nil {
+		return err
+	}
+	result, err := client.Ping(ctx, haozpay.WithRequestTimeout(*timeout))
+	if err != nil {
+		return err
+	}
+	return env.printJSON(map[string]interface{}{
+		"endpoint":   result.Endpoint,
+		"latency":    result.Latency.String(),
+		"serverTime": result.ServerTime,
+		"clockSkew":  result.ClockSkew.String(),
+	})
+}
+
+// runQueryOrder 订单查询
+func runQueryOrder(ctx context.Context, env *cliEnv, args []string) error {
+	flags := env.newFlagSet("query-order")
+	orderNo := flags.String("order-no", "", "平台订单号（必填）")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(map[string]string{"order-no": *orderNo}); err != nil {
+		return err
+	}
+
+	client, err := env.newClient()
+	if err != nil {
+		return err
+	}
+	resp, err := client.Payment.QueryOrder(ctx, &haozpay.QueryOrderRequest{OrderNo: *orderNo})
+	if err != nil {
+		return err
+	}
+	return env.printJSON(resp)
+}
+
+// runQueryRisk 订单风控查询
+func runQueryRisk(ctx context.Context, env *cliEnv, args []string) error {
+	flags := env.newFlagSet("query-risk")
+	orderNo := flags.String("order-no", "", "平台订单号（必填）")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(map[string]string{"order-no": *orderNo}); err != nil {
+		return err
+	}
+
+	client, err := env.newClient()
+	if err != nil {
+		return err
+	}
+	resp, err := client.Risk.QueryOrderRisk(ctx, *orderNo)
+	if err != nil {
+		return err
+	}
+	return env.printJSON(resp)
+}
+
+// runRefund 申请退款
+func runRefund(ctx context.Context, env *cliEnv, args []string) error {
+	flags := env.newFlagSet("refund")
+	orderNo := flags.String("order-no", "", "平台订单号（必填）")
+	amount := flags.String("amount", "", "退款金额，单位元（必填）")
+	currency := flags.String("currency", "", "币种，默认 CNY")
+	reason := flags.String("reason", "", "退款原因")
+	notifyURL := flags.String("notify-url", "", "退款结果通知地址")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if err := requireFlags(map[string]string{"order-no": *orderNo, "amount": *amount}); err != nil {
+		return err
+	}
+
+	refundAmount, err := haozpay.FromYuanString(*amount)
+	if err != nil {
+		return err
+	}
+	client, err := env.newClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Payment.CreateRefund(ctx, &haozpay.CreateRefundRequest{
+		OrderNo:      *orderNo,
+		RefundAmount: refundAmount,
+		Currency:     haozpay.Currency(strings.ToUpper(*currency)),
+		RefundReason: *reason,
+		NotifyUrl:    *notifyURL,
+	})
+	if err != nil {
+		return err
+	}
+	return env.printJSON(resp)
+}
+
+// runVerifyCallback 验证回调通知报文的平台签名，报文从 -file 指定的文件或标准输入读取
+func runVerifyCallback(ctx context.Context, env *cliEnv, args []string) error {
+	flags := env.newFlagSet("verify-callback")
+	file := flags.String("file", "", "回调报文文件，默认从标准输入读取")
+	tolerance := flags.Duration("tolerance", 0, "通知时间戳允许的最大偏差，验证历史报文时可调大，默认 5m")
+	contentType := flags.String("content-type", "application/json", "回调请求的 Content-Type，表单格式的报文使用 application/x-www-form-urlencoded")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if env.config.PublicKey == "" {
+		return errors.New("platform public key is required, set publicKey or HAOZPAY_PUBLIC_KEY")
+	}
+
+	var body []byte
+	var err error
+	if *file != "" {
+		body, err = os.ReadFile(*file)
+	} else {
+		body, err = io.ReadAll(env.stdin)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read callback body: %w", err)
+	}
+
+	cfg := env.config.sdkConfig().WithNotifyTimestampTolerance(*tolerance)
+	envelope, err := haozpay.NewNotificationVerifier(cfg).Verify(haozpay.WithNotificationContentType(ctx, *contentType), body)
+	if err != nil {
+		return err
+	}
+
+	return env.printJSON(map[string]interface{}{
+		"valid":      true,
+		"merchantNo": envelope.MerchantNo,
+		"timestamp":  time.UnixMilli(envelope.Timestamp).Format(time.RFC3339),
+		"bizBody":    json.RawMessage(envelope.BizBody),
+	})
+}
+
+// runSignString 构建签名串并使用商户私钥签名
+// 参数通过 key=value 形式的命令行参数或 -params 指定的 JSON 对象传入
+func runSignString(ctx context.Context, env *cliEnv, args []string) error {
+	flags := env.newFlagSet("sign-string")
+	paramsJSON := flags.String("params", "", `JSON 格式的参数，例如 {"merchantNo":"HZ001","timestamp":1700000000000}`)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	params := make(map[string]interface{})
+	if *paramsJSON != "" {
+		decoder := json.NewDecoder(strings.NewReader(*paramsJSON))
+		decoder.UseNumber()
+		if err := decoder.Decode(&params); err != nil {
+			return fmt.Errorf("invalid -params: %w", err)
+		}
+	}
+	for _, arg := range flags.Args() {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return fmt.Errorf("invalid parameter %q, expected key=value", arg)
+		}
+		params[key] = value
+	}
+	if len(params) == 0 {
+		return errors.New("no parameters to sign")
+	}
+	if env.config.PrivateKey == "" {
+		return errors.New("merchant private key is required, set privateKey or HAOZPAY_PRIVATE_KEY")
+	}
+
+	signType := haozpay.SignType(strings.ToUpper(env.config.SignType))
+	if signType == "" {
+		signType = haozpay.SignTypeRSA2
+	}
+	algorithm, ok := haozpay.LookupSignAlgorithm(signType)
+	if !ok {
+		return fmt.Errorf("sign type %s is not supported", signType)
+	}
+	signer, err := algorithm.NewSigner(env.config.PrivateKey)
+	if err != nil {
+		return err
+	}
+
+	sign, err := haozpay.GenerateSignWithSignType(params, signer, signType)
+	if err != nil {
+		return err
+	}
+	return env.printJSON(map[string]string{
+		"signType":   string(signType),
+		"signString": haozpay.BuildSignString(params),
+		"sign":       sign,
+	})
+}
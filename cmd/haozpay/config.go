@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+// cliConfig 命令行工具的配置
+// 可以从 JSON 配置文件读取，同名环境变量优先于配置文件
+type cliConfig struct {
+	// Environment 网关环境(production/sandbox)，对应环境变量 HAOZPAY_ENV
+	Environment string `json:"environment"`
+	// BaseURL API 基础地址，设置后优先于 Environment，对应环境变量 HAOZPAY_BASE_URL
+	BaseURL string `json:"baseUrl"`
+	// MerchantNo 商户编号，对应环境变量 HAOZPAY_MERCHANT_NO
+	MerchantNo string `json:"merchantNo"`
+	// SignType 签名算法(RSA2/RSA/RSA-PSS/SM2)，对应环境变量 HAOZPAY_SIGN_TYPE
+	SignType string `json:"signType"`
+	// PrivateKey 商户私钥，对应环境变量 HAOZPAY_PRIVATE_KEY
+	PrivateKey string `json:"privateKey"`
+	// PrivateKeyFile 商户私钥文件，PrivateKey 为空时读取，对应环境变量 HAOZPAY_PRIVATE_KEY_FILE
+	PrivateKeyFile string `json:"privateKeyFile"`
+	// PublicKey 平台公钥，对应环境变量 HAOZPAY_PUBLIC_KEY
+	PublicKey string `json:"publicKey"`
+	// PublicKeyFile 平台公钥文件，PublicKey 为空时读取，对应环境变量 HAOZPAY_PUBLIC_KEY_FILE
+	PublicKeyFile string `json:"publicKeyFile"`
+}
+
+// loadConfig 读取配置文件和环境变量
+// path 为空时只读取环境变量
+func loadConfig(path string) (*cliConfig, error) {
+	cfg := &cliConfig{}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	}
+
+	for env, field := range map[string]*string{
+		haozpay.EnvVarEnvironment:    &cfg.Environment,
+		haozpay.EnvVarBaseURL:        &cfg.BaseURL,
+		haozpay.EnvVarMerchantNo:     &cfg.MerchantNo,
+		haozpay.EnvVarSignType:       &cfg.SignType,
+		haozpay.EnvVarPrivateKey:     &cfg.PrivateKey,
+		haozpay.EnvVarPrivateKeyFile: &cfg.PrivateKeyFile,
+		haozpay.EnvVarPublicKey:      &cfg.PublicKey,
+		haozpay.EnvVarPublicKeyFile:  &cfg.PublicKeyFile,
+	} {
+		if value, ok := os.LookupEnv(env); ok {
+			*field = value
+		}
+	}
+
+	var err error
+	if cfg.PrivateKey, err = readKey(cfg.PrivateKey, cfg.PrivateKeyFile); err != nil {
+		return nil, err
+	}
+	if cfg.PublicKey, err = readKey(cfg.PublicKey, cfg.PublicKeyFile); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// readKey 返回密钥内容，key 为空时从 file 读取
+func readKey(key, file string) (string, error) {
+	if key != "" || file == "" {
+		return key, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read key file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// sdkConfig 转换为 SDK 客户端配置
+func (c *cliConfig) sdkConfig() *haozpay.Config {
+	cfg := haozpay.DefaultConfig()
+	if c.Environment != "" {
+		cfg.WithEnvironment(haozpay.Environment(c.Environment))
+	}
+	if c.BaseURL != "" {
+		cfg.WithBaseURL(c.BaseURL)
+	}
+	if cfg.BaseURL == "" {
+		cfg.WithEnvironment(haozpay.EnvProduction)
+	}
+	if c.SignType != "" {
+		cfg.WithSignType(haozpay.SignType(strings.ToUpper(c.SignType)))
+	}
+	return cfg.
+		WithMerchantNo(c.MerchantNo).
+		WithPrivateKey(c.PrivateKey).
+		WithPublicKey(c.PublicKey)
+}
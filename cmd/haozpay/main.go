@@ -0,0 +1,140 @@
+// Command haozpay 皓臻支付网关命令行工具
+//
+// 用于运维和技术支持在不编写代码的情况下调用网关接口、验证回调报文和签名，复现线上问题
+//
+// 用法:
+//
+//	haozpay [-config haozpay.json] [-dry-run] [-debug] <command> [flags]
+//
+// 命令:
+//   - ping: 检查网关连通性和签名配置（请求耗时、服务器时间和时钟偏差）
+//   - create-order: 统一下单
+//   - query-order: 订单查询
+//   - query-risk: 订单风控查询（风险评分、命中规则和挂起状态）
+//   - refund: 申请退款
+//   - verify-callback: 验证回调通知报文的平台签名
+//   - sign-string: 构建签名串并使用商户私钥签名
+//
+// 配置从 -config 指定的 JSON 文件读取，字段与环境变量对应如下，环境变量优先于配置文件:
+//
+//	environment     HAOZPAY_ENV               production 或 sandbox，默认 production
+//	baseUrl         HAOZPAY_BASE_URL          API 基础地址，设置后优先于 environment
+//	merchantNo      HAOZPAY_MERCHANT_NO       商户编号
+//	signType        HAOZPAY_SIGN_TYPE         RSA2 或 SM2，默认 RSA2
+//	privateKey      HAOZPAY_PRIVATE_KEY       商户私钥
+//	privateKeyFile  HAOZPAY_PRIVATE_KEY_FILE  商户私钥文件
+//	publicKey       HAOZPAY_PUBLIC_KEY        平台公钥
+//	publicKeyFile   HAOZPAY_PUBLIC_KEY_FILE   平台公钥文件
+//
+// 示例:
+//
+//	export HAOZPAY_ENV=sandbox
+//	export HAOZPAY_MERCHANT_NO=HZ1971294971928846336
+//	export HAOZPAY_PRIVATE_KEY_FILE=merchant_private.pem
+//	haozpay query-order -order-no ORDER123456
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// command 子命令
+type command struct {
+	// name 命令名称
+	name string
+	// usage 命令说明
+	usage string
+	// run 执行命令，args 为命令名称之后的参数
+	run func(ctx context.Context, env *cliEnv, args []string) error
+}
+
+// cliEnv 子命令的运行环境
+type cliEnv struct {
+	// config 命令行工具配置
+	config *cliConfig
+	// dryRun 是否使用演练模式
+	dryRun bool
+	// debug 是否输出请求和响应详情
+	debug  bool
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+var commands = []command{
+	{name: "ping", usage: "检查网关连通性和签名配置", run: runPing},
+	{name: "create-order", usage: "统一下单", run: runCreateOrder},
+	{name: "query-order", usage: "订单查询", run: runQueryOrder},
+	{name: "query-risk", usage: "订单风控查询", run: runQueryRisk},
+	{name: "refund", usage: "申请退款", run: runRefund},
+	{name: "verify-callback", usage: "验证回调通知报文的平台签名", run: runVerifyCallback},
+	{name: "sign-string", usage: "构建签名串并使用商户私钥签名", run: runSignString},
+}
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run 解析全局参数并执行子命令，返回进程退出码
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("haozpay", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	configPath := flags.String("config", "", "JSON 配置文件路径")
+	dryRun := flags.Bool("dry-run", false, "演练模式，请求经过签名但不发送到平台")
+	debug := flags.Bool("debug", false, "输出请求和响应详情")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "用法: haozpay [-config haozpay.json] [-dry-run] [-debug] <command> [flags]")
+		fmt.Fprintln(stderr, "\n命令:")
+		for _, cmd := range commands {
+			fmt.Fprintf(stderr, "  %-16s %s\n", cmd.name, cmd.usage)
+		}
+		fmt.Fprintln(stderr, "\n全局参数:")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	name := flags.Arg(0)
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		cfg, err := loadConfig(*configPath)
+		if err != nil {
+			fmt.Fprintln(stderr, "haozpay:", err)
+			return 1
+		}
+		env := &cliEnv{config: cfg, dryRun: *dryRun, debug: *debug, stdin: stdin, stdout: stdout, stderr: stderr}
+		if err := cmd.run(ctx, env, flags.Args()[1:]); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return 0
+			}
+			fmt.Fprintf(stderr, "haozpay %s: %v\n", name, err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintf(stderr, "haozpay: unknown command %q\n\n", name)
+	flags.Usage()
+	return 2
+}
+
+// printJSON 以缩进格式输出 v
+func (e *cliEnv) printJSON(v interface{}) error {
+	encoder := json.NewEncoder(e.stdout)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(v)
+}
@@ -0,0 +1,103 @@
+package haozpay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// Codec JSON 编解码器
+// SDK 使用 Codec 序列化业务参数（bizBody）和请求报文、解析响应报文，
+// 吞吐量较高的商户可替换为 jsoniter 或 sonic 等更快的实现以降低 CPU 开销
+//
+// 实现需与 encoding/json 兼容：支持 json 标签、json.Marshaler 和 json.Unmarshaler（Money、ExchangeRate 等类型依赖），
+// 并可被多个 goroutine 并发使用。签名和验签展开参数时仍使用 encoding/json，确保签名串与数字的原始文本一致
+//
+// 可选的实现:
+//   - github.com/haoz-cloud/haozpay-sdk/jsoniter: 基于 json-iterator/go
+//   - github.com/haoz-cloud/haozpay-sdk/sonic: 基于 bytedance/sonic
+type Codec interface {
+	// Marshal 将 v 序列化为 JSON
+	Marshal(v interface{}) ([]byte, error)
+	// Unmarshal 将 JSON 解析到 v
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// StrictCodec 支持严格解析的 JSON 编解码器
+// 开启 Config.StrictDecoding 时，SDK 使用 UnmarshalStrict 解析响应报文，编解码器需实现该接口
+type StrictCodec interface {
+	Codec
+	// UnmarshalStrict 将 JSON 解析到 v，JSON 中包含 v 未定义的字段时返回错误
+	UnmarshalStrict(data []byte, v interface{}) error
+}
+
+// errTrailingData 严格解析时 JSON 之后存在多余数据的错误
+var errTrailingData = errors.New("invalid character after top-level value")
+
+// StdCodec 基于 encoding/json 的默认编解码器
+var StdCodec Codec = stdCodec{}
+
+// stdCodec 基于 encoding/json 的编解码器
+type stdCodec struct{}
+
+// Marshal 实现 Codec 接口
+func (stdCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal 实现 Codec 接口
+func (stdCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// UnmarshalStrict 实现 StrictCodec 接口
+func (stdCodec) UnmarshalStrict(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errTrailingData
+	}
+	return nil
+}
+
+// codecOrDefault 返回配置的编解码器，未配置时返回 StdCodec
+func codecOrDefault(codec Codec) Codec {
+	if codec == nil {
+		return StdCodec
+	}
+	return codec
+}
+
+// decodeError 响应报文解析失败的错误
+// 包装 Codec 返回的原始错误，使不同编解码器的解析错误都转换为 ErrInvalidResponse 错误码
+type decodeError struct {
+	err error
+}
+
+// Error 实现 error 接口
+func (e *decodeError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap 返回编解码器的原始错误
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+// unmarshalResponse 返回供 resty 解析响应报文的函数，解析失败时返回 decodeError
+// strict 为 true 时使用 StrictCodec 的 UnmarshalStrict，调用前需确认 codec 实现了 StrictCodec
+func unmarshalResponse(codec Codec, strict bool) func(data []byte, v interface{}) error {
+	unmarshal := codec.Unmarshal
+	if strict {
+		unmarshal = codec.(StrictCodec).UnmarshalStrict
+	}
+	return func(data []byte, v interface{}) error {
+		if err := unmarshal(data, v); err != nil {
+			return &decodeError{err: err}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,183 @@
+package haozpay
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 配置环境变量，ConfigFromEnv 读取
+const (
+	// EnvVarEnvironment 网关环境，production 或 sandbox
+	EnvVarEnvironment = "HAOZPAY_ENV"
+	// EnvVarBaseURL API 基础地址，设置后优先于 HAOZPAY_ENV 对应的默认地址
+	EnvVarBaseURL = "HAOZPAY_BASE_URL"
+	// EnvVarMerchantNo 商户编号
+	EnvVarMerchantNo = "HAOZPAY_MERCHANT_NO"
+	// EnvVarPrivateKey 商户私钥(PEM格式或纯Base64格式)
+	EnvVarPrivateKey = "HAOZPAY_PRIVATE_KEY"
+	// EnvVarPrivateKeyFile 商户私钥文件路径，HAOZPAY_PRIVATE_KEY 为空时读取
+	EnvVarPrivateKeyFile = "HAOZPAY_PRIVATE_KEY_FILE"
+	// EnvVarPublicKey 平台公钥(PEM格式或纯Base64格式)
+	EnvVarPublicKey = "HAOZPAY_PUBLIC_KEY"
+	// EnvVarPublicKeyFile 平台公钥文件路径，HAOZPAY_PUBLIC_KEY 为空时读取
+	EnvVarPublicKeyFile = "HAOZPAY_PUBLIC_KEY_FILE"
+	// EnvVarClientCert mTLS 客户端证书(PEM格式)
+	EnvVarClientCert = "HAOZPAY_CLIENT_CERT"
+	// EnvVarClientCertFile mTLS 客户端证书文件路径，HAOZPAY_CLIENT_CERT 为空时读取
+	EnvVarClientCertFile = "HAOZPAY_CLIENT_CERT_FILE"
+	// EnvVarClientKey mTLS 客户端私钥(PEM格式)
+	EnvVarClientKey = "HAOZPAY_CLIENT_KEY"
+	// EnvVarClientKeyFile mTLS 客户端私钥文件路径，HAOZPAY_CLIENT_KEY 为空时读取
+	EnvVarClientKeyFile = "HAOZPAY_CLIENT_KEY_FILE"
+	// EnvVarSignType 签名算法类型，RSA2、RSA、RSA-PSS 或 SM2
+	EnvVarSignType = "HAOZPAY_SIGN_TYPE"
+	// EnvVarTimeout 单个请求的超时时间，例如 30s
+	EnvVarTimeout = "HAOZPAY_TIMEOUT"
+	// EnvVarRetryCount 请求失败时的重试次数
+	EnvVarRetryCount = "HAOZPAY_RETRY_COUNT"
+	// EnvVarProxy 代理服务器地址
+	EnvVarProxy = "HAOZPAY_PROXY"
+	// EnvVarProxyUsername 代理认证用户名
+	EnvVarProxyUsername = "HAOZPAY_PROXY_USERNAME"
+	// EnvVarProxyPassword 代理认证密码
+	EnvVarProxyPassword = "HAOZPAY_PROXY_PASSWORD"
+	// EnvVarDebug 是否开启调试模式，true 或 false
+	EnvVarDebug = "HAOZPAY_DEBUG"
+	// EnvVarDryRun 是否开启演练模式，true 或 false
+	EnvVarDryRun = "HAOZPAY_DRY_RUN"
+	// EnvVarTrace 是否统计每次调用的耗时分解，true 或 false
+	EnvVarTrace = "HAOZPAY_TRACE"
+	// EnvVarStrictDecoding 是否严格解析响应报文，true 或 false
+	EnvVarStrictDecoding = "HAOZPAY_STRICT_DECODING"
+)
+
+// ConfigFromEnv 从环境变量读取配置
+// 未设置的配置项使用 DefaultConfig 的默认值，未设置 HAOZPAY_ENV 和 HAOZPAY_BASE_URL 时使用生产环境
+//
+// 支持的环境变量:
+//   - HAOZPAY_ENV: 网关环境，production 或 sandbox
+//   - HAOZPAY_BASE_URL: API 基础地址，设置后优先于 HAOZPAY_ENV 对应的默认地址
+//   - HAOZPAY_MERCHANT_NO: 商户编号（必填）
+//   - HAOZPAY_PRIVATE_KEY / HAOZPAY_PRIVATE_KEY_FILE: 商户私钥或私钥文件路径（必填其一）
+//   - HAOZPAY_PUBLIC_KEY / HAOZPAY_PUBLIC_KEY_FILE: 平台公钥或公钥文件路径
+//   - HAOZPAY_CLIENT_CERT / HAOZPAY_CLIENT_CERT_FILE: mTLS 客户端证书或证书文件路径
+//   - HAOZPAY_CLIENT_KEY / HAOZPAY_CLIENT_KEY_FILE: mTLS 客户端私钥或私钥文件路径
+//   - HAOZPAY_SIGN_TYPE: 签名算法类型，RSA2 或 SM2
+//   - HAOZPAY_TIMEOUT: 请求超时时间，例如 30s
+//   - HAOZPAY_RETRY_COUNT: 重试次数
+//   - HAOZPAY_PROXY: 代理服务器地址，支持 http、https、socks5 和 socks5h 协议
+//   - HAOZPAY_PROXY_USERNAME / HAOZPAY_PROXY_PASSWORD: 代理认证用户名和密码
+//   - HAOZPAY_DEBUG: 调试模式，true 或 false
+//   - HAOZPAY_DRY_RUN: 演练模式，true 或 false
+//   - HAOZPAY_TRACE: 统计调用耗时分解，true 或 false
+//   - HAOZPAY_STRICT_DECODING: 严格解析响应报文，true 或 false
+//
+// 返回:
+//   - *Config: 通过校验的配置对象，可继续链式调用 WithLogger 等方法补充配置
+//   - error: 环境变量格式错误、密钥文件读取失败或配置校验失败时返回 *ConfigError
+//
+// 示例:
+//
+//	config, err := haozpay.ConfigFromEnv()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	client, err := haozpay.NewClient(config.WithLogger(logger))
+func ConfigFromEnv() (*Config, error) {
+	cfg := DefaultConfig()
+
+	if env := os.Getenv(EnvVarEnvironment); env != "" {
+		cfg.WithEnvironment(Environment(strings.ToLower(env)))
+	}
+	if baseURL := os.Getenv(EnvVarBaseURL); baseURL != "" {
+		cfg.WithBaseURL(baseURL)
+	}
+	if cfg.BaseURL == "" {
+		cfg.WithEnvironment(EnvProduction)
+	}
+	cfg.MerchantNo = os.Getenv(EnvVarMerchantNo)
+	if signType := os.Getenv(EnvVarSignType); signType != "" {
+		cfg.SignType = SignType(strings.ToUpper(signType))
+	}
+	cfg.Proxy = os.Getenv(EnvVarProxy)
+	cfg.ProxyUsername = os.Getenv(EnvVarProxyUsername)
+	cfg.ProxyPassword = os.Getenv(EnvVarProxyPassword)
+
+	var err error
+	if cfg.PrivateKey, err = keyFromEnv(EnvVarPrivateKey, EnvVarPrivateKeyFile); err != nil {
+		return nil, err
+	}
+	if cfg.PublicKey, err = keyFromEnv(EnvVarPublicKey, EnvVarPublicKeyFile); err != nil {
+		return nil, err
+	}
+	if cfg.ClientCertPEM, err = keyFromEnv(EnvVarClientCert, EnvVarClientCertFile); err != nil {
+		return nil, err
+	}
+	if cfg.ClientKeyPEM, err = keyFromEnv(EnvVarClientKey, EnvVarClientKeyFile); err != nil {
+		return nil, err
+	}
+
+	if value := os.Getenv(EnvVarTimeout); value != "" {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, &ConfigError{Field: EnvVarTimeout, Message: fmt.Sprintf("invalid duration %q", value)}
+		}
+		cfg.Timeout = timeout
+	}
+	if value := os.Getenv(EnvVarRetryCount); value != "" {
+		count, err := strconv.Atoi(value)
+		if err != nil || count < 0 {
+			return nil, &ConfigError{Field: EnvVarRetryCount, Message: fmt.Sprintf("invalid retry count %q", value)}
+		}
+		cfg.RetryCount = count
+	}
+	if cfg.Debug, err = boolFromEnv(EnvVarDebug); err != nil {
+		return nil, err
+	}
+	if cfg.DryRun, err = boolFromEnv(EnvVarDryRun); err != nil {
+		return nil, err
+	}
+	if cfg.Trace, err = boolFromEnv(EnvVarTrace); err != nil {
+		return nil, err
+	}
+	if cfg.StrictDecoding, err = boolFromEnv(EnvVarStrictDecoding); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// keyFromEnv 读取密钥环境变量，为空时读取密钥文件环境变量指定的文件
+func keyFromEnv(keyVar, fileVar string) (string, error) {
+	if key := os.Getenv(keyVar); key != "" {
+		return key, nil
+	}
+	path := os.Getenv(fileVar)
+	if path == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", &ConfigError{Field: fileVar, Message: err.Error()}
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// boolFromEnv 读取布尔类型的环境变量，未设置时返回 false
+func boolFromEnv(name string) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, &ConfigError{Field: name, Message: fmt.Sprintf("invalid boolean %q", value)}
+	}
+	return b, nil
+}
@@ -0,0 +1,273 @@
+package haozpay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig 配置文件的结构
+// 字段名与 JSON/YAML 配置文件中的键一致
+type fileConfig struct {
+	Environment               string        `json:"environment" yaml:"environment"`
+	BaseURL                   string        `json:"baseUrl" yaml:"baseUrl"`
+	Endpoints                 []string      `json:"endpoints" yaml:"endpoints"`
+	EndpointProbeInterval     *fileDuration `json:"endpointProbeInterval" yaml:"endpointProbeInterval"`
+	SandboxInsecureSkipVerify bool          `json:"sandboxInsecureSkipVerify" yaml:"sandboxInsecureSkipVerify"`
+	MerchantNo                string        `json:"merchantNo" yaml:"merchantNo"`
+	SignType                  string        `json:"signType" yaml:"signType"`
+	PrivateKey                string        `json:"privateKey" yaml:"privateKey"`
+	PrivateKeyFile            string        `json:"privateKeyFile" yaml:"privateKeyFile"`
+	PublicKey                 string        `json:"publicKey" yaml:"publicKey"`
+	PublicKeyFile             string        `json:"publicKeyFile" yaml:"publicKeyFile"`
+	Timeout                   *fileDuration `json:"timeout" yaml:"timeout"`
+	Retry                     *struct {
+		Count    *int          `json:"count" yaml:"count"`
+		WaitTime *fileDuration `json:"waitTime" yaml:"waitTime"`
+		MaxWait  *fileDuration `json:"maxWait" yaml:"maxWait"`
+	} `json:"retry" yaml:"retry"`
+	RateLimit *struct {
+		QPS   float64 `json:"qps" yaml:"qps"`
+		Burst int     `json:"burst" yaml:"burst"`
+	} `json:"rateLimit" yaml:"rateLimit"`
+	ExchangeRateCacheTTL     *fileDuration `json:"exchangeRateCacheTTL" yaml:"exchangeRateCacheTTL"`
+	ChannelStatusCacheTTL    *fileDuration `json:"channelStatusCacheTTL" yaml:"channelStatusCacheTTL"`
+	BankInfoCacheTTL         *fileDuration `json:"bankInfoCacheTTL" yaml:"bankInfoCacheTTL"`
+	NotifyTimestampTolerance *fileDuration `json:"notifyTimestampTolerance" yaml:"notifyTimestampTolerance"`
+	SlowRequestThreshold     *fileDuration `json:"slowRequestThreshold" yaml:"slowRequestThreshold"`
+	Debug                    bool          `json:"debug" yaml:"debug"`
+	DryRun                   bool          `json:"dryRun" yaml:"dryRun"`
+	Trace                    bool          `json:"trace" yaml:"trace"`
+	StrictDecoding           bool          `json:"strictDecoding" yaml:"strictDecoding"`
+	Proxy                    string        `json:"proxy" yaml:"proxy"`
+	ProxyUsername            string        `json:"proxyUsername" yaml:"proxyUsername"`
+	ProxyPassword            string        `json:"proxyPassword" yaml:"proxyPassword"`
+	ClientCert               string        `json:"clientCert" yaml:"clientCert"`
+	ClientCertFile           string        `json:"clientCertFile" yaml:"clientCertFile"`
+	ClientKey                string        `json:"clientKey" yaml:"clientKey"`
+	ClientKeyFile            string        `json:"clientKeyFile" yaml:"clientKeyFile"`
+}
+
+// fileDuration 配置文件中的时间间隔，使用 time.ParseDuration 格式，例如 "30s"、"1m30s"
+type fileDuration time.Duration
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口
+func (d *fileDuration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"30s\", got %s", data)
+	}
+	return d.parse(s)
+}
+
+// UnmarshalYAML 实现 yaml.Unmarshaler 接口
+func (d *fileDuration) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d: duration must be a string such as \"30s\"", value.Line)
+	}
+	if err := d.parse(value.Value); err != nil {
+		return fmt.Errorf("line %d: %w", value.Line, err)
+	}
+	return nil
+}
+
+// parse 解析时间间隔字符串
+func (d *fileDuration) parse(s string) error {
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q, expected format such as \"30s\"", s)
+	}
+	*d = fileDuration(duration)
+	return nil
+}
+
+// LoadConfig 从 JSON 或 YAML 配置文件读取配置
+// 按文件扩展名识别格式：.json 为 JSON，.yaml 和 .yml 为 YAML；配置文件中的未知字段视为错误
+// 未设置的配置项使用 DefaultConfig 的默认值，未设置 environment 和 baseUrl 时使用生产环境
+//
+// 参数:
+//   - path: 配置文件路径。privateKeyFile、publicKeyFile 为相对路径时相对于配置文件所在目录
+//
+// 返回:
+//   - *Config: 通过校验的配置对象，可继续链式调用 WithLogger 等方法补充配置
+//   - error: 文件读取失败、格式错误或校验失败时返回错误，字段相关的错误为 *ConfigError，
+//     Field 为配置文件中的字段名，例如 retry.count
+//
+// 示例:
+//
+//	# haozpay.yaml
+//	environment: sandbox
+//	merchantNo: HZ1971294971928846336
+//	privateKeyFile: keys/merchant_private.pem
+//	publicKeyFile: keys/platform_public.pem
+//	timeout: 10s
+//	retry:
+//	  count: 2
+//	  waitTime: 500ms
+//	  maxWait: 3s
+//
+//	config, err := haozpay.LoadConfig("haozpay.yaml")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	client, err := haozpay.NewClient(config)
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var fc fileConfig
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&fc); err != nil {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&fc); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported config file format %q, expected .json, .yaml or .yml", ext)
+	}
+
+	return fc.config(filepath.Dir(path))
+}
+
+// config 转换为 Config 并校验
+// dir 为配置文件所在目录，用于解析相对路径的密钥文件
+func (fc *fileConfig) config(dir string) (*Config, error) {
+	cfg := DefaultConfig()
+
+	if fc.Environment != "" {
+		env := Environment(strings.ToLower(fc.Environment))
+		if !env.IsValid() {
+			return nil, &ConfigError{Field: "environment", Message: fmt.Sprintf("unsupported environment %q, expected production or sandbox", fc.Environment)}
+		}
+		cfg.WithEnvironment(env)
+	}
+	if fc.BaseURL != "" {
+		cfg.WithBaseURL(fc.BaseURL)
+	}
+	if len(fc.Endpoints) > 0 {
+		cfg.WithEndpoints(fc.Endpoints...)
+	}
+	if cfg.BaseURL == "" {
+		cfg.WithEnvironment(EnvProduction)
+	}
+	if fc.EndpointProbeInterval != nil {
+		cfg.EndpointProbeInterval = time.Duration(*fc.EndpointProbeInterval)
+	}
+	cfg.SandboxInsecureSkipVerify = fc.SandboxInsecureSkipVerify
+
+	if fc.MerchantNo == "" {
+		return nil, &ConfigError{Field: "merchantNo", Message: "merchantNo is required"}
+	}
+	cfg.MerchantNo = fc.MerchantNo
+
+	if fc.SignType != "" {
+		signType := SignType(strings.ToUpper(fc.SignType))
+		if !isRegisteredSignType(signType) {
+			return nil, &ConfigError{Field: "signType", Message: fmt.Sprintf("unsupported signType %q, expected one of %v", fc.SignType, registeredSignTypes())}
+		}
+		cfg.SignType = signType
+	}
+
+	var err error
+	if cfg.PrivateKey, err = fileKey(fc.PrivateKey, fc.PrivateKeyFile, "privateKeyFile", dir); err != nil {
+		return nil, err
+	}
+	if cfg.PrivateKey == "" {
+		return nil, &ConfigError{Field: "privateKey", Message: "privateKey or privateKeyFile is required"}
+	}
+	if cfg.PublicKey, err = fileKey(fc.PublicKey, fc.PublicKeyFile, "publicKeyFile", dir); err != nil {
+		return nil, err
+	}
+	if cfg.ClientCertPEM, err = fileKey(fc.ClientCert, fc.ClientCertFile, "clientCertFile", dir); err != nil {
+		return nil, err
+	}
+	if cfg.ClientKeyPEM, err = fileKey(fc.ClientKey, fc.ClientKeyFile, "clientKeyFile", dir); err != nil {
+		return nil, err
+	}
+
+	if fc.Timeout != nil {
+		if *fc.Timeout <= 0 {
+			return nil, &ConfigError{Field: "timeout", Message: "timeout must be positive"}
+		}
+		cfg.Timeout = time.Duration(*fc.Timeout)
+	}
+	if retry := fc.Retry; retry != nil {
+		if retry.Count != nil {
+			if *retry.Count < 0 {
+				return nil, &ConfigError{Field: "retry.count", Message: "retry count must not be negative"}
+			}
+			cfg.RetryCount = *retry.Count
+		}
+		if retry.WaitTime != nil {
+			cfg.RetryWaitTime = time.Duration(*retry.WaitTime)
+		}
+		if retry.MaxWait != nil {
+			cfg.RetryMaxWait = time.Duration(*retry.MaxWait)
+		}
+		if cfg.RetryMaxWait < cfg.RetryWaitTime {
+			return nil, &ConfigError{Field: "retry.maxWait", Message: fmt.Sprintf("retry maxWait %s is less than waitTime %s", cfg.RetryMaxWait, cfg.RetryWaitTime)}
+		}
+	}
+	if rateLimit := fc.RateLimit; rateLimit != nil {
+		cfg.WithRateLimit(rateLimit.QPS, rateLimit.Burst)
+	}
+	if fc.ExchangeRateCacheTTL != nil {
+		cfg.ExchangeRateCacheTTL = time.Duration(*fc.ExchangeRateCacheTTL)
+	}
+	if fc.ChannelStatusCacheTTL != nil {
+		cfg.ChannelStatusCacheTTL = time.Duration(*fc.ChannelStatusCacheTTL)
+	}
+	if fc.BankInfoCacheTTL != nil {
+		cfg.BankInfoCacheTTL = time.Duration(*fc.BankInfoCacheTTL)
+	}
+	if fc.NotifyTimestampTolerance != nil {
+		cfg.NotifyTimestampTolerance = time.Duration(*fc.NotifyTimestampTolerance)
+	}
+	if fc.SlowRequestThreshold != nil {
+		cfg.SlowRequestThreshold = time.Duration(*fc.SlowRequestThreshold)
+	}
+	cfg.Debug = fc.Debug
+	cfg.DryRun = fc.DryRun
+	cfg.Trace = fc.Trace
+	cfg.StrictDecoding = fc.StrictDecoding
+	cfg.Proxy = fc.Proxy
+	cfg.ProxyUsername = fc.ProxyUsername
+	cfg.ProxyPassword = fc.ProxyPassword
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// fileKey 返回密钥内容，key 为空时读取 file 指定的密钥文件
+func fileKey(key, file, field, dir string) (string, error) {
+	if key != "" || file == "" {
+		return key, nil
+	}
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(dir, file)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", &ConfigError{Field: field, Message: err.Error()}
+	}
+	return strings.TrimSpace(string(data)), nil
+}
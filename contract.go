@@ -0,0 +1,56 @@
+package haozpay
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type ContractService struct {
+	executor *apiExecutor
+}
+
+func NewContractService(client *resty.Client, config *Config) *ContractService {
+	return &ContractService{
+		executor: newAPIExecutor(client, config),
+	}
+}
+
+func (s *ContractService) SignContract(ctx context.Context, req *SignContractRequest, opts ...RequestOption) (*SignContractResponse, error) {
+	var resp *SignContractResponse
+	if err := s.executor.post(ctx, "/pay-core/contract/sign", req, &resp, "failed to sign contract", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *ContractService) QueryContract(ctx context.Context, req *QueryContractRequest, opts ...RequestOption) (*QueryContractResponse, error) {
+	if err := checkContractIdentifier(req.ContractId, req.ContractCode); err != nil {
+		return nil, err
+	}
+
+	var resp *QueryContractResponse
+	if err := s.executor.post(ctx, "/pay-core/contract/query", req, &resp, "failed to query contract", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *ContractService) TerminateContract(ctx context.Context, req *TerminateContractRequest, opts ...RequestOption) error {
+	if err := checkContractIdentifier(req.ContractId, req.ContractCode); err != nil {
+		return err
+	}
+	return s.executor.post(ctx, "/pay-core/contract/terminate", req, nil, "failed to terminate contract", opts...)
+}
+
+// checkContractIdentifier 校验协议标识，平台协议号和商户协议号至少需要传入一个
+func checkContractIdentifier(contractId, contractCode string) error {
+	if contractId == "" && contractCode == "" {
+		return &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "contractId or contractCode is required",
+			StatusCode: 0,
+		}
+	}
+	return nil
+}
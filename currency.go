@@ -0,0 +1,71 @@
+package haozpay
+
+import "fmt"
+
+// Currency 币种，使用 ISO 4217 三位字母代码
+//
+// 下单和退款请求未设置币种时不传 currency 字段，由平台按人民币处理，
+// 与未支持多币种前的签名串保持一致；响应未返回币种时 SDK 填充为 CurrencyCNY
+type Currency string
+
+const (
+	// CurrencyCNY 人民币（默认币种）
+	CurrencyCNY Currency = "CNY"
+	// CurrencyHKD 港币
+	CurrencyHKD Currency = "HKD"
+	// CurrencyUSD 美元
+	CurrencyUSD Currency = "USD"
+	// CurrencyEUR 欧元
+	CurrencyEUR Currency = "EUR"
+	// CurrencyGBP 英镑
+	CurrencyGBP Currency = "GBP"
+	// CurrencyJPY 日元
+	CurrencyJPY Currency = "JPY"
+	// CurrencySGD 新加坡元
+	CurrencySGD Currency = "SGD"
+	// CurrencyAUD 澳大利亚元
+	CurrencyAUD Currency = "AUD"
+)
+
+// DefaultCurrency 默认币种
+const DefaultCurrency = CurrencyCNY
+
+// String 返回币种代码
+func (c Currency) String() string {
+	return string(c)
+}
+
+// IsValid 判断是否为格式正确的 ISO 4217 币种代码（三位大写字母）
+// 平台是否支持该币种以接口返回为准
+func (c Currency) IsValid() bool {
+	if len(c) != 3 {
+		return false
+	}
+	for i := 0; i < len(c); i++ {
+		if c[i] < 'A' || c[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// OrDefault 返回币种，为空时返回 DefaultCurrency
+func (c Currency) OrDefault() Currency {
+	if c == "" {
+		return DefaultCurrency
+	}
+	return c
+}
+
+// checkCurrency 校验请求中的币种，为空时表示默认币种
+// 币种会参与签名，格式不正确（例如小写）时平台验签或校验会失败，因此在发送前拒绝
+func checkCurrency(currency Currency) error {
+	if currency == "" || currency.IsValid() {
+		return nil
+	}
+	return &SDKError{
+		Code:       ErrInvalidParameter.Code,
+		Message:    fmt.Sprintf("invalid currency: %q, expected ISO 4217 code such as CNY", string(currency)),
+		StatusCode: 0,
+	}
+}
@@ -0,0 +1,100 @@
+// Package haozpaydatadog 将皓臻支付 SDK 的接口调用记录为 Datadog APM span
+//
+// 通过客户端的 OnBeforeCall、OnAfterCall 钩子在每次接口调用前后创建和结束 span，
+// span 的父 span 取自调用传入的 context（例如 HTTP 中间件创建的请求 span）；
+// 通过 OnRequestHeader 钩子将 span 的链路信息按 tracer 配置的传播格式（默认包括 W3C traceparent、tracestate）发送到平台
+//
+// 示例:
+//
+//	tracer.Start(tracer.WithService("order-service"))
+//	defer tracer.Stop()
+//
+//	client, err := haozpay.NewClient(config)
+//	if err != nil {
+//	    return err
+//	}
+//	haozpaydatadog.Instrument(client, "")
+package haozpaydatadog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/ext"
+	"gopkg.in/DataDog/dd-trace-go.v1/ddtrace/tracer"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+const (
+	// DefaultServiceName span 的默认服务名
+	DefaultServiceName = "haozpay"
+	// OperationName span 的操作名，资源名为接口路径
+	OperationName = "haozpay.call"
+
+	// TagRequestID 客户端请求ID的 span 标签
+	TagRequestID = "haozpay.request_id"
+	// TagGatewayRequestID 平台返回的请求ID的 span 标签，收到平台响应时设置
+	TagGatewayRequestID = "haozpay.gateway_request_id"
+	// TagCode 错误码的 span 标签，调用失败时设置
+	TagCode = "haozpay.code"
+)
+
+// Instrument 为客户端注册创建 Datadog span 的调用钩子
+// 由 Clone 派生的客户端继承钩子，无需重复注册
+//
+// 参数:
+//   - client: 皓臻支付客户端
+//   - serviceName: span 的服务名，为空时使用 DefaultServiceName
+func Instrument(client *haozpay.Client, serviceName string) {
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+
+	// 调用前后的钩子收到同一个 context，按 context 关联 span
+	var spans sync.Map
+
+	client.OnBeforeCall(func(ctx context.Context, op string, req interface{}) {
+		span, _ := tracer.StartSpanFromContext(ctx, OperationName,
+			tracer.ServiceName(serviceName),
+			tracer.ResourceName(op),
+			tracer.SpanType(ext.SpanTypeHTTP),
+			tracer.Tag(ext.Component, "haozpay-sdk"),
+			tracer.Tag(ext.SpanKind, ext.SpanKindClient),
+			tracer.Tag(TagRequestID, haozpay.RequestIDFromContext(ctx)),
+		)
+		spans.Store(ctx, span)
+	})
+
+	client.OnRequestHeader(func(ctx context.Context, op string, header http.Header) {
+		value, ok := spans.Load(ctx)
+		if !ok {
+			return
+		}
+		_ = tracer.Inject(value.(ddtrace.Span).Context(), tracer.HTTPHeadersCarrier(header))
+	})
+
+	client.OnAfterCall(func(ctx context.Context, op string, resp interface{}, err error) {
+		value, ok := spans.LoadAndDelete(ctx)
+		if !ok {
+			return
+		}
+		span := value.(ddtrace.Span)
+
+		if gatewayRequestID := haozpay.GatewayRequestIDFromContext(ctx); gatewayRequestID != "" {
+			span.SetTag(TagGatewayRequestID, gatewayRequestID)
+		}
+		var sdkErr *haozpay.SDKError
+		if errors.As(err, &sdkErr) {
+			span.SetTag(TagCode, sdkErr.Code)
+			if sdkErr.StatusCode != 0 {
+				span.SetTag(ext.HTTPCode, sdkErr.StatusCode)
+			}
+		}
+		// 错误来自平台响应而非程序异常，不记录调用栈
+		span.Finish(tracer.WithError(err), tracer.NoDebugStack())
+	})
+}
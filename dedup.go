@@ -0,0 +1,102 @@
+package haozpay
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultDedupStoreCapacity MemoryDedupStore 默认保留的记录数
+	DefaultDedupStoreCapacity = 100000
+	// DefaultDedupTTL 通知处理记录默认保留时间
+	// 平台在该时间内重复推送的同一通知不会再次调用业务处理函数
+	DefaultDedupTTL = 24 * time.Hour
+)
+
+// DedupStore 回调通知处理记录，用于回调处理器按通知标识去重
+//
+// 平台可能多次推送同一通知（应答丢失、超时重推等），且重推的报文签名和时间戳可能不同，
+// 因此 NonceStore 无法识别；DedupStore 按通知标识（例如 PaymentNotification.NotificationID）记录已处理成功的通知，
+// 回调处理器在调用业务处理函数前检查记录，处理成功后写入记录
+//
+// 并发收到的同一通知可能同时通过检查，业务处理仍应保持幂等
+type DedupStore interface {
+	// Processed 判断通知是否已处理成功
+	Processed(ctx context.Context, id string) (bool, error)
+	// MarkProcessed 记录通知已处理成功
+	MarkProcessed(ctx context.Context, id string) error
+}
+
+// MemoryDedupStore 基于内存的 DedupStore 实现，适用于单实例部署
+// 记录数超过容量时淘汰最早的记录（LRU），多实例部署请使用 haozpayredis.NewDedupStore
+// 通过 NewMemoryDedupStore 函数创建实例，可在多个 goroutine 中并发使用
+type MemoryDedupStore struct {
+	set *expiringSet
+	ttl time.Duration
+}
+
+// NewMemoryDedupStore 创建基于内存的 DedupStore
+//
+// 参数:
+//   - capacity: 最多保留的记录数，小于等于 0 时使用 DefaultDedupStoreCapacity
+//   - ttl: 记录保留时间，小于等于 0 时使用 DefaultDedupTTL
+//
+// 返回:
+//   - *MemoryDedupStore: 内存通知处理记录
+func NewMemoryDedupStore(capacity int, ttl time.Duration) *MemoryDedupStore {
+	if capacity <= 0 {
+		capacity = DefaultDedupStoreCapacity
+	}
+	if ttl <= 0 {
+		ttl = DefaultDedupTTL
+	}
+	return &MemoryDedupStore{set: newExpiringSet(capacity), ttl: ttl}
+}
+
+// Processed 实现 DedupStore 接口
+func (s *MemoryDedupStore) Processed(ctx context.Context, id string) (bool, error) {
+	return s.set.contains(id), nil
+}
+
+// MarkProcessed 实现 DedupStore 接口
+func (s *MemoryDedupStore) MarkProcessed(ctx context.Context, id string) error {
+	s.set.add(id, s.ttl)
+	return nil
+}
+
+// NotificationID 返回支付通知的去重标识
+// 同一订单的同一状态只处理一次，格式为 payment:{merchantNo}:{orderNo}:{orderStatus}
+func (n *PaymentNotification) NotificationID() string {
+	return "payment:" + n.MerchantNo + ":" + n.OrderNo + ":" + strconv.Itoa(int(n.OrderStatus))
+}
+
+// NotificationID 返回退款通知的去重标识，格式为 refund:{merchantNo}:{refundSeqId}:{refundStatus}
+func (n *RefundNotification) NotificationID() string {
+	return "refund:" + n.MerchantNo + ":" + n.RefundSeqId + ":" + strconv.Itoa(int(n.RefundStatus))
+}
+
+// NotificationID 返回提现通知的去重标识，格式为 withdraw:{merchantNo}:{reqSeqId}:{withdrawStatus}
+func (n *WithdrawNotification) NotificationID() string {
+	return "withdraw:" + n.MerchantNo + ":" + n.ReqSeqId + ":" + strconv.Itoa(int(n.WithdrawStatus))
+}
+
+// NotificationID 返回转账通知的去重标识，格式为 transfer:{merchantNo}:{reqSeqId}:{transferStatus}
+func (n *TransferNotification) NotificationID() string {
+	return "transfer:" + n.MerchantNo + ":" + n.ReqSeqId + ":" + strconv.Itoa(int(n.TransferStatus))
+}
+
+// NotificationID 返回代扣扣款通知的去重标识，格式为 deduct:{merchantNo}:{orderNo}:{orderStatus}
+func (n *DeductNotification) NotificationID() string {
+	return "deduct:" + n.MerchantNo + ":" + n.OrderNo + ":" + strconv.Itoa(int(n.OrderStatus))
+}
+
+// NotificationID 返回代扣协议通知的去重标识，格式为 contract:{merchantNo}:{contractId}:{contractStatus}
+func (n *ContractNotification) NotificationID() string {
+	return "contract:" + n.MerchantNo + ":" + n.ContractId + ":" + strconv.Itoa(int(n.ContractStatus))
+}
+
+// NotificationID 返回电子发票通知的去重标识，格式为 invoice:{merchantNo}:{invoiceNo}:{invoiceStatus}
+func (n *InvoiceNotification) NotificationID() string {
+	return "invoice:" + n.MerchantNo + ":" + n.InvoiceNo + ":" + strconv.Itoa(int(n.InvoiceStatus))
+}
@@ -0,0 +1,151 @@
+package haozpay
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Do 调用皓臻支付网关接口，用于调用 SDK 尚未封装的接口
+// 与业务服务使用同一套请求流程：业务参数序列化（含敏感字段加密）、请求签名、响应验签、
+// 重试、限流、调用钩子和错误处理
+//
+// 类型参数:
+//   - T: 响应 data 的解析目标类型
+//
+// 参数:
+//   - ctx: 上下文
+//   - client: SDK 客户端
+//   - path: 接口路径，例如 /pay-core/payment/order/query
+//   - biz: 业务参数，序列化为 bizBody；使用结构体时可通过 haozpay:"encrypt" 标签加密敏感字段
+//   - opts: 单次调用的请求选项
+//
+// 返回:
+//   - *T: 响应数据，平台未返回 data 时为 nil
+//   - error: 请求失败或业务响应码非 0 时返回 SDKError
+//
+// 注意: 默认重试策略只对 SDK 已知的幂等查询接口重试，新接口幂等时可传入 WithRequestRetryPolicy(RetryAlways) 启用重试
+//
+// 示例:
+//
+//	type SettleQueryResponse struct {
+//	    SettleStatus int           `json:"settleStatus"`
+//	    SettleAmount haozpay.Money `json:"settleAmount"`
+//	}
+//
+//	resp, err := haozpay.Do[SettleQueryResponse](ctx, client, "/pay-core/settle/query", map[string]string{
+//	    "settleDate": "20240101",
+//	})
+func Do[T any](ctx context.Context, client *Client, path string, biz interface{}, opts ...RequestOption) (*T, error) {
+	return call[T](ctx, client.executor, path, biz, "failed to call "+path, opts...)
+}
+
+// DoRaw 调用皓臻支付网关接口，返回完整的响应报文，响应 data 保留为原始 JSON
+// 请求流程与 Do 相同，适用于需要读取响应外层字段（平台请求ID、时间戳），
+// 或按响应内容决定 data 解析类型的场景，data 可通过 Response.DecodeData 按需解析
+//
+// 参数:
+//   - ctx: 上下文
+//   - client: SDK 客户端
+//   - path: 接口路径
+//   - biz: 业务参数，序列化为 bizBody
+//   - opts: 单次调用的请求选项
+//
+// 返回:
+//   - *Response: 响应报文，Data 为原始 JSON，平台未返回 data 或 data 为 null 时为空
+//   - error: 请求失败或业务响应码非 0 时返回 SDKError
+//
+// 示例:
+//
+//	resp, err := haozpay.DoRaw(ctx, client, "/pay-core/settle/query", map[string]string{
+//	    "settleDate": "20240101",
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	var settle SettleQueryResponse
+//	if err := resp.DecodeData(&settle); err != nil {
+//	    return err
+//	}
+func DoRaw(ctx context.Context, client *Client, path string, biz interface{}, opts ...RequestOption) (*Response, error) {
+	var (
+		resp Response
+		data json.RawMessage
+	)
+	opts = append(opts, withResponseEnvelope(&resp))
+	if err := client.executor.post(ctx, path, biz, &data, "failed to call "+path, opts...); err != nil {
+		return nil, err
+	}
+	if string(data) != "null" {
+		resp.Data = data
+	}
+	return &resp, nil
+}
+
+// withResponseEnvelope 将响应报文的外层字段写入 resp，供 DoRaw 使用
+func withResponseEnvelope(resp *Response) RequestOption {
+	return func(o *requestOptions) {
+		o.envelope = resp
+	}
+}
+
+// DecodeData 将响应 data 解析到 v，data 为空或 null 时不修改 v
+// 使用 encoding/json 解析，与 Config.Codec 无关
+//
+// 参数:
+//   - v: 解析目标，需为指针
+//
+// 返回:
+//   - error: data 与 v 的类型不匹配时返回错误
+func (r *Response) DecodeData(v interface{}) error {
+	if len(r.Data) == 0 || string(r.Data) == "null" {
+		return nil
+	}
+	return json.Unmarshal(r.Data, v)
+}
+
+// DoGet 以 GET 方式调用皓臻支付网关的只读接口，用于调用 SDK 尚未封装的查询接口
+// 业务参数的各字段与 merchantNo、timestamp、signType、sign 作为查询参数发送，
+// 签名串规则与 Do 一致，对象和数组类型的参数编码为 JSON 字符串；GET 接口在默认重试策略下会重试
+//
+// 类型参数:
+//   - T: 响应 data 的解析目标类型
+//
+// 参数:
+//   - ctx: 上下文
+//   - client: SDK 客户端
+//   - path: 接口路径，例如 /pay-core/bank/list
+//   - biz: 业务参数，必须序列化为 JSON 对象，为 nil 时只发送公共参数
+//   - opts: 单次调用的请求选项
+//
+// 返回:
+//   - *T: 响应数据，平台未返回 data 时为 nil
+//   - error: 请求失败或业务响应码非 0 时返回 SDKError
+//
+// 示例:
+//
+//	resp, err := haozpay.DoGet[BankListResponse](ctx, client, "/pay-core/bank/list", map[string]string{
+//	    "bankType": "DEBIT",
+//	})
+func DoGet[T any](ctx context.Context, client *Client, path string, biz interface{}, opts ...RequestOption) (*T, error) {
+	return callGet[T](ctx, client.executor, path, biz, "failed to call "+path, opts...)
+}
+
+// call 发送业务请求并将响应 data 解析为 T
+// 参数与 apiExecutor.post 一致，响应 data 为空时返回 nil
+func call[T any](ctx context.Context, e *apiExecutor, path string, req interface{}, errMessage string, opts ...RequestOption) (*T, error) {
+	var resp *T
+	if err := e.post(ctx, path, req, &resp, errMessage, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// callGet 发送 GET 业务请求并将响应 data 解析为 T
+// 参数与 apiExecutor.get 一致，响应 data 为空时返回 nil
+func callGet[T any](ctx context.Context, e *apiExecutor, path string, req interface{}, errMessage string, opts ...RequestOption) (*T, error) {
+	var resp *T
+	if err := e.get(ctx, path, req, &resp, errMessage, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
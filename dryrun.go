@@ -0,0 +1,83 @@
+package haozpay
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// dryRunKey context 中存储单次调用演练模式的键
+type dryRunKey struct{}
+
+// WithRequestDryRun 本次调用使用演练模式，效果与 Config.DryRun 相同，只对本次调用生效
+//
+// 示例:
+//
+//	// 验证下单请求的参数和签名，不实际下单
+//	_, err := client.Payment.CreateOrder(ctx, req, haozpay.WithRequestDryRun())
+func WithRequestDryRun() RequestOption {
+	return func(o *requestOptions) {
+		o.dryRun = true
+	}
+}
+
+// isDryRun 判断本次请求是否使用演练模式
+func isDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
+// dryRunTransport 演练模式的传输层
+// 请求已经过参数校验、序列化和签名，演练模式下以 Info 级别输出将要发送的请求，
+// 不发送到平台，直接返回业务响应码为 0、data 为空对象的合成响应
+type dryRunTransport struct {
+	base   http.RoundTripper
+	logger Logger
+	// enabled 是否对所有请求使用演练模式（Config.DryRun）
+	enabled bool
+}
+
+// newDryRunTransport 创建演练模式的传输层
+func newDryRunTransport(base http.RoundTripper, logger Logger, enabled bool) *dryRunTransport {
+	return &dryRunTransport{base: base, logger: logger, enabled: enabled}
+}
+
+// RoundTrip 实现 http.RoundTripper 接口
+func (t *dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.enabled && !isDryRun(req.Context()) {
+		return t.base.RoundTrip(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	callLogger(req.Context(), t.logger).Info("[SDK DryRun] request not sent",
+		"method", req.Method,
+		"url", req.URL.String(),
+		"body", string(body),
+	)
+
+	respBody := fmt.Sprintf(`{"code":0,"message":"dry run","request_id":%q,"data":{}}`, req.Header.Get(RequestIDHeader))
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json")
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(respBody))),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
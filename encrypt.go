@@ -0,0 +1,249 @@
+package haozpay
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/emmansun/gmsm/sm2"
+	"github.com/emmansun/gmsm/smx509"
+)
+
+const (
+	// encryptTagName 敏感字段标记使用的结构体标签名
+	encryptTagName = "haozpay"
+	// encryptTagValue 标记字段需要使用平台公钥加密
+	encryptTagValue = "encrypt"
+)
+
+// EncryptSensitive 使用平台公钥加密敏感信息（银行卡号、身份证号、姓名等）
+//
+// 参数:
+//   - plaintext: 待加密的明文
+//   - platformPublicKey: 平台公钥(PEM格式或纯Base64格式)，RSA 公钥使用 PKCS1v15 加密，SM2 公钥使用 SM2 加密
+//
+// 返回:
+//   - string: Base64编码的密文
+//   - error: 公钥解析或加密失败时返回错误
+//
+// 注意:
+//   - 请求结构体中带有 `haozpay:"encrypt"` 标签的字段会在发送前自动加密，一般无需直接调用
+func EncryptSensitive(plaintext string, platformPublicKey string) (string, error) {
+	encryptor := newFieldEncryptor(platformPublicKey)
+	return encryptor.encrypt(plaintext)
+}
+
+// fieldEncryptor 敏感字段加密器
+// 平台公钥在首次使用时解析，之后复用解析结果
+type fieldEncryptor struct {
+	publicKeyPEM string
+
+	once      sync.Once
+	publicKey interface{}
+	err       error
+}
+
+// newFieldEncryptor 创建敏感字段加密器
+func newFieldEncryptor(publicKeyPEM string) *fieldEncryptor {
+	return &fieldEncryptor{publicKeyPEM: publicKeyPEM}
+}
+
+// encrypt 加密单个字段值
+func (e *fieldEncryptor) encrypt(plaintext string) (string, error) {
+	e.once.Do(func() {
+		if e.publicKeyPEM == "" {
+			e.err = errors.New("PublicKey is required to encrypt sensitive fields")
+			return
+		}
+		keyBytes, err := decodePublicKeyBytes(e.publicKeyPEM)
+		if err != nil {
+			e.err = err
+			return
+		}
+		e.publicKey, e.err = smx509.ParsePKIXPublicKey(keyBytes)
+	})
+	if e.err != nil {
+		return "", e.err
+	}
+
+	var (
+		ciphertext []byte
+		err        error
+	)
+	switch pub := e.publicKey.(type) {
+	case *rsa.PublicKey:
+		ciphertext, err = rsa.EncryptPKCS1v15(rand.Reader, pub, []byte(plaintext))
+	case *ecdsa.PublicKey:
+		ciphertext, err = sm2.EncryptASN1(rand.Reader, pub, []byte(plaintext))
+	default:
+		err = fmt.Errorf("unsupported public key type: %T", e.publicKey)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// marshalBizBody 使用 codec 将业务请求序列化为 bizBody
+// 带有 `haozpay:"encrypt"` 标签的字段会先在副本上加密，不修改调用方传入的请求对象
+func marshalBizBody(req interface{}, encryptor *fieldEncryptor, codec Codec) ([]byte, error) {
+	value := reflect.ValueOf(req)
+	if !value.IsValid() || !hasEncryptedFields(value.Type()) {
+		return codec.Marshal(req)
+	}
+
+	encrypted, err := encryptFields(value, encryptor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt sensitive fields: %w", err)
+	}
+	return codec.Marshal(encrypted.Interface())
+}
+
+// encryptFields 返回加密敏感字段后的副本
+func encryptFields(value reflect.Value, encryptor *fieldEncryptor) (reflect.Value, error) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() || !hasEncryptedFields(value.Type()) {
+			return value, nil
+		}
+		elem, err := encryptFields(value.Elem(), encryptor)
+		if err != nil {
+			return value, err
+		}
+		copied := reflect.New(elem.Type())
+		copied.Elem().Set(elem)
+		return copied, nil
+
+	case reflect.Struct:
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			fieldValue := copied.Field(i)
+
+			if isEncryptTag(field.Tag) {
+				if err := encryptFieldValue(fieldValue, encryptor); err != nil {
+					return value, fmt.Errorf("%s: %w", field.Name, err)
+				}
+				continue
+			}
+
+			if hasEncryptedFields(field.Type) {
+				encrypted, err := encryptFields(fieldValue, encryptor)
+				if err != nil {
+					return value, fmt.Errorf("%s.%w", field.Name, err)
+				}
+				fieldValue.Set(encrypted)
+			}
+		}
+		return copied, nil
+
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return value, nil
+		}
+		var copied reflect.Value
+		if value.Kind() == reflect.Slice {
+			copied = reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		} else {
+			copied = reflect.New(value.Type()).Elem()
+		}
+		for i := 0; i < value.Len(); i++ {
+			encrypted, err := encryptFields(value.Index(i), encryptor)
+			if err != nil {
+				return value, fmt.Errorf("[%d].%w", i, err)
+			}
+			copied.Index(i).Set(encrypted)
+		}
+		return copied, nil
+	}
+
+	return value, nil
+}
+
+// encryptFieldValue 加密 string 或 *string 字段，空值不加密
+func encryptFieldValue(fieldValue reflect.Value, encryptor *fieldEncryptor) error {
+	switch {
+	case fieldValue.Kind() == reflect.String:
+		if fieldValue.String() == "" {
+			return nil
+		}
+		ciphertext, err := encryptor.encrypt(fieldValue.String())
+		if err != nil {
+			return err
+		}
+		fieldValue.SetString(ciphertext)
+		return nil
+
+	case fieldValue.Kind() == reflect.Ptr && fieldValue.Type().Elem().Kind() == reflect.String:
+		if fieldValue.IsNil() || fieldValue.Elem().String() == "" {
+			return nil
+		}
+		ciphertext, err := encryptor.encrypt(fieldValue.Elem().String())
+		if err != nil {
+			return err
+		}
+		fieldValue.Set(reflect.ValueOf(&ciphertext))
+		return nil
+	}
+
+	return fmt.Errorf("encrypt tag is only supported on string fields, got %s", fieldValue.Type())
+}
+
+// isEncryptTag 判断字段是否标记为需要加密
+func isEncryptTag(tag reflect.StructTag) bool {
+	for _, option := range strings.Split(tag.Get(encryptTagName), ",") {
+		if strings.TrimSpace(option) == encryptTagValue {
+			return true
+		}
+	}
+	return false
+}
+
+// encryptedTypes 缓存各类型是否包含需要加密的字段
+var encryptedTypes sync.Map
+
+// hasEncryptedFields 判断类型中是否包含带加密标签的字段（递归检查嵌套结构体、指针、切片）
+func hasEncryptedFields(t reflect.Type) bool {
+	if cached, ok := encryptedTypes.Load(t); ok {
+		return cached.(bool)
+	}
+
+	result := typeHasEncryptedFields(t, make(map[reflect.Type]bool))
+	encryptedTypes.Store(t, result)
+	return result
+}
+
+// typeHasEncryptedFields 递归检查类型，visited 用于避免自引用类型无限递归
+func typeHasEncryptedFields(t reflect.Type, visited map[reflect.Type]bool) bool {
+	if visited[t] {
+		return false
+	}
+	visited[t] = true
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return typeHasEncryptedFields(t.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if isEncryptTag(field.Tag) || typeHasEncryptedFields(field.Type, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
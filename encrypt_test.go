@@ -0,0 +1,213 @@
+package haozpay
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/emmansun/gmsm/sm2"
+	"github.com/emmansun/gmsm/smx509"
+)
+
+// testPayee 带加密字段的嵌套结构体
+type testPayee struct {
+	Name   string  `json:"name" haozpay:"encrypt"`
+	Mobile *string `json:"mobile,omitempty" haozpay:"encrypt"`
+	Bank   string  `json:"bank"`
+}
+
+// testEncryptRequest 在各种位置包含加密字段的请求
+type testEncryptRequest struct {
+	Account   string         `json:"account" haozpay:"encrypt"`
+	Empty     string         `json:"empty" haozpay:"encrypt"`
+	Payee     testPayee      `json:"payee"`
+	Guarantor *testPayee     `json:"guarantor"`
+	Missing   *testPayee     `json:"missing"`
+	Items     []testPayee    `json:"items"`
+	Pointers  []*testPayee   `json:"pointers"`
+	Fixed     [1]testPayee   `json:"fixed"`
+	NoItems   []testPayee    `json:"noItems"`
+	Remark    string         `json:"remark"`
+	Extra     map[string]any `json:"extra"`
+}
+
+// decryptRSA 使用测试私钥解密 Base64 编码的密文
+func decryptRSA(t *testing.T, ciphertext string) string {
+	t.Helper()
+	privateKeyPEM, _ := testKeyPair(t)
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		t.Fatalf("ciphertext %q is not Base64: %v", ciphertext, err)
+	}
+	plaintext, err := rsa.DecryptPKCS1v15(rand.Reader, key, data)
+	if err != nil {
+		t.Fatalf("failed to decrypt %q: %v", ciphertext, err)
+	}
+	return string(plaintext)
+}
+
+func TestMarshalBizBodyEncryptsTaggedFields(t *testing.T) {
+	_, publicKey := testKeyPair(t)
+	mobile := "13800000001"
+	req := &testEncryptRequest{
+		Account:   "6222020000000001",
+		Payee:     testPayee{Name: "张三", Mobile: &mobile, Bank: "ICBC"},
+		Guarantor: &testPayee{Name: "李四"},
+		Items:     []testPayee{{Name: "王五"}, {Name: "赵六", Bank: "CCB"}},
+		Pointers:  []*testPayee{{Name: "钱七"}, nil},
+		Fixed:     [1]testPayee{{Name: "孙八"}},
+		Remark:    "plain remark",
+		Extra:     map[string]any{"name": "not tagged"},
+	}
+	before := *req
+	beforePayee := *req.Guarantor
+
+	bizBody, err := marshalBizBody(req, newFieldEncryptor(publicKey), StdCodec)
+	if err != nil {
+		t.Fatalf("marshalBizBody() error = %v", err)
+	}
+
+	// 明文不会出现在 bizBody 中
+	for _, plaintext := range []string{"6222020000000001", "13800000001", "张三", "李四", "王五", "赵六", "钱七", "孙八"} {
+		if bytes.Contains(bizBody, []byte(plaintext)) {
+			t.Errorf("bizBody contains plaintext %q: %s", plaintext, bizBody)
+		}
+	}
+
+	var got testEncryptRequest
+	if err := json.Unmarshal(bizBody, &got); err != nil {
+		t.Fatal(err)
+	}
+	decrypted := map[string]string{
+		"account":          decryptRSA(t, got.Account),
+		"payee.name":       decryptRSA(t, got.Payee.Name),
+		"payee.mobile":     decryptRSA(t, *got.Payee.Mobile),
+		"guarantor.name":   decryptRSA(t, got.Guarantor.Name),
+		"items[0].name":    decryptRSA(t, got.Items[0].Name),
+		"items[1].name":    decryptRSA(t, got.Items[1].Name),
+		"pointers[0].name": decryptRSA(t, got.Pointers[0].Name),
+		"fixed[0].name":    decryptRSA(t, got.Fixed[0].Name),
+	}
+	want := map[string]string{
+		"account":          "6222020000000001",
+		"payee.name":       "张三",
+		"payee.mobile":     "13800000001",
+		"guarantor.name":   "李四",
+		"items[0].name":    "王五",
+		"items[1].name":    "赵六",
+		"pointers[0].name": "钱七",
+		"fixed[0].name":    "孙八",
+	}
+	if !reflect.DeepEqual(decrypted, want) {
+		t.Errorf("decrypted fields = %v, want %v", decrypted, want)
+	}
+
+	// 未标记的字段、空值和 nil 保持原样
+	if got.Empty != "" || got.Missing != nil || got.NoItems != nil || got.Pointers[1] != nil || got.Guarantor.Mobile != nil {
+		t.Errorf("empty values changed: %s", bizBody)
+	}
+	if got.Payee.Bank != "ICBC" || got.Items[1].Bank != "CCB" || got.Remark != "plain remark" || got.Extra["name"] != "not tagged" {
+		t.Errorf("untagged fields changed: %s", bizBody)
+	}
+
+	// 在副本上加密，不修改调用方的请求对象
+	if !reflect.DeepEqual(*req, before) || *req.Guarantor != beforePayee || *req.Payee.Mobile != "13800000001" || req.Items[0].Name != "王五" || req.Pointers[0].Name != "钱七" {
+		t.Errorf("request was modified: %+v", req)
+	}
+}
+
+func TestMarshalBizBodyTransferRequests(t *testing.T) {
+	_, publicKey := testKeyPair(t)
+	encryptor := newFieldEncryptor(publicKey)
+
+	bizBody, err := marshalBizBody(&CreateBatchTransferRequest{
+		BatchNo: "B1",
+		Items: []BatchTransferItem{
+			{ItemSeqId: "I1", PayeeAccount: "6222020000000001", PayeeName: "张三", BankCode: "ICBC"},
+		},
+	}, encryptor, StdCodec)
+	if err != nil {
+		t.Fatalf("marshalBizBody() error = %v", err)
+	}
+	var batch CreateBatchTransferRequest
+	if err := json.Unmarshal(bizBody, &batch); err != nil {
+		t.Fatal(err)
+	}
+	item := batch.Items[0]
+	if decryptRSA(t, item.PayeeAccount) != "6222020000000001" || decryptRSA(t, item.PayeeName) != "张三" {
+		t.Errorf("batch item payee was not encrypted: %s", bizBody)
+	}
+	if item.ItemSeqId != "I1" || item.BankCode != "ICBC" || item.PayeeIdCardNo != "" || item.PayeeMobile != "" {
+		t.Errorf("batch item = %+v", item)
+	}
+
+	// 不含加密字段的请求按 codec 原样序列化
+	plain := &QueryOrderRequest{OrderNo: "P1"}
+	got, err := marshalBizBody(plain, encryptor, StdCodec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, _ := StdCodec.Marshal(plain); !bytes.Equal(got, want) {
+		t.Errorf("bizBody = %s, want %s", got, want)
+	}
+}
+
+func TestMarshalBizBodyErrors(t *testing.T) {
+	_, publicKey := testKeyPair(t)
+
+	type invalidItem struct {
+		Count int `json:"count" haozpay:"encrypt"`
+	}
+	type invalidRequest struct {
+		Items []invalidItem `json:"items"`
+	}
+	_, err := marshalBizBody(&invalidRequest{Items: []invalidItem{{Count: 1}}}, newFieldEncryptor(publicKey), StdCodec)
+	if err == nil || !strings.Contains(err.Error(), "Items.[0].Count: encrypt tag is only supported on string fields") {
+		t.Errorf("marshalBizBody() with an int field error = %v", err)
+	}
+
+	// 未配置平台公钥时返回错误，不发送明文
+	_, err = marshalBizBody(&testEncryptRequest{Account: "6222020000000001"}, newFieldEncryptor(""), StdCodec)
+	if err == nil || !strings.Contains(err.Error(), "PublicKey is required") {
+		t.Errorf("marshalBizBody() without a public key error = %v", err)
+	}
+}
+
+func TestEncryptSensitiveSM2(t *testing.T) {
+	key, err := sm2.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicDER, err := smx509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ciphertext, err := EncryptSensitive("6222020000000001", string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})))
+	if err != nil {
+		t.Fatalf("EncryptSensitive() error = %v", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext, err := sm2.Decrypt(key, data)
+	if err != nil {
+		t.Fatalf("sm2.Decrypt() error = %v", err)
+	}
+	if string(plaintext) != "6222020000000001" {
+		t.Errorf("plaintext = %q", plaintext)
+	}
+}
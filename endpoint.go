@@ -0,0 +1,257 @@
+package haozpay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultEndpointProbeInterval 不可用网关地址的默认健康探测间隔
+	DefaultEndpointProbeInterval = 30 * time.Second
+	// endpointProbeTimeout 单次健康探测的最长等待时间
+	endpointProbeTimeout = 5 * time.Second
+)
+
+// parseEndpoint 解析网关地址，去掉路径末尾的 /
+func parseEndpoint(endpoint string) (*url.URL, error) {
+	u, err := url.Parse(strings.TrimSpace(endpoint))
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme %q, expected http or https", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, errors.New("host is required")
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	return u, nil
+}
+
+// endpointPool 按优先级在多个网关地址之间切换的传输层
+//
+// 请求总是发往优先级最高的可用地址：
+//   - 连接失败（拨号失败、DNS 解析失败）时请求未到达网关，立即改发下一个地址，并将该地址标记为不可用
+//   - 返回 5xx 时请求可能已被处理，原样返回响应，只将该地址标记为不可用，由重试策略决定是否重发
+//
+// 存在不可用地址时，请求触发后台健康探测（每个探测间隔最多一次），探测恢复的地址重新参与选择，
+// 主地址恢复后请求自动切回主地址
+type endpointPool struct {
+	base      http.RoundTripper
+	endpoints []*url.URL
+	interval  time.Duration
+	logger    Logger
+
+	mu        sync.Mutex
+	down      []bool
+	probing   bool
+	lastProbe time.Time
+}
+
+// newEndpointPool 创建多地址传输层，endpoints 的第一个地址为主地址，与 BaseURL 相同
+func newEndpointPool(base http.RoundTripper, endpoints []string, interval time.Duration, logger Logger) (*endpointPool, error) {
+	if interval <= 0 {
+		interval = DefaultEndpointProbeInterval
+	}
+	pool := &endpointPool{
+		base:     base,
+		interval: interval,
+		logger:   logger,
+		down:     make([]bool, len(endpoints)),
+	}
+	for _, endpoint := range endpoints {
+		u, err := parseEndpoint(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("endpoint %s is invalid: %w", endpoint, err)
+		}
+		pool.endpoints = append(pool.endpoints, u)
+	}
+	return pool, nil
+}
+
+// active 返回当前优先使用的地址
+func (p *endpointPool) active() string {
+	return p.endpoints[p.order()[0]].String()
+}
+
+// order 返回本次请求尝试地址的顺序：可用地址在前，不可用地址在后，各自按优先级排列
+func (p *endpointPool) order() []int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	order := make([]int, 0, len(p.endpoints))
+	for i := range p.endpoints {
+		if !p.down[i] {
+			order = append(order, i)
+		}
+	}
+	for i := range p.endpoints {
+		if p.down[i] {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// RoundTrip 实现 http.RoundTripper 接口
+func (p *endpointPool) RoundTrip(req *http.Request) (*http.Response, error) {
+	primary := p.endpoints[0]
+	if req.URL.Scheme != primary.Scheme || req.URL.Host != primary.Host || !strings.HasPrefix(req.URL.Path, primary.Path) {
+		// 对账单下载地址等不属于网关的请求直接发送
+		return p.base.RoundTrip(req)
+	}
+	p.probeIfNeeded()
+
+	var lastErr error
+	for attempt, i := range p.order() {
+		if attempt > 0 && req.Body != nil && req.GetBody == nil {
+			// 请求体无法重新读取，不能改发下一个地址
+			break
+		}
+		r, err := p.rewrite(req, i, attempt > 0)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := p.base.RoundTrip(r)
+		if err != nil {
+			if !isConnectError(err) || req.Context().Err() != nil {
+				return nil, err
+			}
+			p.markDown(i, err.Error())
+			lastErr = err
+			continue
+		}
+		if resp.StatusCode >= 500 {
+			p.markDown(i, resp.Status)
+		}
+		return resp, nil
+	}
+	return nil, lastErr
+}
+
+// rewrite 返回发往第 i 个地址的请求副本，resend 为 true 时重新读取请求体
+func (p *endpointPool) rewrite(req *http.Request, i int, resend bool) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if resend && req.Body != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	if i == 0 {
+		return r, nil
+	}
+	endpoint := p.endpoints[i]
+	r.URL.Scheme = endpoint.Scheme
+	r.URL.Host = endpoint.Host
+	r.URL.Path = endpoint.Path + strings.TrimPrefix(req.URL.Path, p.endpoints[0].Path)
+	r.URL.RawPath = ""
+	r.Host = ""
+	return r, nil
+}
+
+// markDown 将地址标记为不可用，下一次健康探测在一个探测间隔之后
+func (p *endpointPool) markDown(i int, reason string) {
+	p.mu.Lock()
+	wasDown := p.down[i]
+	p.down[i] = true
+	p.lastProbe = time.Now()
+	p.mu.Unlock()
+
+	if !wasDown {
+		p.logger.Warn("[SDK] gateway endpoint is unavailable, failing over",
+			"endpoint", p.endpoints[i].String(),
+			"reason", reason,
+		)
+	}
+}
+
+// probeIfNeeded 存在不可用地址且距离上次探测超过探测间隔时，在后台探测不可用地址
+func (p *endpointPool) probeIfNeeded() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.probing || time.Since(p.lastProbe) < p.interval {
+		return
+	}
+	var targets []int
+	for i, down := range p.down {
+		if down {
+			targets = append(targets, i)
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+	p.probing = true
+	go p.probe(targets)
+}
+
+// probe 向不可用地址发送 HEAD 请求，收到 5xx 以外的响应即视为恢复
+func (p *endpointPool) probe(targets []int) {
+	recovered := make([]bool, len(targets))
+	for n, i := range targets {
+		recovered[n] = p.probeEndpoint(p.endpoints[i])
+	}
+
+	p.mu.Lock()
+	for n, i := range targets {
+		if recovered[n] {
+			p.down[i] = false
+		}
+	}
+	p.probing = false
+	p.lastProbe = time.Now()
+	p.mu.Unlock()
+
+	for n, i := range targets {
+		if recovered[n] {
+			p.logger.Info("[SDK] gateway endpoint recovered", "endpoint", p.endpoints[i].String())
+		}
+	}
+}
+
+// probeEndpoint 探测单个地址是否可用
+func (p *endpointPool) probeEndpoint(endpoint *url.URL) bool {
+	timeout := endpointProbeTimeout
+	if p.interval < timeout {
+		timeout = p.interval
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint.String()+"/", nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("User-Agent", UserAgent)
+	resp, err := p.base.RoundTrip(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode < 500
+}
+
+// isConnectError 判断错误是否发生在连接建立阶段，此时请求未到达网关，可安全地改发其他地址
+// 经过代理时，代理无法连接网关（CONNECT 返回 5xx）同样视为连接失败
+func isConnectError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var proxyErr *ProxyError
+	if errors.As(err, &proxyErr) {
+		return proxyErr.StatusCode >= 500
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
@@ -0,0 +1,217 @@
+package haozpay
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testEndpoint 记录收到的请求的模拟网关地址，status 为非零值时对所有请求返回该状态码
+type testEndpoint struct {
+	*httptest.Server
+	status atomic.Int32
+
+	mu     sync.Mutex
+	paths  []string
+	bodies []string
+}
+
+func newTestEndpoint(t *testing.T) *testEndpoint {
+	t.Helper()
+	e := &testEndpoint{}
+	e.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			body, _ := io.ReadAll(r.Body)
+			e.mu.Lock()
+			e.paths = append(e.paths, r.URL.Path)
+			e.bodies = append(e.bodies, string(body))
+			e.mu.Unlock()
+		}
+		if status := e.status.Load(); status != 0 {
+			w.WriteHeader(int(status))
+		}
+	}))
+	t.Cleanup(e.Close)
+	return e
+}
+
+// received 返回收到的非探测请求的路径和请求体
+func (e *testEndpoint) received() ([]string, []string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.paths...), append([]string(nil), e.bodies...)
+}
+
+// newTestEndpointPool 创建按 endpoints 顺序切换的传输层
+func newTestEndpointPool(t *testing.T, interval time.Duration, endpoints ...string) *endpointPool {
+	t.Helper()
+	pool, err := newEndpointPool(&http.Transport{}, endpoints, interval, NewStdLogger(io.Discard, LogLevelError))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pool
+}
+
+// roundTrip 通过 pool 发送 POST 请求，返回响应状态码
+func roundTrip(t *testing.T, pool *endpointPool, url, body string) (int, error) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := pool.RoundTrip(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func TestEndpointPoolConnectErrorFailover(t *testing.T) {
+	primary := newTestEndpoint(t)
+	primary.Close()
+	backup := newTestEndpoint(t)
+	pool := newTestEndpointPool(t, time.Hour, primary.URL+"/gw", backup.URL+"/dr/")
+
+	// 主地址拒绝连接，请求未到达网关，改发灾备地址，路径按地址前缀替换，请求体重新读取
+	status, err := roundTrip(t, pool, primary.URL+"/gw/pay-core/payment/order", `{"a":1}`)
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("RoundTrip() = %d, %v", status, err)
+	}
+	paths, bodies := backup.received()
+	if len(paths) != 1 || paths[0] != "/dr/pay-core/payment/order" || bodies[0] != `{"a":1}` {
+		t.Errorf("backup received %q %q", paths, bodies)
+	}
+	if got := pool.active(); got != backup.URL+"/dr" {
+		t.Errorf("active() = %s, want backup", got)
+	}
+
+	// 主地址已标记为不可用，之后的请求直接发往灾备地址
+	if _, err := roundTrip(t, pool, primary.URL+"/gw/pay-core/payment/order", ""); err != nil {
+		t.Fatal(err)
+	}
+	if paths, _ := backup.received(); len(paths) != 2 {
+		t.Errorf("backup requests = %d, want 2", len(paths))
+	}
+}
+
+func TestEndpointPoolServerError(t *testing.T) {
+	primary := newTestEndpoint(t)
+	primary.status.Store(http.StatusBadGateway)
+	backup := newTestEndpoint(t)
+	pool := newTestEndpointPool(t, time.Hour, primary.URL, backup.URL)
+
+	// 5xx 时请求可能已被处理，原样返回响应，不改发灾备地址
+	status, err := roundTrip(t, pool, primary.URL+"/pay-core/payment/refund", "")
+	if err != nil || status != http.StatusBadGateway {
+		t.Fatalf("RoundTrip() = %d, %v, want %d", status, err, http.StatusBadGateway)
+	}
+	if paths, _ := backup.received(); len(paths) != 0 {
+		t.Fatalf("backup received %q, want no requests", paths)
+	}
+
+	// 主地址已标记为不可用，由重试策略重发的请求发往灾备地址
+	if got := pool.active(); got != backup.URL {
+		t.Errorf("active() = %s, want backup", got)
+	}
+	if status, err := roundTrip(t, pool, primary.URL+"/pay-core/payment/refund", ""); err != nil || status != http.StatusOK {
+		t.Fatalf("second RoundTrip() = %d, %v", status, err)
+	}
+	if paths, _ := primary.received(); len(paths) != 1 {
+		t.Errorf("primary requests = %d, want 1", len(paths))
+	}
+}
+
+func TestEndpointPoolProbeRecovery(t *testing.T) {
+	primary := newTestEndpoint(t)
+	primary.status.Store(http.StatusServiceUnavailable)
+	backup := newTestEndpoint(t)
+	const interval = 20 * time.Millisecond
+	pool := newTestEndpointPool(t, interval, primary.URL, backup.URL)
+	const url = "/pay-core/payment/order/query"
+
+	if status, _ := roundTrip(t, pool, primary.URL+url, ""); status != http.StatusServiceUnavailable {
+		t.Fatalf("RoundTrip() = %d, want %d", status, http.StatusServiceUnavailable)
+	}
+
+	// 探测仍返回 5xx 时地址保持不可用
+	time.Sleep(2 * interval)
+	if _, err := roundTrip(t, pool, primary.URL+url, ""); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(interval / 2)
+	if got := pool.active(); got != backup.URL {
+		t.Fatalf("active() while primary is failing = %s, want backup", got)
+	}
+
+	// 主地址恢复后，请求触发的后台探测将其重新标记为可用，之后的请求切回主地址
+	primary.status.Store(0)
+	deadline := time.Now().Add(5 * time.Second)
+	for pool.active() != primary.URL {
+		if time.Now().After(deadline) {
+			t.Fatal("primary endpoint was not recovered by the probe")
+		}
+		if _, err := roundTrip(t, pool, primary.URL+url, ""); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(interval / 4)
+	}
+
+	before, _ := primary.received()
+	if status, err := roundTrip(t, pool, primary.URL+url, ""); err != nil || status != http.StatusOK {
+		t.Fatalf("RoundTrip() after recovery = %d, %v", status, err)
+	}
+	if after, _ := primary.received(); len(after) != len(before)+1 {
+		t.Errorf("primary requests after recovery = %d, want %d", len(after), len(before)+1)
+	}
+}
+
+func TestEndpointPoolNonRewindableBody(t *testing.T) {
+	primary := newTestEndpoint(t)
+	primary.Close()
+	backup := newTestEndpoint(t)
+	pool := newTestEndpointPool(t, time.Hour, primary.URL, backup.URL)
+
+	// 请求体无法重新读取时不改发灾备地址，返回连接错误
+	req, err := http.NewRequest(http.MethodPost, primary.URL+"/pay-core/payment/order", io.NopCloser(strings.NewReader(`{"a":1}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.GetBody != nil {
+		t.Fatal("request body is rewindable")
+	}
+	if resp, err := pool.RoundTrip(req); err == nil {
+		resp.Body.Close()
+		t.Fatalf("RoundTrip() status = %d, want connect error", resp.StatusCode)
+	}
+	if paths, _ := backup.received(); len(paths) != 0 {
+		t.Errorf("backup received %q, want no requests", paths)
+	}
+}
+
+func TestEndpointPoolPassthrough(t *testing.T) {
+	primary := newTestEndpoint(t)
+	primary.Close()
+	backup := newTestEndpoint(t)
+	other := newTestEndpoint(t)
+	pool := newTestEndpointPool(t, time.Hour, primary.URL+"/gw", backup.URL)
+
+	// 不属于网关的请求（例如对账单下载地址）直接发送，不参与切换
+	if status, err := roundTrip(t, pool, other.URL+"/bill/1.csv", ""); err != nil || status != http.StatusOK {
+		t.Fatalf("RoundTrip() = %d, %v", status, err)
+	}
+	if _, err := roundTrip(t, pool, primary.URL+"/other", ""); err == nil {
+		t.Error("RoundTrip() outside the gateway path succeeded, want connect error")
+	}
+	if paths, _ := backup.received(); len(paths) != 0 {
+		t.Errorf("backup received %q, want no requests", paths)
+	}
+	if got := pool.active(); got != primary.URL+"/gw" {
+		t.Errorf("active() = %s, want primary", got)
+	}
+}
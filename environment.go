@@ -0,0 +1,45 @@
+package haozpay
+
+import "strings"
+
+// Environment 皓臻支付网关环境
+type Environment string
+
+const (
+	// EnvProduction 生产环境
+	EnvProduction Environment = "production"
+	// EnvSandbox 沙箱环境，用于开发联调和 CI 测试，不产生真实资金流动
+	EnvSandbox Environment = "sandbox"
+)
+
+const (
+	// ProductionBaseURL 生产环境 API 地址
+	ProductionBaseURL = "https://gate.haozpay.com"
+	// SandboxBaseURL 沙箱环境 API 地址
+	SandboxBaseURL = "https://sandbox-gate.haozpay.com"
+
+	// EnvironmentHeader 标记请求所属环境的请求头
+	// 沙箱环境的请求会携带该请求头，生产网关收到带沙箱标记的请求会直接拒绝
+	EnvironmentHeader = "X-HaozPay-Env"
+)
+
+// BaseURL 返回环境对应的 API 地址，未知环境返回空字符串
+func (e Environment) BaseURL() string {
+	switch e {
+	case EnvProduction:
+		return ProductionBaseURL
+	case EnvSandbox:
+		return SandboxBaseURL
+	}
+	return ""
+}
+
+// IsValid 判断是否为已知环境
+func (e Environment) IsValid() bool {
+	return e == EnvProduction || e == EnvSandbox
+}
+
+// isProductionURL 判断地址是否指向生产环境
+func isProductionURL(baseURL string) bool {
+	return strings.TrimRight(strings.ToLower(strings.TrimSpace(baseURL)), "/") == ProductionBaseURL
+}
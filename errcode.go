@@ -0,0 +1,87 @@
+package haozpay
+
+import (
+	"fmt"
+	"strings"
+)
+
+//go:generate go run ./internal/errcodegen
+
+// ErrorCategory 错误的处理建议
+type ErrorCategory int
+
+const (
+	// ErrorCategoryUnknown 错误码未收录，处理建议未知
+	ErrorCategoryUnknown ErrorCategory = iota
+	// ErrorCategoryRetry 暂时性错误，稍后重试即可恢复（变更类接口重试前应先查询结果）
+	ErrorCategoryRetry
+	// ErrorCategoryFixRequest 请求参数或商户配置错误，修正后重新请求
+	ErrorCategoryFixRequest
+	// ErrorCategoryContactSupport 需要联系平台技术支持处理
+	ErrorCategoryContactSupport
+)
+
+var errorCategoryNames = map[ErrorCategory]string{
+	ErrorCategoryUnknown:        "unknown",
+	ErrorCategoryRetry:          "retry",
+	ErrorCategoryFixRequest:     "fix-request",
+	ErrorCategoryContactSupport: "contact-support",
+}
+
+// String 返回处理建议名称，未知处理建议返回 ErrorCategory(n)
+func (c ErrorCategory) String() string {
+	if name, ok := errorCategoryNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("ErrorCategory(%d)", int(c))
+}
+
+// CodeInfo 错误码说明
+type CodeInfo struct {
+	// Code 错误码
+	Code int
+	// Name 错误码名称，与 Code 常量名的后缀一致，例如 Timeout 对应 CodeTimeout
+	Name string
+	// Category 处理建议
+	Category ErrorCategory
+	// DescriptionZH 中文描述
+	DescriptionZH string
+	// DescriptionEN 英文描述
+	DescriptionEN string
+}
+
+// Description 返回指定语言的错误描述
+// lang 为 en 或以 en- 开头（如 en-US）时返回英文描述，其他语言返回中文描述
+func (c CodeInfo) Description(lang string) string {
+	lang = strings.ToLower(lang)
+	if lang == "en" || strings.HasPrefix(lang, "en-") || strings.HasPrefix(lang, "en_") {
+		return c.DescriptionEN
+	}
+	return c.DescriptionZH
+}
+
+// DescribeCode 查询错误码的名称、描述和处理建议
+// 错误码表由 internal/errcodegen/codes.csv 生成，平台错误码按平台文档补充到 codes.csv 后执行 go generate
+//
+// 参数:
+//   - code: 错误码，通常为 SDKError.Code
+//
+// 返回:
+//   - CodeInfo: 错误码说明，未收录的错误码返回仅包含 Code 的说明，处理建议为 ErrorCategoryUnknown
+//   - bool: 错误码是否已收录
+//
+// 示例:
+//
+//	var sdkErr *haozpay.SDKError
+//	if errors.As(err, &sdkErr) {
+//	    info, _ := haozpay.DescribeCode(sdkErr.Code)
+//	    if info.Category == haozpay.ErrorCategoryFixRequest {
+//	        return fmt.Errorf("%s: %s", info.Description("zh"), sdkErr.Message)
+//	    }
+//	}
+func DescribeCode(code int) (CodeInfo, bool) {
+	if info, ok := codeCatalog[code]; ok {
+		return info, true
+	}
+	return CodeInfo{Code: code}, false
+}
@@ -0,0 +1,41 @@
+// Code generated by internal/errcodegen from internal/errcodegen/codes.csv; DO NOT EDIT.
+
+package haozpay
+
+// 错误码常量，与 SDKError.Code 比较
+const (
+	// CodeTimeout 请求超时
+	CodeTimeout = 1001
+	// CodeNetworkError 网络错误
+	CodeNetworkError = 1002
+	// CodeInvalidResponse 平台响应格式错误
+	CodeInvalidResponse = 1003
+	// CodeUnauthorized 商户认证失败，请检查商户号和密钥配置
+	CodeUnauthorized = 1004
+	// CodeForbidden 商户未开通该接口权限
+	CodeForbidden = 1005
+	// CodeNotFound 接口不存在，请检查请求路径和环境配置
+	CodeNotFound = 1006
+	// CodeServerError 平台服务异常
+	CodeServerError = 1007
+	// CodeSignatureVerification 响应验签失败，请检查平台公钥配置
+	CodeSignatureVerification = 1008
+	// CodeInvalidParameter 请求参数错误
+	CodeInvalidParameter = 1009
+	// CodeProxy 代理服务器错误
+	CodeProxy = 1010
+)
+
+// codeCatalog 错误码表
+var codeCatalog = map[int]CodeInfo{
+	CodeTimeout:               {Code: CodeTimeout, Name: "Timeout", Category: ErrorCategoryRetry, DescriptionZH: "请求超时", DescriptionEN: "request timeout"},
+	CodeNetworkError:          {Code: CodeNetworkError, Name: "NetworkError", Category: ErrorCategoryRetry, DescriptionZH: "网络错误", DescriptionEN: "network error"},
+	CodeInvalidResponse:       {Code: CodeInvalidResponse, Name: "InvalidResponse", Category: ErrorCategoryContactSupport, DescriptionZH: "平台响应格式错误", DescriptionEN: "invalid response"},
+	CodeUnauthorized:          {Code: CodeUnauthorized, Name: "Unauthorized", Category: ErrorCategoryFixRequest, DescriptionZH: "商户认证失败，请检查商户号和密钥配置", DescriptionEN: "unauthorized"},
+	CodeForbidden:             {Code: CodeForbidden, Name: "Forbidden", Category: ErrorCategoryContactSupport, DescriptionZH: "商户未开通该接口权限", DescriptionEN: "forbidden"},
+	CodeNotFound:              {Code: CodeNotFound, Name: "NotFound", Category: ErrorCategoryFixRequest, DescriptionZH: "接口不存在，请检查请求路径和环境配置", DescriptionEN: "not found"},
+	CodeServerError:           {Code: CodeServerError, Name: "ServerError", Category: ErrorCategoryRetry, DescriptionZH: "平台服务异常", DescriptionEN: "server error"},
+	CodeSignatureVerification: {Code: CodeSignatureVerification, Name: "SignatureVerification", Category: ErrorCategoryFixRequest, DescriptionZH: "响应验签失败，请检查平台公钥配置", DescriptionEN: "signature verification failed"},
+	CodeInvalidParameter:      {Code: CodeInvalidParameter, Name: "InvalidParameter", Category: ErrorCategoryFixRequest, DescriptionZH: "请求参数错误", DescriptionEN: "invalid parameter"},
+	CodeProxy:                 {Code: CodeProxy, Name: "Proxy", Category: ErrorCategoryRetry, DescriptionZH: "代理服务器错误", DescriptionEN: "proxy error"},
+}
@@ -1,22 +1,164 @@
 package haozpay
 
-import "fmt"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
 
 type SDKError struct {
 	Code       int
 	Message    string
 	RequestID  string
 	StatusCode int
+	// Err 导致该错误的底层错误，可能为 nil
+	Err error
+	// Header 平台响应的 HTTP 头，未收到响应（参数错误、网络错误等）时为 nil
+	Header http.Header
+	// RawBody 平台响应的原始报文，最多保留 MaxErrorBodySize 字节，未收到响应时为 nil
+	// 响应格式错误、验签失败、业务错误时可据此排查，无需开启调试模式重新请求
+	RawBody []byte
+	// RawBodyTruncated 原始报文是否因超过 MaxErrorBodySize 被截断
+	RawBodyTruncated bool
+	// Details 平台返回的明细错误码和字段校验错误，平台未返回明细或未收到响应时为 nil
+	Details *ErrorDetails
 }
 
+// ErrorDetails 平台错误响应中的明细错误信息
+// Code 为网关的通用错误码（如参数错误），SubCode 进一步说明具体原因（如金额超出单笔限额），
+// 参数校验失败时 FieldErrors 列出每个不合法的字段
+type ErrorDetails struct {
+	// SubCode 明细错误码
+	SubCode string
+	// SubMessage 明细错误信息
+	SubMessage string
+	// FieldErrors 字段校验错误
+	FieldErrors []FieldError
+}
+
+// FieldError 单个请求字段的校验错误
+type FieldError struct {
+	// Field 字段名，与请求报文中的 JSON 字段名一致，嵌套字段以 . 分隔
+	Field string `json:"field"`
+	// Code 校验错误码
+	Code string `json:"code,omitempty"`
+	// Message 校验错误信息
+	Message string `json:"message"`
+}
+
+// MaxErrorBodySize SDKError.RawBody 保留的最大字节数
+const MaxErrorBodySize = 16 << 10
+
 func (e *SDKError) Error() string {
 	if e.RequestID != "" {
-		return fmt.Sprintf("[%d] %s (RequestID: %s, StatusCode: %d)", 
+		return fmt.Sprintf("[%d] %s (RequestID: %s, StatusCode: %d)",
 			e.Code, e.Message, e.RequestID, e.StatusCode)
 	}
 	return fmt.Sprintf("[%d] %s (StatusCode: %d)", e.Code, e.Message, e.StatusCode)
 }
 
+// Unwrap 返回底层错误，支持 errors.Is 和 errors.As
+// 网络错误和超时的底层错误为传输层返回的原始错误，例如可通过 errors.As 取得 net.Error
+func (e *SDKError) Unwrap() error {
+	return e.Err
+}
+
+// Is 按错误码判断是否为同一类错误，支持 errors.Is(err, ErrTimeout) 等判断
+// ErrUnauthorized、ErrForbidden、ErrNotFound 同时按 HTTP 状态码匹配，ErrServerError 匹配所有 5xx 响应
+func (e *SDKError) Is(target error) bool {
+	t, ok := target.(*SDKError)
+	if !ok {
+		return false
+	}
+	if e.Code == t.Code {
+		return true
+	}
+
+	switch t.Code {
+	case ErrUnauthorized.Code, ErrForbidden.Code, ErrNotFound.Code:
+		return e.StatusCode == t.StatusCode
+	case ErrServerError.Code:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// retryableCodes 可通过重试恢复的错误码
+var retryableCodes = map[int]bool{
+	ErrTimeout.Code:      true,
+	ErrNetworkError.Code: true,
+	ErrServerError.Code:  true,
+}
+
+// Retryable 判断错误是否可以通过重试恢复
+// 按错误码表判断（超时、网络错误、服务端错误），HTTP 429 和 5xx 响应同样视为可重试
+// 代理错误按 ProxyError.Temporary 判断；参数错误、业务错误、验签失败等重试也无法恢复，返回 false
+//
+// 注意：超时和网络错误时请求可能已被平台处理，下单、退款等变更类接口重试前应先查询结果
+func (e *SDKError) Retryable() bool {
+	var proxyErr *ProxyError
+	if errors.As(e.Err, &proxyErr) {
+		return proxyErr.Temporary()
+	}
+	return retryableCodes[e.Code] ||
+		e.StatusCode == http.StatusTooManyRequests ||
+		e.StatusCode >= 500
+}
+
+// Temporary 判断错误是否为暂时性错误，与 Retryable 一致
+// 便于按 interface{ Temporary() bool } 判断错误的代码识别 SDKError
+func (e *SDKError) Temporary() bool {
+	return e.Retryable()
+}
+
+// httpStatusErrors HTTP 错误状态码对应的预定义错误
+var httpStatusErrors = map[int]*SDKError{
+	http.StatusUnauthorized: ErrUnauthorized,
+	http.StatusForbidden:    ErrForbidden,
+	http.StatusNotFound:     ErrNotFound,
+}
+
+// httpStatusError 返回 HTTP 错误状态码对应的预定义错误
+// 5xx 对应 ErrServerError，没有对应预定义错误的状态码返回 ErrInvalidResponse
+func httpStatusError(statusCode int) *SDKError {
+	if err, ok := httpStatusErrors[statusCode]; ok {
+		return err
+	}
+	if statusCode >= 500 {
+		return ErrServerError
+	}
+	return ErrInvalidResponse
+}
+
+// transportErrorCode 将请求执行过程中的错误转换为预定义错误码
+//   - 连接代理失败或代理拒绝请求（ProxyError）：ErrProxy
+//   - 超时（context 超时或 net.Error 超时）：ErrTimeout
+//   - 响应体 JSON 格式错误：ErrInvalidResponse
+//   - 其他错误（连接失败、DNS 解析失败等）：ErrNetworkError
+func transportErrorCode(err error) int {
+	var proxyErr *ProxyError
+	if errors.As(err, &proxyErr) {
+		return ErrProxy.Code
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return ErrTimeout.Code
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var decodeErr *decodeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.As(err, &decodeErr) {
+		return ErrInvalidResponse.Code
+	}
+
+	return ErrNetworkError.Code
+}
+
 func NewSDKError(code int, message string, statusCode int) *SDKError {
 	return &SDKError{
 		Code:       code,
@@ -34,6 +176,19 @@ func NewSDKErrorWithRequestID(code int, message string, statusCode int, requestI
 	}
 }
 
+// responseError 将平台的错误响应转换为 SDKError，并附加响应中的明细错误信息
+func responseError(resp *Response, statusCode int) *SDKError {
+	err := NewSDKErrorWithRequestID(resp.Code, resp.Message, statusCode, resp.RequestID)
+	if resp.SubCode != "" || resp.SubMessage != "" || len(resp.FieldErrors) > 0 {
+		err.Details = &ErrorDetails{
+			SubCode:     resp.SubCode,
+			SubMessage:  resp.SubMessage,
+			FieldErrors: resp.FieldErrors,
+		}
+	}
+	return err
+}
+
 type ConfigError struct {
 	Field   string
 	Message string
@@ -51,11 +206,20 @@ func ErrInvalidConfig(message string) *ConfigError {
 }
 
 var (
-	ErrTimeout         = NewSDKError(1001, "request timeout", 0)
-	ErrNetworkError    = NewSDKError(1002, "network error", 0)
-	ErrInvalidResponse = NewSDKError(1003, "invalid response", 0)
-	ErrUnauthorized    = NewSDKError(1004, "unauthorized", 401)
-	ErrForbidden       = NewSDKError(1005, "forbidden", 403)
-	ErrNotFound        = NewSDKError(1006, "not found", 404)
-	ErrServerError     = NewSDKError(1007, "server error", 500)
-)
\ No newline at end of file
+	ErrTimeout         = NewSDKError(CodeTimeout, "request timeout", 0)
+	ErrNetworkError    = NewSDKError(CodeNetworkError, "network error", 0)
+	ErrInvalidResponse = NewSDKError(CodeInvalidResponse, "invalid response", 0)
+	ErrUnauthorized    = NewSDKError(CodeUnauthorized, "unauthorized", 401)
+	ErrForbidden       = NewSDKError(CodeForbidden, "forbidden", 403)
+	ErrNotFound        = NewSDKError(CodeNotFound, "not found", 404)
+	ErrServerError     = NewSDKError(CodeServerError, "server error", 500)
+
+	ErrSignatureVerification = NewSDKError(CodeSignatureVerification, "signature verification failed", 0)
+	ErrInvalidParameter      = NewSDKError(CodeInvalidParameter, "invalid parameter", 0)
+	ErrProxy                 = NewSDKError(CodeProxy, "proxy error", 0)
+)
+
+// ErrSignatureInvalid 签名验证失败
+// 回调通知验签、响应验签和 VerifySign 失败时返回的错误均包装该错误，
+// 可通过 errors.Is(err, ErrSignatureInvalid) 与网络错误等其他错误区分
+var ErrSignatureInvalid = errors.New("signature verification failed")
@@ -0,0 +1,256 @@
+package haozpay
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// exchangeRateDateLayout 汇率日期格式
+const exchangeRateDateLayout = "20060102"
+
+// CurrencyPair 货币对，表示 1 单位 Base 可兑换的 Quote 数量
+type CurrencyPair struct {
+	// Base 基准币种，例如 USD
+	Base Currency
+	// Quote 计价币种，例如 CNY
+	Quote Currency
+}
+
+// ParseCurrencyPair 解析货币对字符串
+//
+// 参数:
+//   - s: 货币对，格式为 BASE/QUOTE，例如 "USD/CNY"
+//
+// 返回:
+//   - CurrencyPair: 货币对
+//   - error: 格式错误或币种代码不合法时返回错误
+func ParseCurrencyPair(s string) (CurrencyPair, error) {
+	base, quote, ok := strings.Cut(s, "/")
+	pair := CurrencyPair{Base: Currency(strings.TrimSpace(base)), Quote: Currency(strings.TrimSpace(quote))}
+	if !ok || !pair.Base.IsValid() || !pair.Quote.IsValid() {
+		return CurrencyPair{}, fmt.Errorf("invalid currency pair %q, expected format such as USD/CNY", s)
+	}
+	return pair, nil
+}
+
+// String 返回 BASE/QUOTE 格式的货币对字符串
+func (p CurrencyPair) String() string {
+	return string(p.Base) + "/" + string(p.Quote)
+}
+
+// Rate 汇率，以十进制字符串保存，避免浮点运算带来的精度问题
+// 反序列化同时支持数字（7.1234）和字符串（"7.1234"）两种形式
+type Rate string
+
+// Rat 返回汇率的精确有理数表示
+func (r Rate) Rat() (*big.Rat, error) {
+	rat, ok := new(big.Rat).SetString(string(r))
+	if !ok {
+		return nil, fmt.Errorf("invalid exchange rate %q", string(r))
+	}
+	return rat, nil
+}
+
+// String 返回汇率的十进制字符串
+func (r Rate) String() string {
+	return string(r)
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口，支持数字和字符串两种形式
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+	rate := Rate(bytes.TrimSpace(data))
+	if rate != "" {
+		if _, err := rate.Rat(); err != nil {
+			return err
+		}
+	}
+	*r = rate
+	return nil
+}
+
+// MarshalJSON 实现 json.Marshaler 接口，序列化为十进制数
+func (r Rate) MarshalJSON() ([]byte, error) {
+	if r == "" {
+		return []byte("null"), nil
+	}
+	return []byte(r), nil
+}
+
+// Convert 按汇率将基准币种金额换算为计价币种金额，结果四舍五入到分
+//
+// 参数:
+//   - amount: 基准币种金额
+//
+// 返回:
+//   - Money: 计价币种金额
+//   - error: 汇率格式错误时返回错误
+func (r *ExchangeRateResponse) Convert(amount Money) (Money, error) {
+	rate, err := r.Rate.Rat()
+	if err != nil {
+		return 0, err
+	}
+
+	product := new(big.Rat).Mul(new(big.Rat).SetInt64(amount.Fen()), rate)
+	// 四舍五入（远离零）：|x| + 1/2 向下取整后恢复符号
+	num := new(big.Int).Abs(product.Num())
+	den := product.Denom()
+	num.Mul(num, big.NewInt(2)).Add(num, den)
+	num.Quo(num, new(big.Int).Mul(den, big.NewInt(2)))
+	if product.Sign() < 0 {
+		num.Neg(num)
+	}
+	if !num.IsInt64() {
+		return 0, fmt.Errorf("converted amount overflows: %s * %s", amount, r.Rate)
+	}
+	return Fen(num.Int64()), nil
+}
+
+type ExchangeRateService struct {
+	executor *apiExecutor
+	cache    *exchangeRateCache
+}
+
+func NewExchangeRateService(client *resty.Client, config *Config) *ExchangeRateService {
+	return &ExchangeRateService{
+		executor: newAPIExecutor(client, config),
+		cache:    newExchangeRateCache(config.ExchangeRateCacheTTL),
+	}
+}
+
+// QueryExchangeRate 查询平台的日汇率
+//
+// 汇率按货币对和日期缓存，缓存时间由 Config.ExchangeRateCacheTTL 控制，
+// 缓存有效期内重复查询同一货币对和日期不会请求平台
+//
+// 参数:
+//   - ctx: 上下文
+//   - pair: 货币对，例如 CurrencyPair{Base: CurrencyUSD, Quote: CurrencyCNY}
+//   - date: 汇率日期，按 date 所在时区取日期；为零值时查询当日汇率
+//   - opts: 单次调用的请求选项
+//
+// 返回:
+//   - *ExchangeRateResponse: 汇率信息，多次调用可能返回同一缓存实例，调用方不应修改
+//   - error: 货币对不合法或查询失败时返回错误
+//
+// 示例:
+//
+//	rate, err := client.ExchangeRate.QueryExchangeRate(ctx, haozpay.CurrencyPair{
+//	    Base:  haozpay.CurrencyUSD,
+//	    Quote: haozpay.CurrencyCNY,
+//	}, time.Now())
+//	if err != nil {
+//	    return err
+//	}
+//	cny, err := rate.Convert(haozpay.MustParseMoney("19.99"))
+func (s *ExchangeRateService) QueryExchangeRate(ctx context.Context, pair CurrencyPair, date time.Time, opts ...RequestOption) (*ExchangeRateResponse, error) {
+	if !pair.Base.IsValid() || !pair.Quote.IsValid() {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid currency pair: %s", pair),
+			StatusCode: 0,
+		}
+	}
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	req := &QueryExchangeRateRequest{
+		BaseCurrency:  pair.Base,
+		QuoteCurrency: pair.Quote,
+		RateDate:      date.Format(exchangeRateDateLayout),
+	}
+
+	key := pair.String() + "@" + req.RateDate
+	if resp, ok := s.cache.get(key); ok {
+		return resp, nil
+	}
+
+	var resp *ExchangeRateResponse
+	if err := s.executor.post(ctx, "/pay-core/exchange/rate/query", req, &resp, "failed to query exchange rate", opts...); err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Rate == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidResponse.Code,
+			Message:    fmt.Sprintf("exchange rate of %s on %s is not available", pair, req.RateDate),
+			StatusCode: 0,
+		}
+	}
+
+	s.cache.set(key, resp)
+	return resp, nil
+}
+
+// exchangeRateCache 汇率缓存
+// ttl 小于等于 0 时不缓存
+type exchangeRateCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]exchangeRateEntry
+}
+
+// exchangeRateEntry 汇率缓存条目
+type exchangeRateEntry struct {
+	resp      *ExchangeRateResponse
+	expiresAt time.Time
+}
+
+// newExchangeRateCache 创建汇率缓存
+func newExchangeRateCache(ttl time.Duration) *exchangeRateCache {
+	return &exchangeRateCache{
+		ttl:     ttl,
+		entries: make(map[string]exchangeRateEntry),
+	}
+}
+
+// get 返回未过期的缓存汇率
+func (c *exchangeRateCache) get(key string) (*ExchangeRateResponse, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.resp, true
+}
+
+// set 缓存汇率，同时清理已过期的条目
+func (c *exchangeRateCache) set(key string, resp *ExchangeRateResponse) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = exchangeRateEntry{resp: resp, expiresAt: now.Add(c.ttl)}
+}
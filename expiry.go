@@ -0,0 +1,137 @@
+package haozpay
+
+import (
+	"encoding/pem"
+	"fmt"
+	"time"
+
+	"github.com/emmansun/gmsm/smx509"
+)
+
+// certificatePEMType X.509 证书的 PEM 块类型
+const certificatePEMType = "CERTIFICATE"
+
+// CertificateExpiry 证书的有效期信息
+type CertificateExpiry struct {
+	// Field 证书所在的配置项，PublicKey（平台证书）、MerchantPublicKey（商户证书）或 ClientCertificate（mTLS 客户端证书）
+	Field string
+	// Subject 证书主题
+	Subject string
+	// SerialNumber 证书序列号（十六进制）
+	SerialNumber string
+	// NotAfter 证书到期时间
+	NotAfter time.Time
+}
+
+// DaysUntilExpiry 返回距离证书到期的天数（向下取整），已过期时为负数
+func (e CertificateExpiry) DaysUntilExpiry() int {
+	remaining := time.Until(e.NotAfter)
+	days := int(remaining / (24 * time.Hour))
+	if remaining < 0 && remaining%(24*time.Hour) != 0 {
+		days--
+	}
+	return days
+}
+
+// Expired 判断证书是否已过期
+func (e CertificateExpiry) Expired() bool {
+	return time.Now().After(e.NotAfter)
+}
+
+// CertExpiryFunc 证书即将到期时的回调函数，可用于发送告警或上报指标
+type CertExpiryFunc func(expiry CertificateExpiry)
+
+// parseCertificate 解析 DER 格式的 X.509 证书，支持 RSA 和 SM2 证书
+func parseCertificate(der []byte) (*smx509.Certificate, error) {
+	cert, err := smx509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	return cert, nil
+}
+
+// CertificateExpiries 返回以证书形式配置的平台公钥、商户公钥以及 mTLS 客户端证书的有效期
+// 纯公钥格式的配置项没有有效期，不包含在结果中
+//
+// 返回:
+//   - []CertificateExpiry: 证书有效期信息
+//   - error: 证书解析失败、客户端证书无法加载或已过期时返回 *ConfigError
+//
+// 示例:
+//
+//	expiries, err := config.CertificateExpiries()
+//	if err != nil {
+//	    return err
+//	}
+//	for _, e := range expiries {
+//	    metrics.Gauge("haozpay_cert_days_left", e.DaysUntilExpiry(), "field", e.Field)
+//	}
+func (c *Config) CertificateExpiries() ([]CertificateExpiry, error) {
+	var expiries []CertificateExpiry
+	for _, key := range []struct {
+		field string
+		pem   string
+	}{
+		{field: "PublicKey", pem: c.PublicKey},
+		{field: "MerchantPublicKey", pem: c.MerchantPublicKey},
+	} {
+		block, _ := pem.Decode([]byte(key.pem))
+		if block == nil || block.Type != certificatePEMType {
+			continue
+		}
+		cert, err := parseCertificate(block.Bytes)
+		if err != nil {
+			return nil, &ConfigError{Field: key.field, Message: err.Error()}
+		}
+		expiries = append(expiries, CertificateExpiry{
+			Field:        key.field,
+			Subject:      cert.Subject.String(),
+			SerialNumber: cert.SerialNumber.Text(16),
+			NotAfter:     cert.NotAfter,
+		})
+	}
+
+	clientCert, err := c.loadClientCertificate()
+	if err != nil {
+		return nil, err
+	}
+	if clientCert != nil {
+		expiries = append(expiries, CertificateExpiry{
+			Field:        clientCertificateField,
+			Subject:      clientCert.Leaf.Subject.String(),
+			SerialNumber: clientCert.Leaf.SerialNumber.Text(16),
+			NotAfter:     clientCert.Leaf.NotAfter,
+		})
+	}
+	return expiries, nil
+}
+
+// CheckCertificateExpiry 检查证书是否即将到期
+// 剩余有效期不超过 CertExpiryWarning 的证书（包括已过期的证书）会传给 OnCertExpiry 回调
+// NewClient 在设置了 CertExpiryWarning 时检查一次并输出警告日志，
+// 长期运行的服务可定期调用本方法，例如每天一次
+//
+// 返回:
+//   - []CertificateExpiry: 即将到期的证书，CertExpiryWarning 小于等于 0 时为空
+//   - error: 证书解析失败时返回 *ConfigError
+func (c *Config) CheckCertificateExpiry() ([]CertificateExpiry, error) {
+	if c.CertExpiryWarning <= 0 {
+		return nil, nil
+	}
+	expiries, err := c.CertificateExpiries()
+	if err != nil {
+		return nil, err
+	}
+
+	var expiring []CertificateExpiry
+	for _, expiry := range expiries {
+		if time.Until(expiry.NotAfter) > c.CertExpiryWarning {
+			continue
+		}
+		expiring = append(expiring, expiry)
+		if c.OnCertExpiry != nil {
+			c.OnCertExpiry(expiry)
+		}
+	}
+	return expiring, nil
+}
@@ -0,0 +1,58 @@
+package haozpay_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"log/slog"
+	"testing"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+	"github.com/haoz-cloud/haozpay-sdk/haozpaytest"
+)
+
+// newTestGateway 启动模拟网关，返回网关和指向网关的客户端，configure 用于在创建客户端前调整配置
+func newTestGateway(t *testing.T, configure ...func(*haozpay.Config)) (*haozpaytest.Server, *haozpay.Client) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	publicKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+
+	server, err := haozpaytest.NewServer(string(publicKeyPEM))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Close)
+
+	cfg := server.ClientConfig().
+		WithPrivateKey(string(privateKeyPEM)).
+		WithLogger(haozpay.NewSlogLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	for _, fn := range configure {
+		fn(cfg)
+	}
+	client, err := haozpay.NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, client
+}
+
+// countRequests 统计模拟网关收到的指定路径的请求数
+func countRequests(server *haozpaytest.Server, path string) int {
+	n := 0
+	for _, req := range server.Requests() {
+		if req.Path == path {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,184 @@
+// Package haozpaygin 提供皓臻支付回调通知的 Gin 适配
+//
+// 示例:
+//
+//	router := gin.Default()
+//	router.POST("/haozpay/notify", haozpaygin.NotifyHandler(config, func(c *gin.Context, n *haozpay.PaymentNotification) error {
+//	    return orderService.MarkPaid(c, n.MerchantOrderNo, n.PaidAmount)
+//	}))
+package haozpaygin
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+const (
+	// NotificationKey 验签通过的支付通知在 gin.Context 中的存储键
+	NotificationKey = "haozpay.notification"
+
+	// maxNotifyBodySize 回调报文的最大长度
+	maxNotifyBodySize = 1 << 20
+)
+
+// HandlerFunc 支付结果通知的业务处理函数
+// 返回 nil 表示处理成功，返回错误时平台会重新推送该通知
+type HandlerFunc func(c *gin.Context, notification *haozpay.PaymentNotification) error
+
+// NotifyHandler 创建处理支付结果回调的 gin.HandlerFunc
+//
+// 参数:
+//   - cfg: 客户端配置，需要设置平台公钥 PublicKey
+//   - handle: 业务处理函数，仅在验签通过后调用
+//
+// 返回:
+//   - gin.HandlerFunc: 可直接注册到路由的处理函数
+//
+// 应答规则与 haozpay.NotifyHandler 一致:
+//   - 验签通过且业务处理成功: HTTP 200，应答 SUCCESS
+//   - 重复收到已处理成功的通知（防重放或去重）: HTTP 200，应答 SUCCESS，不调用业务处理函数
+//   - 报文格式错误、验签失败或通知过期: HTTP 400，应答 FAIL
+//   - 业务处理返回错误或查询处理记录失败: HTTP 500，应答 FAIL，并撤销防重放记录以便平台重新推送
+func NotifyHandler(cfg *haozpay.Config, handle HandlerFunc) gin.HandlerFunc {
+	verifier := haozpay.NewNotificationVerifier(cfg)
+	return func(c *gin.Context) {
+		notification, body, ok := bindNotification(c, verifier, cfg.NotifyDedupStore)
+		if !ok {
+			return
+		}
+
+		if err := handle(c, notification); err != nil {
+			_ = verifier.Release(notificationContext(c), body)
+			_ = c.Error(err)
+			c.String(http.StatusInternalServerError, haozpay.NotifyAckFail)
+			return
+		}
+
+		markProcessed(c, cfg.NotifyDedupStore, notification)
+		c.String(http.StatusOK, haozpay.NotifyAckSuccess)
+	}
+}
+
+// VerifyNotification 创建验证支付结果回调的 Gin 中间件
+// 验签通过后将通知存入 gin.Context，后续处理函数通过 Notification 获取
+//
+// 参数:
+//   - cfg: 客户端配置，需要设置平台公钥 PublicKey
+//
+// 返回:
+//   - gin.HandlerFunc: Gin 中间件
+//
+// 应答规则:
+//   - 验签失败或通知过期时中止处理链并应答 FAIL
+//   - 重复收到已处理成功的通知（防重放或去重）时中止处理链并应答 SUCCESS
+//   - 后续处理函数未写入响应时自动应答：c.Errors 为空应答 SUCCESS，否则应答 FAIL
+//   - 后续处理函数记录了错误时撤销防重放记录，以便平台重新推送
+//
+// 示例:
+//
+//	router.POST("/haozpay/notify", haozpaygin.VerifyNotification(config), func(c *gin.Context) {
+//	    n := haozpaygin.Notification(c)
+//	    if err := orderService.MarkPaid(c, n.MerchantOrderNo, n.PaidAmount); err != nil {
+//	        _ = c.Error(err)
+//	    }
+//	})
+func VerifyNotification(cfg *haozpay.Config) gin.HandlerFunc {
+	verifier := haozpay.NewNotificationVerifier(cfg)
+	return func(c *gin.Context) {
+		notification, body, ok := bindNotification(c, verifier, cfg.NotifyDedupStore)
+		if !ok {
+			return
+		}
+
+		c.Next()
+
+		if len(c.Errors) > 0 {
+			_ = verifier.Release(notificationContext(c), body)
+		} else {
+			markProcessed(c, cfg.NotifyDedupStore, notification)
+		}
+		if c.Writer.Written() {
+			return
+		}
+		if len(c.Errors) > 0 {
+			c.String(http.StatusInternalServerError, haozpay.NotifyAckFail)
+			return
+		}
+		c.String(http.StatusOK, haozpay.NotifyAckSuccess)
+	}
+}
+
+// Notification 获取 gin.Context 中验签通过的支付通知
+// 未经过 VerifyNotification 或 NotifyHandler 处理时返回 nil
+func Notification(c *gin.Context) *haozpay.PaymentNotification {
+	value, ok := c.Get(NotificationKey)
+	if !ok {
+		return nil
+	}
+	notification, _ := value.(*haozpay.PaymentNotification)
+	return notification
+}
+
+// bindNotification 读取并验证回调报文，失败时中止处理链并应答 FAIL，重复的通知中止处理链并应答 SUCCESS
+// 返回验证通过的通知和原始报文，原始报文用于业务处理失败时撤销防重放记录
+func bindNotification(c *gin.Context, verifier *haozpay.NotificationVerifier, dedup haozpay.DedupStore) (*haozpay.PaymentNotification, []byte, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxNotifyBodySize))
+	if err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		c.String(http.StatusBadRequest, haozpay.NotifyAckFail)
+		return nil, nil, false
+	}
+
+	notification, err := verifier.ParsePaymentNotification(notificationContext(c), body)
+	if errors.Is(err, haozpay.ErrNotificationReplayed) {
+		// 通知已处理成功，平台可能未收到上次的应答，直接应答成功
+		c.Abort()
+		c.String(http.StatusOK, haozpay.NotifyAckSuccess)
+		return nil, nil, false
+	}
+	if err != nil {
+		_ = c.Error(err)
+		c.Abort()
+		c.String(http.StatusBadRequest, haozpay.NotifyAckFail)
+		return nil, nil, false
+	}
+
+	if dedup != nil {
+		processed, err := dedup.Processed(c, notification.NotificationID())
+		if err != nil {
+			_ = verifier.Release(notificationContext(c), body)
+			_ = c.Error(err)
+			c.Abort()
+			c.String(http.StatusInternalServerError, haozpay.NotifyAckFail)
+			return nil, nil, false
+		}
+		if processed {
+			c.Abort()
+			c.String(http.StatusOK, haozpay.NotifyAckSuccess)
+			return nil, nil, false
+		}
+	}
+
+	c.Set(NotificationKey, notification)
+	return notification, body, true
+}
+
+// notificationContext 返回携带回调请求 Content-Type 的 context，供验证器选择报文的解析方式
+func notificationContext(c *gin.Context) context.Context {
+	return haozpay.WithNotificationContentType(c, c.GetHeader("Content-Type"))
+}
+
+// markProcessed 记录通知已处理成功
+// 业务已处理成功，记录失败时平台重新推送的通知会再次调用业务处理函数，仍应答成功
+func markProcessed(c *gin.Context, dedup haozpay.DedupStore, notification *haozpay.PaymentNotification) {
+	if dedup != nil {
+		_ = dedup.MarkProcessed(c, notification.NotificationID())
+	}
+}
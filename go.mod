@@ -1,7 +1,15 @@
 module github.com/haoz-cloud/haozpay-sdk
 
-go 1.23
+go 1.23.0
 
-require github.com/go-resty/resty/v2 v2.16.5
+require (
+	github.com/emmansun/gmsm v0.30.1
+	github.com/go-resty/resty/v2 v2.16.5
+	golang.org/x/time v0.6.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
-require golang.org/x/net v0.33.0 // indirect
+require (
+	golang.org/x/crypto v0.37.0 // indirect
+	golang.org/x/net v0.33.0 // indirect
+)
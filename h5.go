@@ -0,0 +1,95 @@
+package haozpay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// H5 支付场景类型
+const (
+	// SceneTypeWap 手机网站
+	SceneTypeWap = "Wap"
+	// SceneTypeIOS iOS 应用内打开的网页
+	SceneTypeIOS = "iOS"
+	// SceneTypeAndroid Android 应用内打开的网页
+	SceneTypeAndroid = "Android"
+)
+
+// H5PayInfo H5 支付下单返回的支付信息
+type H5PayInfo struct {
+	// RedirectUrl 支付跳转地址，由前端在手机浏览器中打开
+	RedirectUrl string `json:"redirectUrl"`
+	// ExpireTime 跳转地址的过期时间，格式为 yyyy-MM-dd HH:mm:ss，可能为空
+	ExpireTime string `json:"expireTime"`
+}
+
+// ExpireAt 返回跳转地址的过期时间，按 ParseHaozTime 解析
+// ExpireTime 为空或格式无法识别时返回零值
+func (p *H5PayInfo) ExpireAt() time.Time {
+	t, err := ParseHaozTime(p.ExpireTime)
+	if err != nil {
+		return time.Time{}
+	}
+	return t.Time
+}
+
+// H5PayInfo 解析 H5 支付下单（PayTypeWechatH5、PayTypeAlipayWap）返回的 PayInfo
+// PayInfo 为 JSON 时解析跳转地址和过期时间；为普通 URL 时作为跳转地址，过期时间为空
+//
+// 返回:
+//   - *H5PayInfo: H5 支付信息
+//   - error: 订单不是 H5 支付方式或 PayInfo 无法解析时返回错误
+//
+// 示例:
+//
+//	h5, err := order.H5PayInfo()
+//	if err != nil {
+//	    return err
+//	}
+//	// 将 h5.RedirectUrl 返回给前端跳转
+func (r *PaymentOrderResponse) H5PayInfo() (*H5PayInfo, error) {
+	if !r.PayType.IsH5() {
+		return nil, fmt.Errorf("payType %s is not an H5 payment", r.PayType)
+	}
+
+	payInfo := strings.TrimSpace(r.PayInfo)
+	if strings.HasPrefix(payInfo, "{") {
+		var info H5PayInfo
+		if err := json.Unmarshal([]byte(payInfo), &info); err != nil {
+			return nil, fmt.Errorf("failed to parse H5 payInfo: %w", err)
+		}
+		if info.RedirectUrl == "" {
+			return nil, errors.New("H5 payInfo is missing redirectUrl")
+		}
+		return &info, nil
+	}
+
+	if payInfo == "" {
+		return nil, errors.New("H5 payInfo is empty")
+	}
+	return &H5PayInfo{RedirectUrl: payInfo}, nil
+}
+
+// validateH5Order 校验 H5 支付下单参数
+// H5 支付需要传入用户的真实 IP，渠道据此进行风控校验
+func validateH5Order(req *CreatePaymentOrderRequest) error {
+	if req.ClientIp == "" {
+		return &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("clientIp is required for payType %s", req.PayType),
+			StatusCode: 0,
+		}
+	}
+	if net.ParseIP(req.ClientIp) == nil {
+		return &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid clientIp: %s", req.ClientIp),
+			StatusCode: 0,
+		}
+	}
+	return nil
+}
@@ -1,61 +1,193 @@
 package haozpay
 
 import (
+	"bytes"
 	"crypto/rsa"
-	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"math/big"
+	"reflect"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 )
 
 // BuildSignString 构建签名字符串
 // 参数按字典序升序排列，如果参数值为空字符串则略过
+// 对象和数组类型的参数值为紧凑的 JSON 文本，对象的键按字典序排列，参见 signValueString
 //
 // params: 参数Map
 // 返回: 签名字符串，格式为: key1=value1&key2=value2
 func BuildSignString(params map[string]interface{}) string {
-	if params == nil || len(params) == 0 {
+	if len(params) == 0 {
 		return ""
 	}
 
+	b := acquireSignBuffer()
+	defer releaseSignBuffer(b)
+
 	// 提取key并排序（字典序）
-	keys := make([]string, 0, len(params))
 	for key := range params {
-		keys = append(keys, key)
+		b.keys = append(b.keys, key)
 	}
-	sort.Strings(keys)
+	sort.Strings(b.keys)
 
-	// 构建签名字符串
-	var sb strings.Builder
-	for _, key := range keys {
+	// 构建签名字符串，跳过sign字段和nil值
+	for _, key := range b.keys {
 		value := params[key]
-		// 跳过sign字段和nil值以及空字符串
 		if key == "sign" || value == nil {
 			continue
 		}
-		valueStr := fmt.Sprintf("%v", value)
-		if strings.TrimSpace(valueStr) == "" {
+		b.appendParam(key, signValueString(value))
+	}
+	return b.String()
+}
+
+// buildSignStringFromStrings 与 BuildSignString 规则相同，供验签时直接使用字符串参数，避免转换为 map[string]interface{}
+func buildSignStringFromStrings(params map[string]string) string {
+	if len(params) == 0 {
+		return ""
+	}
+
+	b := acquireSignBuffer()
+	defer releaseSignBuffer(b)
+
+	for key := range params {
+		b.keys = append(b.keys, key)
+	}
+	sort.Strings(b.keys)
+
+	for _, key := range b.keys {
+		if key == "sign" {
 			continue
 		}
+		b.appendParam(key, params[key])
+	}
+	return b.String()
+}
 
-		sb.WriteString(key)
-		sb.WriteString("=")
-		sb.WriteString(valueStr)
-		sb.WriteString("&")
+// signValueString 将参数值格式化为签名串中的文本
+// 字符串、数字和布尔值为其文本；对象（map、结构体）和数组（切片）为紧凑的 JSON 文本，
+// 对象的键按字典序排列、不转义 HTML 字符，与表单请求（RequestEncodingForm）中对应参数发送的值一致
+// 常见类型直接转换，避免 fmt 的反射和内存分配
+func signValueString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case json.Number:
+		return string(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case int:
+		return strconv.Itoa(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case map[string]interface{}, []interface{}:
+		return canonicalJSONOrDefault(v)
 	}
 
-	// 删除最后一个&
-	result := sb.String()
-	if len(result) > 0 {
-		result = result[:len(result)-1]
+	switch reflect.Indirect(reflect.ValueOf(value)).Kind() {
+	case reflect.Map, reflect.Struct, reflect.Slice, reflect.Array:
+		if _, ok := value.(fmt.Stringer); !ok {
+			return canonicalJSONOrDefault(value)
+		}
 	}
+	return fmt.Sprintf("%v", value)
+}
 
-	return result
+// canonicalJSONOrDefault 返回 canonicalJSON 的结果，无法序列化为 JSON 时使用 fmt.Sprintf("%v", value)
+func canonicalJSONOrDefault(value interface{}) string {
+	text, err := canonicalJSON(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return text
+}
+
+// canonicalJSON 将值序列化为紧凑的 JSON 文本，对象的键按字典序排列，不转义 HTML 字符
+// 数字保留原始文本（json.Number），结构体先按 JSON 字段名展开为对象再排序
+func canonicalJSON(value interface{}) (string, error) {
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+	default:
+		// 结构体的字段按声明顺序序列化，先解析为通用对象以便按键排序
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err != nil {
+			return "", err
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// maxPooledSignBufferSize 放回缓冲池的签名串缓冲区的最大容量，超过时丢弃，避免个别大请求长期占用内存
+const maxPooledSignBufferSize = 64 << 10
+
+// signBufferPool 构建签名串使用的缓冲区池，高并发下复用排序用的键切片和字节缓冲区
+var signBufferPool = sync.Pool{
+	New: func() interface{} {
+		return &signBuffer{
+			keys: make([]string, 0, 16),
+			buf:  make([]byte, 0, 512),
+		}
+	},
+}
+
+// signBuffer 构建签名串的可复用缓冲区
+type signBuffer struct {
+	keys []string
+	buf  []byte
+}
+
+// acquireSignBuffer 从缓冲池取得缓冲区
+func acquireSignBuffer() *signBuffer {
+	return signBufferPool.Get().(*signBuffer)
+}
+
+// releaseSignBuffer 清空缓冲区并放回缓冲池
+func releaseSignBuffer(b *signBuffer) {
+	if cap(b.buf) > maxPooledSignBufferSize {
+		return
+	}
+	clear(b.keys)
+	b.keys = b.keys[:0]
+	b.buf = b.buf[:0]
+	signBufferPool.Put(b)
+}
+
+// appendParam 追加 key=value&，值为空白字符串时略过
+func (b *signBuffer) appendParam(key, value string) {
+	if strings.TrimSpace(value) == "" {
+		return
+	}
+	b.buf = append(b.buf, key...)
+	b.buf = append(b.buf, '=')
+	b.buf = append(b.buf, value...)
+	b.buf = append(b.buf, '&')
+}
+
+// String 返回签名串，去掉末尾的 &
+func (b *signBuffer) String() string {
+	if len(b.buf) == 0 {
+		return ""
+	}
+	return string(b.buf[:len(b.buf)-1])
 }
 
 // GenerateSign 生成签名
@@ -71,30 +203,87 @@ func BuildSignString(params map[string]interface{}) string {
 // params: 参数Map
 // privateKeyStr: 私钥字符串（支持纯私钥字符串或完整PEM格式）
 func GenerateSign(params map[string]interface{}, privateKeyStr string) (string, error) {
-	// 1. 构建签名字符串
-	signString := BuildSignString(params)
+	// 解析私钥
+	signer, err := NewRSASigner(privateKeyStr)
+	if err != nil {
+		return "", fmt.Errorf("解析私钥失败: %w", err)
+	}
 
-	// 2. SHA256摘要，转为HEX字符串（小写）
-	hash := sha256.Sum256([]byte(signString))
-	sha256Hash := fmt.Sprintf("%x", hash)
+	return GenerateSignWithSigner(params, signer)
+}
 
-	// 3. 解析私钥
-	privateKey, err := parsePrivateKey(privateKeyStr)
+// GenerateSignWithSigner 使用签名器生成签名
+// 签名串和摘要的构建规则与 GenerateSign 一致，签名操作由 signer 完成
+//
+// params: 参数Map
+// signer: 签名器
+func GenerateSignWithSigner(params map[string]interface{}, signer Signer) (string, error) {
+	return GenerateSignWithSignType(params, signer, SignTypeRSA2)
+}
+
+// GenerateSignWithSignType 使用签名器按指定签名算法生成签名
+// 步骤：
+// 1. 构建签名字符串（字典序排序，空值跳过）
+// 2. 计算摘要并转为HEX字符串（RSA2、RSA-PSS使用SHA256，RSA使用SHA1，SM2使用SM3）
+// 3. 使用签名器对摘要进行签名
+// 4. Base64编码
+//
+// params: 参数Map
+// signer: 签名器，需与签名算法匹配（RSA2、RSA使用RSASigner/CryptoSigner，RSA-PSS使用RSAPSSSigner，SM2使用SM2Signer）
+// signType: 签名算法类型，需为内置或通过 RegisterSignAlgorithm 注册的算法
+func GenerateSignWithSignType(params map[string]interface{}, signer Signer, signType SignType) (string, error) {
+	algorithm, err := signAlgorithmFor(signType)
 	if err != nil {
-		return "", fmt.Errorf("解析私钥失败: %w", err)
+		return "", err
 	}
 
-	// 4. 使用私钥进行RSA"加密"（PKCS1v15填充 + 私钥指数运算）
+	// 1. 构建签名字符串
+	signString := BuildSignString(params)
+
+	// 2. 计算摘要，转为HEX字符串（小写）
+	digest := algorithm.Digest(signString)
+
+	// 3. 使用签名器进行签名（默认实现为PKCS1v15填充 + 私钥指数运算）
 	// 这对应Java Hutool的encryptBase64(data, KeyType.PrivateKey)
-	signBytes, err := privateKeyEncryptRaw(privateKey, []byte(sha256Hash))
+	signBytes, err := signer.Sign([]byte(digest))
 	if err != nil {
-		return "", fmt.Errorf("RSA私钥加密失败: %w", err)
+		return "", fmt.Errorf("%s签名失败: %w", signType, err)
 	}
 
-	// 5. Base64编码
+	// 4. Base64编码
 	return base64.StdEncoding.EncodeToString(signBytes), nil
 }
 
+// VerifySign 使用公钥验证签名
+// 根据公钥类型自动选择算法：RSA 公钥使用 SHA256 摘要（RSA2），SM2 公钥使用 SM3 摘要
+// 签名串的构建规则与 GenerateSign 一致
+//
+// params: 参数Map（sign字段会被忽略）
+// signature: Base64编码的签名字符串
+// publicKeyStr: 公钥字符串（支持纯公钥字符串或完整PEM格式）
+func VerifySign(params map[string]interface{}, signature string, publicKeyStr string) error {
+	return VerifySignWithSignType(params, signature, publicKeyStr, "")
+}
+
+// VerifySignWithSignType 使用公钥按指定签名算法验证签名
+// signType 为空时与 VerifySign 一致，根据公钥类型选择算法
+//
+// params: 参数Map（sign字段会被忽略）
+// signature: Base64编码的签名字符串
+// publicKeyStr: 公钥字符串（支持纯公钥字符串或完整PEM格式）
+// signType: 签名算法类型，例如 SignTypeRSA
+func VerifySignWithSignType(params map[string]interface{}, signature string, publicKeyStr string, signType SignType) error {
+	signParams := make(map[string]string, len(params))
+	for k, v := range params {
+		if v == nil {
+			continue
+		}
+		signParams[k] = signValueString(v)
+	}
+
+	return verifyHaozPaySignature(publicKeyStr, signType, signParams, signature)
+}
+
 // privateKeyEncryptRaw 使用私钥进行"加密"（实际是签名操作）
 // Java Hutool 使用 RSA/ECB/PKCS1Padding，私钥加密时使用 block type 1
 // 1. PKCS1v15填充（block type 1，使用 0xFF 填充）
@@ -0,0 +1,231 @@
+package haozpay
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/emmansun/gmsm/sm2"
+	"github.com/emmansun/gmsm/smx509"
+)
+
+func TestBuildSignStringNestedValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]interface{}
+		want   string
+	}{
+		{
+			name: "scalar values",
+			params: map[string]interface{}{
+				"b": "x", "a": json.Number("12.30"), "c": true, "d": int64(7), "empty": " ", "nil": nil, "sign": "s",
+			},
+			want: "a=12.30&b=x&c=true&d=7",
+		},
+		{
+			name: "object keys are sorted",
+			params: map[string]interface{}{
+				"extra": map[string]interface{}{"z": "1", "a": json.Number("2"), "m": []interface{}{"x", nil}},
+			},
+			want: `extra={"a":2,"m":["x",null],"z":"1"}`,
+		},
+		{
+			name: "html characters are not escaped",
+			params: map[string]interface{}{
+				"items": []interface{}{map[string]interface{}{"remark": "a<b&c"}},
+			},
+			want: `items=[{"remark":"a<b&c"}]`,
+		},
+		{
+			name: "struct fields are sorted by json name",
+			params: map[string]interface{}{
+				"item": struct {
+					Z string `json:"z"`
+					A int    `json:"a"`
+				}{Z: "last", A: 1},
+			},
+			want: `item={"a":1,"z":"last"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuildSignString(tt.params); got != tt.want {
+				t.Errorf("BuildSignString() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchTransferSignString(t *testing.T) {
+	req := &CreateBatchTransferRequest{
+		BatchNo:     "B1",
+		TotalAmount: Fen(100),
+		TotalCount:  1,
+		Items: []BatchTransferItem{{
+			ItemSeqId:      "i1",
+			TransferAmount: Fen(100),
+			PayeeType:      PayeeTypeAlipay,
+			PayeeAccount:   "x@example.com",
+			PayeeName:      "张三",
+		}},
+	}
+	bizBody, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `batchNo=B1` +
+		`&items=[{"itemSeqId":"i1","payeeAccount":"x@example.com","payeeName":"张三","payeeType":1,"transferAmount":1.00}]` +
+		`&merchantNo=M1&timestamp=1700000000000&totalAmount=1.00&totalCount=1`
+
+	params, err := requestSignParams(&HaozPayRequest{MerchantNo: "M1", Timestamp: 1700000000000, BizBody: string(bizBody)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := BuildSignString(params); got != want {
+		t.Errorf("json sign string = %s\nwant %s", got, want)
+	}
+
+	// 表单请求发送的参数值和签名串与 JSON 报文一致
+	formReq, err := newFormRequest("M1", 1700000000000, bizBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := BuildSignString(formSignParams(formReq)); got != want {
+		t.Errorf("form sign string = %s\nwant %s", got, want)
+	}
+	const wantItems = `[{"itemSeqId":"i1","payeeAccount":"x@example.com","payeeName":"张三","payeeType":1,"transferAmount":1.00}]`
+	if got := formReq.Fields["items"]; got != wantItems {
+		t.Errorf("form items = %s, want %s", got, wantItems)
+	}
+}
+
+func TestVerifySignNestedValues(t *testing.T) {
+	signer, publicKey := newTestRSAKey(t)
+	params := map[string]interface{}{
+		"batchNo": "B1",
+		"items":   []interface{}{map[string]interface{}{"z": "1", "a": json.Number("1.00")}},
+	}
+	sign, err := GenerateSignWithSigner(params, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifySign(params, sign, publicKey); err != nil {
+		t.Fatalf("VerifySign() error = %v", err)
+	}
+
+	params["items"] = []interface{}{map[string]interface{}{"z": "1", "a": json.Number("2.00")}}
+	if err := VerifySign(params, sign, publicKey); err == nil {
+		t.Fatal("VerifySign() succeeded after changing a nested value")
+	}
+}
+
+func TestGenerateSignRoundTrip(t *testing.T) {
+	privateKey, rsaPublicKey := testKeyPair(t)
+	rsaSigner, err := NewRSASigner(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pssSigner, err := NewRSAPSSSigner(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm2Key, err := sm2.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm2PublicDER, err := smx509.MarshalPKIXPublicKey(&sm2Key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm2PublicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: sm2PublicDER}))
+
+	tests := []struct {
+		signType  SignType
+		signer    Signer
+		publicKey string
+		// other 使用同一密钥的其他签名算法，按该算法验签应失败
+		other SignType
+	}{
+		{signType: SignTypeRSA2, signer: rsaSigner, publicKey: rsaPublicKey, other: SignTypeRSA},
+		{signType: SignTypeRSA, signer: rsaSigner, publicKey: rsaPublicKey, other: SignTypeRSA2},
+		{signType: SignTypeRSAPSS, signer: pssSigner, publicKey: rsaPublicKey, other: SignTypeRSA2},
+		{signType: SignTypeSM2, signer: NewSM2SignerFromKey(sm2Key), publicKey: sm2PublicKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.signType), func(t *testing.T) {
+			params := map[string]interface{}{
+				"merchantNo":  "M1",
+				"timestamp":   int64(1700000000000),
+				"orderAmount": json.Number("12.30"),
+				"orderTitle":  "测试商品",
+			}
+			sign, err := GenerateSignWithSignType(params, tt.signer, tt.signType)
+			if err != nil {
+				t.Fatalf("GenerateSignWithSignType() error = %v", err)
+			}
+			if err := VerifySignWithSignType(params, sign, tt.publicKey, tt.signType); err != nil {
+				t.Fatalf("VerifySignWithSignType() error = %v", err)
+			}
+
+			// sign 字段和空值不参与签名
+			params["sign"] = sign
+			params["remark"] = ""
+			if err := VerifySignWithSignType(params, sign, tt.publicKey, tt.signType); err != nil {
+				t.Errorf("VerifySignWithSignType() with sign and empty fields error = %v", err)
+			}
+
+			params["orderAmount"] = json.Number("12.31")
+			if err := VerifySignWithSignType(params, sign, tt.publicKey, tt.signType); !errors.Is(err, ErrSignatureInvalid) {
+				t.Errorf("VerifySignWithSignType() after tampering error = %v, want %v", err, ErrSignatureInvalid)
+			}
+			params["orderAmount"] = json.Number("12.30")
+
+			if tt.other != "" {
+				if err := VerifySignWithSignType(params, sign, tt.publicKey, tt.other); err == nil {
+					t.Errorf("signature verified with %s", tt.other)
+				}
+			}
+		})
+	}
+}
+
+// benchmarkSignParams 与一次下单请求签名时的参数相同
+func benchmarkSignParams() map[string]interface{} {
+	return map[string]interface{}{
+		"merchantNo":        "M1",
+		"timestamp":         int64(1700000000000),
+		"orderTitle":        "测试商品",
+		"orderAmount":       json.Number("12.30"),
+		"payType":           "WECHAT_NATIVE",
+		"useHaozPayCashier": false,
+		"notifyUrl":         "https://example.com/notify",
+		"merOrderNo":        "ORDER202401010001",
+		"attach":            "",
+	}
+}
+
+func BenchmarkBuildSignString(b *testing.B) {
+	params := benchmarkSignParams()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BuildSignString(params)
+	}
+}
+
+func BenchmarkGenerateSign(b *testing.B) {
+	signer, _ := newTestRSAKey(b)
+	params := benchmarkSignParams()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := GenerateSignWithSigner(params, signer); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,657 @@
+// Package haozpaytest 提供用于集成测试的进程内皓臻支付模拟网关
+//
+// 模拟网关基于 httptest.Server 实现：
+//   - 使用商户公钥验证请求签名
+//   - 按接口路径返回预设或脚本化的响应，并使用平台私钥对响应签名
+//   - 可以生成并推送带平台签名的回调通知
+//
+// 示例:
+//
+//	server, err := haozpaytest.NewServer(merchantPublicKeyPEM)
+//	if err != nil {
+//	    t.Fatal(err)
+//	}
+//	defer server.Close()
+//
+//	server.Respond("/pay-core/payment/order", &haozpay.PaymentOrderResponse{
+//	    SeqId:   "SEQ001",
+//	    PayInfo: "https://qr.example.com/xxx",
+//	})
+//
+//	client, _ := haozpay.NewClient(server.ClientConfig().WithPrivateKey(merchantPrivateKeyPEM))
+package haozpaytest
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/emmansun/gmsm/sm2"
+	"github.com/emmansun/gmsm/smx509"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+// DefaultMerchantNo 模拟网关默认的商户编号
+const DefaultMerchantNo = "HZTEST000000000001"
+
+// 模拟网关返回的错误码
+const (
+	// CodeInvalidRequest 请求报文格式错误
+	CodeInvalidRequest = 400
+	// CodeInvalidSignature 请求签名验证失败
+	CodeInvalidSignature = 401
+	// CodeNotFound 接口路径未注册响应
+	CodeNotFound = 404
+	// CodeInternalError 处理函数返回了非 *Error 类型的错误
+	CodeInternalError = 500
+)
+
+// Request 模拟网关收到的已验签请求
+type Request struct {
+	// Path 请求路径，例如 /pay-core/payment/order
+	Path string
+	// Header 请求头
+	Header http.Header
+	// MerchantNo 商户编号
+	MerchantNo string
+	// Timestamp 请求时间戳(毫秒)
+	Timestamp int64
+	// BizBody 业务参数 JSON
+	// 表单编码的请求（haozpay.RequestEncodingForm）、GET 请求和文件上传请求由表单字段或查询参数转换而来，字段值均为字符串
+	BizBody string
+	// Files 文件上传请求中的文件内容，按表单字段名索引
+	Files map[string][]byte
+}
+
+// Decode 将业务参数解析到 v
+func (r *Request) Decode(v interface{}) error {
+	return json.Unmarshal([]byte(r.BizBody), v)
+}
+
+// Error 模拟网关返回的业务错误
+// 作为处理函数的返回值或 Respond 的响应项使用
+type Error struct {
+	// StatusCode HTTP 状态码，为 0 时使用 200
+	StatusCode int
+	// Code 业务错误码
+	Code int
+	// Message 错误信息
+	Message string
+	// SubCode 明细错误码，为空时响应不包含该字段
+	SubCode string
+	// SubMessage 明细错误信息
+	SubMessage string
+	// FieldErrors 字段校验错误
+	FieldErrors []haozpay.FieldError
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("haozpaytest: code=%d, message=%s", e.Code, e.Message)
+}
+
+// HandlerFunc 接口处理函数
+// 返回的 data 作为响应的 data 字段；返回 *Error 时响应对应的业务错误
+type HandlerFunc func(req *Request) (data interface{}, err error)
+
+// Server 进程内模拟网关
+// 通过 NewServer 函数创建实例，使用完毕后调用 Close 关闭
+type Server struct {
+	// URL 模拟网关地址
+	URL string
+	// MerchantNo 商户编号，用于 ClientConfig 和回调通知
+	MerchantNo string
+	// PlatformPublicKey 平台公钥(PEM格式)，客户端使用该公钥验证响应和回调签名
+	PlatformPublicKey string
+
+	server            *httptest.Server
+	merchantPublicKey string
+	platformSigner    haozpay.Signer
+	platformPSSSigner haozpay.Signer
+	signType          haozpay.SignType
+	requestSeq        atomic.Int64
+
+	mu       sync.Mutex
+	handlers map[string]HandlerFunc
+	files    map[string][]byte
+	requests []*Request
+}
+
+// NewServer 创建并启动模拟网关
+//
+// 参数:
+//   - merchantPublicKey: 商户公钥(PEM格式或纯Base64格式)，用于验证请求签名
+//     RSA 公钥对应 RSA2 签名，SM2 公钥对应 SM2 签名，平台密钥对使用相同算法生成
+//     请求报文声明了 signType 时按其验签，响应也使用相同算法签名
+//
+// 返回:
+//   - *Server: 已启动的模拟网关
+//   - error: 公钥解析或平台密钥生成失败时返回错误
+func NewServer(merchantPublicKey string) (*Server, error) {
+	pub, err := parsePublicKey(merchantPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse merchant public key: %w", err)
+	}
+
+	s := &Server{
+		MerchantNo:        DefaultMerchantNo,
+		merchantPublicKey: merchantPublicKey,
+		handlers:          make(map[string]HandlerFunc),
+		files:             make(map[string][]byte),
+	}
+
+	var platformPublicKey interface{}
+	switch pub.(type) {
+	case *rsa.PublicKey:
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate platform key: %w", err)
+		}
+		s.platformSigner = haozpay.NewRSASignerFromKey(key)
+		s.platformPSSSigner = haozpay.NewRSAPSSSignerFromKey(key)
+		s.signType = haozpay.SignTypeRSA2
+		platformPublicKey = &key.PublicKey
+	case *ecdsa.PublicKey:
+		key, err := sm2.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate platform key: %w", err)
+		}
+		s.platformSigner = haozpay.NewSM2SignerFromKey(key)
+		s.signType = haozpay.SignTypeSM2
+		platformPublicKey = &key.PublicKey
+	default:
+		return nil, fmt.Errorf("unsupported merchant public key type: %T", pub)
+	}
+
+	der, err := smx509.MarshalPKIXPublicKey(platformPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal platform public key: %w", err)
+	}
+	s.PlatformPublicKey = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+
+	return s, nil
+}
+
+// Close 关闭模拟网关
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// ClientConfig 返回指向模拟网关的客户端配置
+// 已设置 BaseURL、MerchantNo、PublicKey 和 SignType，调用方只需补充商户私钥
+// 配置不重试，避免脚本化响应被重试请求消耗
+func (s *Server) ClientConfig() *haozpay.Config {
+	return haozpay.DefaultConfig().
+		WithBaseURL(s.URL).
+		WithMerchantNo(s.MerchantNo).
+		WithPublicKey(s.PlatformPublicKey).
+		WithSignType(s.signType).
+		WithRetry(0, 0, 0)
+}
+
+// Handle 注册接口处理函数，覆盖该路径之前注册的处理函数或预设响应
+func (s *Server) Handle(path string, handler HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[path] = handler
+}
+
+// Respond 注册接口的预设响应
+// 传入多个响应时按请求顺序依次返回，超出后重复返回最后一个
+// 响应项为 *Error 时返回对应的业务错误，否则作为响应的 data 字段
+//
+// 示例:
+//
+//	// 第一次查询返回支付中，之后返回已支付
+//	server.Respond("/pay-core/payment/order/query",
+//	    &haozpay.QueryOrderResponse{OrderStatus: haozpay.OrderStatusPaying},
+//	    &haozpay.QueryOrderResponse{OrderStatus: haozpay.OrderStatusPaid},
+//	)
+func (s *Server) Respond(path string, responses ...interface{}) {
+	var (
+		mu    sync.Mutex
+		index int
+	)
+	s.Handle(path, func(req *Request) (interface{}, error) {
+		if len(responses) == 0 {
+			return nil, nil
+		}
+		mu.Lock()
+		response := responses[index]
+		if index < len(responses)-1 {
+			index++
+		}
+		mu.Unlock()
+
+		if e, ok := response.(*Error); ok {
+			return nil, e
+		}
+		return response, nil
+	})
+}
+
+// HandleFile 注册 GET 下载的文件内容，用于模拟对账单等文件下载
+// 文件内容原样返回，不做签名
+func (s *Server) HandleFile(path string, content []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.files[path] = content
+}
+
+// Requests 返回模拟网关收到的所有已验签请求
+func (s *Server) Requests() []*Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Request(nil), s.requests...)
+}
+
+// SignNotification 生成带平台签名的回调通知报文
+//
+// 参数:
+//   - bizBody: 通知业务内容，例如 *haozpay.PaymentNotification
+//
+// 返回:
+//   - []byte: 回调报文，可直接传给 haozpay.ParsePaymentNotification 或回调处理器
+//   - error: 序列化或签名失败时返回错误
+func (s *Server) SignNotification(bizBody interface{}) ([]byte, error) {
+	bizBodyBytes, err := json.Marshal(bizBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	envelope := &haozpay.HaozPayRequest{
+		MerchantNo: s.MerchantNo,
+		Timestamp:  time.Now().UnixMilli(),
+		BizBody:    string(bizBodyBytes),
+		SignType:   s.signType,
+	}
+
+	params, err := decodeObject(bizBodyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification: %w", err)
+	}
+	params["merchantNo"] = envelope.MerchantNo
+	params["timestamp"] = envelope.Timestamp
+
+	envelope.Sign, err = haozpay.GenerateSignWithSignType(params, s.platformSigner, s.signType)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(envelope)
+}
+
+// SendNotification 向商户回调地址推送带平台签名的回调通知
+//
+// 参数:
+//   - ctx: 上下文
+//   - notifyURL: 商户回调地址
+//   - bizBody: 通知业务内容
+//
+// 返回:
+//   - error: 推送失败或商户未应答 SUCCESS 时返回错误
+func (s *Server) SendNotification(ctx context.Context, notifyURL string, bizBody interface{}) error {
+	body, err := s.SignNotification(bizBody)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, notifyURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	ack, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || strings.TrimSpace(string(ack)) != haozpay.NotifyAckSuccess {
+		return fmt.Errorf("notification not acknowledged: status=%d, body=%s", resp.StatusCode, ack)
+	}
+	return nil
+}
+
+// serveHTTP 验证请求签名并调用接口处理函数
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	// 不带签名的 GET 请求为文件下载，带签名的 GET 请求为查询接口
+	if r.Method == http.MethodGet && !r.URL.Query().Has("sign") {
+		s.serveFile(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		s.writeError(w, "", &Error{StatusCode: http.StatusBadRequest, Code: CodeInvalidRequest, Message: "failed to read request body"})
+		return
+	}
+
+	var envelope haozpay.HaozPayRequest
+	var files map[string][]byte
+	if r.Method == http.MethodGet {
+		err = decodeFormRequest([]byte(r.URL.RawQuery), &envelope)
+	} else if mediaType, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "application/x-www-form-urlencoded" {
+		err = decodeFormRequest(body, &envelope)
+	} else if mediaType == "multipart/form-data" {
+		files, err = decodeMultipartRequest(body, params["boundary"], &envelope)
+	} else {
+		err = json.Unmarshal(body, &envelope)
+	}
+	if err != nil {
+		s.writeError(w, "", &Error{StatusCode: http.StatusBadRequest, Code: CodeInvalidRequest, Message: "invalid request body"})
+		return
+	}
+
+	if err := s.verifyRequest(&envelope); err != nil {
+		s.writeError(w, envelope.SignType, &Error{StatusCode: http.StatusUnauthorized, Code: CodeInvalidSignature, Message: err.Error()})
+		return
+	}
+
+	req := &Request{
+		Path:       r.URL.Path,
+		Header:     r.Header.Clone(),
+		MerchantNo: envelope.MerchantNo,
+		Timestamp:  envelope.Timestamp,
+		BizBody:    envelope.BizBody,
+		Files:      files,
+	}
+
+	s.mu.Lock()
+	s.requests = append(s.requests, req)
+	handler, ok := s.handlers[r.URL.Path]
+	s.mu.Unlock()
+
+	if !ok {
+		s.writeError(w, envelope.SignType, &Error{StatusCode: http.StatusNotFound, Code: CodeNotFound, Message: "no response registered for " + r.URL.Path})
+		return
+	}
+
+	data, err := handler(req)
+	if err != nil {
+		var gatewayErr *Error
+		if !errors.As(err, &gatewayErr) {
+			gatewayErr = &Error{StatusCode: http.StatusInternalServerError, Code: CodeInternalError, Message: err.Error()}
+		}
+		s.writeError(w, envelope.SignType, gatewayErr)
+		return
+	}
+
+	var raw json.RawMessage
+	if data != nil {
+		if raw, err = json.Marshal(data); err != nil {
+			s.writeError(w, envelope.SignType, &Error{StatusCode: http.StatusInternalServerError, Code: CodeInternalError, Message: err.Error()})
+			return
+		}
+	}
+	s.writeResponse(w, http.StatusOK, envelope.SignType, &haozpay.Response{
+		Code:    0,
+		Message: "success",
+		Data:    raw,
+	})
+}
+
+// serveFile 返回通过 HandleFile 注册的文件内容
+func (s *Server) serveFile(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	content, ok := s.files[r.URL.Path]
+	s.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	_, _ = w.Write(content)
+}
+
+// verifyRequest 使用商户公钥验证请求签名
+// 验签参数与 SDK 请求签名一致：展开 bizBody 中的字段，并加入 merchantNo 和 timestamp
+// 按请求报文的 signType 选择签名算法，未携带时根据商户公钥类型选择
+func (s *Server) verifyRequest(envelope *haozpay.HaozPayRequest) error {
+	if envelope.Sign == "" {
+		return errors.New("sign is missing")
+	}
+
+	params := make(map[string]interface{})
+	if envelope.BizBody != "" {
+		fields, err := decodeObject([]byte(envelope.BizBody))
+		if err != nil {
+			return fmt.Errorf("invalid bizBody: %w", err)
+		}
+		params = fields
+	}
+	params["merchantNo"] = envelope.MerchantNo
+	params["timestamp"] = envelope.Timestamp
+
+	if err := haozpay.VerifySignWithSignType(params, envelope.Sign, s.merchantPublicKey, envelope.SignType); err != nil {
+		return fmt.Errorf("invalid request signature: %w", err)
+	}
+	return nil
+}
+
+// writeError 输出业务错误响应
+func (s *Server) writeError(w http.ResponseWriter, signType haozpay.SignType, e *Error) {
+	statusCode := e.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	s.writeResponse(w, statusCode, signType, &haozpay.Response{
+		Code:        e.Code,
+		Message:     e.Message,
+		SubCode:     e.SubCode,
+		SubMessage:  e.SubMessage,
+		FieldErrors: e.FieldErrors,
+	})
+}
+
+// writeResponse 使用平台私钥对响应签名并输出
+// 签名参数与 SDK 响应验签一致：除 sign 和 data 外的顶层字段，data 为对象时展开其字段
+// signType 为请求报文声明的签名算法，为空时使用与平台密钥对应的默认算法
+func (s *Server) writeResponse(w http.ResponseWriter, statusCode int, signType haozpay.SignType, resp *haozpay.Response) {
+	resp.RequestID = fmt.Sprintf("mock-%d", s.requestSeq.Add(1))
+	resp.Timestamp = time.Now().UnixMilli()
+
+	sign, err := s.signResponse(resp, signType)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resp.Sign = sign
+
+	body, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(body)
+}
+
+// signResponse 计算响应签名
+func (s *Server) signResponse(resp *haozpay.Response, signType haozpay.SignType) (string, error) {
+	unsigned, err := json.Marshal(resp)
+	if err != nil {
+		return "", err
+	}
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(unsigned, &envelope); err != nil {
+		return "", err
+	}
+
+	params := make(map[string]interface{})
+	for k, v := range envelope {
+		if k == "sign" || k == "data" {
+			continue
+		}
+		value, err := decodeValue(v)
+		if err != nil {
+			return "", err
+		}
+		params[k] = value
+	}
+	if data, ok := envelope["data"]; ok && len(data) > 0 && string(data) != "null" {
+		if data[0] == '{' {
+			fields, err := decodeObject(data)
+			if err != nil {
+				return "", err
+			}
+			for k, v := range fields {
+				params[k] = v
+			}
+		} else {
+			// 与客户端验签一致：非对象的 data 作为名为 data 的参数，按签名串的规则转换
+			value, err := decodeValue(data)
+			if err != nil {
+				return "", err
+			}
+			params["data"] = value
+		}
+	}
+
+	if signType == "" {
+		signType = s.signType
+	}
+	signer := s.platformSigner
+	if signType == haozpay.SignTypeRSAPSS && s.platformPSSSigner != nil {
+		signer = s.platformPSSSigner
+	}
+	return haozpay.GenerateSignWithSignType(params, signer, signType)
+}
+
+// decodeFormRequest 解析表单编码的请求体或 GET 请求的查询串
+// merchantNo、timestamp、signType 和 sign 以外的字段作为业务参数，转换为 JSON 对象写入 BizBody
+func decodeFormRequest(body []byte, envelope *haozpay.HaozPayRequest) error {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return err
+	}
+
+	fields := make(map[string]string, len(values))
+	for key := range values {
+		value := values.Get(key)
+		switch key {
+		case "merchantNo":
+			envelope.MerchantNo = value
+		case "timestamp":
+			if envelope.Timestamp, err = strconv.ParseInt(value, 10, 64); err != nil {
+				return fmt.Errorf("invalid timestamp: %w", err)
+			}
+		case "signType":
+			envelope.SignType = haozpay.SignType(value)
+		case "sign":
+			envelope.Sign = value
+		default:
+			fields[key] = value
+		}
+	}
+
+	bizBody, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	envelope.BizBody = string(bizBody)
+	return nil
+}
+
+// decodeMultipartRequest 解析 multipart/form-data 格式的文件上传请求
+// 普通字段按 decodeFormRequest 的规则处理，文件字段返回其内容
+func decodeMultipartRequest(body []byte, boundary string, envelope *haozpay.HaozPayRequest) (map[string][]byte, error) {
+	if boundary == "" {
+		return nil, errors.New("multipart boundary is missing")
+	}
+
+	values := make(url.Values)
+	files := make(map[string][]byte)
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		if part.FileName() != "" {
+			files[part.FormName()] = content
+		} else {
+			values.Set(part.FormName(), string(content))
+		}
+	}
+
+	if err := decodeFormRequest([]byte(values.Encode()), envelope); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// decodeObject 解析 JSON 对象，使用 json.Number 保留数字的原始文本
+func decodeObject(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		fields = make(map[string]interface{})
+	}
+	return fields, nil
+}
+
+// decodeValue 解析 JSON 值，使用 json.Number 保留数字的原始文本
+func decodeValue(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// parsePublicKey 解析 PEM 格式或纯 Base64 格式的公钥
+func parsePublicKey(publicKey string) (interface{}, error) {
+	var der []byte
+	if block, _ := pem.Decode([]byte(publicKey)); block != nil {
+		der = block.Bytes
+	} else {
+		decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(publicKey), ""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode public key: %w", err)
+		}
+		der = decoded
+	}
+	return smx509.ParsePKIXPublicKey(der)
+}
@@ -0,0 +1,119 @@
+package haozpay
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// haozTimeLayout 网关时间的标准格式
+const haozTimeLayout = "2006-01-02 15:04:05"
+
+// haozTimeLayouts 网关返回的不带时区的时间格式，按北京时间解析
+var haozTimeLayouts = []string{
+	haozTimeLayout,
+	"2006-01-02 15:04:05.000",
+	"2006-01-02T15:04:05",
+	"20060102150405",
+	"2006-01-02",
+	"20060102",
+}
+
+// gatewayLocation 网关时间所在时区（北京时间）
+var gatewayLocation = time.FixedZone("CST", 8*60*60)
+
+// HaozTime 网关返回的时间
+//
+// 网关的时间字段通常为不带时区的 yyyy-MM-dd HH:mm:ss（北京时间），部分接口返回 yyyyMMddHHmmss、
+// 带时区的 RFC 3339 或毫秒时间戳，反序列化时均可识别；空字符串和 null 解析为零值
+//
+// 序列化为 yyyy-MM-dd HH:mm:ss 格式的北京时间，零值序列化为空字符串。
+// 嵌入的 time.Time 提供 Before、Sub、In 等方法
+//
+// 示例:
+//
+//	order, err := client.Payment.QueryOrder(ctx, req)
+//	if err == nil && !order.FinishTime.IsZero() {
+//	    fmt.Println(time.Since(order.FinishTime.Time))
+//	}
+type HaozTime struct {
+	time.Time
+}
+
+// ParseHaozTime 解析网关格式的时间字符串，不带时区的时间按北京时间解析
+//
+// 参数:
+//   - s: 时间字符串，例如 "2024-01-01 12:00:00"、"20240101120000"、"2024-01-01T12:00:00+08:00"、"1704081600000"
+//
+// 返回:
+//   - HaozTime: 时间，s 为空时返回零值
+//   - error: 无法识别的格式返回错误
+func ParseHaozTime(s string) (HaozTime, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return HaozTime{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return HaozTime{Time: t}, nil
+	}
+	for _, layout := range haozTimeLayouts {
+		if len(s) != len(layout) {
+			continue
+		}
+		if t, err := time.ParseInLocation(layout, s, gatewayLocation); err == nil {
+			return HaozTime{Time: t}, nil
+		}
+	}
+	// 13 位数字为毫秒时间戳，14 位数字已按 yyyyMMddHHmmss 尝试
+	if len(s) == 13 && isDigits(s) {
+		ms, _ := strconv.ParseInt(s, 10, 64)
+		return HaozTime{Time: time.UnixMilli(ms)}, nil
+	}
+	return HaozTime{}, fmt.Errorf("invalid time %q", s)
+}
+
+// String 返回 yyyy-MM-dd HH:mm:ss 格式的北京时间，零值返回空字符串
+func (t HaozTime) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.In(gatewayLocation).Format(haozTimeLayout)
+}
+
+// MarshalJSON 实现 json.Marshaler 接口，序列化为 yyyy-MM-dd HH:mm:ss 格式的字符串
+func (t HaozTime) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(t.String())), nil
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口，支持 ParseHaozTime 识别的字符串和毫秒时间戳数字
+func (t *HaozTime) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] != '"' {
+		ms, err := strconv.ParseInt(string(data), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid time %s", data)
+		}
+		if ms == 0 {
+			*t = HaozTime{}
+			return nil
+		}
+		*t = HaozTime{Time: time.UnixMilli(ms)}
+		return nil
+	}
+
+	s, err := strconv.Unquote(string(data))
+	if err != nil {
+		return fmt.Errorf("invalid time %s", data)
+	}
+	parsed, err := ParseHaozTime(s)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
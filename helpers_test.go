@@ -0,0 +1,46 @@
+package haozpay
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"sync"
+	"testing"
+)
+
+var (
+	testKeyOnce sync.Once
+	testKey     *rsa.PrivateKey
+	testKeyErr  error
+)
+
+// testKeyPair 返回测试使用的 RSA 密钥对（PEM 格式），同一测试进程内复用
+func testKeyPair(t testing.TB) (privateKeyPEM, publicKeyPEM string) {
+	t.Helper()
+	testKeyOnce.Do(func() {
+		testKey, testKeyErr = rsa.GenerateKey(rand.Reader, 2048)
+	})
+	if testKeyErr != nil {
+		t.Fatal(testKeyErr)
+	}
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&testKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKeyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(testKey)}))
+	publicKeyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	return privateKeyPEM, publicKeyPEM
+}
+
+// newTestRSAKey 返回测试使用的签名器和对应的公钥（PEM 格式）
+func newTestRSAKey(t testing.TB) (*RSASigner, string) {
+	t.Helper()
+	privateKeyPEM, publicKeyPEM := testKeyPair(t)
+	signer, err := NewRSASigner(privateKeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, publicKeyPEM
+}
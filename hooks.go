@@ -0,0 +1,174 @@
+package haozpay
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// BeforeCallHook 接口调用前的钩子
+//
+// 参数:
+//   - ctx: 调用使用的 context，可通过 RequestIDFromContext 获取客户端请求ID
+//   - op: 接口路径，例如 /pay-core/payment/order
+//   - req: 业务请求参数（例如 *CreatePaymentOrderRequest），钩子对其的修改会随本次请求发送并参与签名
+type BeforeCallHook func(ctx context.Context, op string, req interface{})
+
+// AfterCallHook 接口调用完成后的钩子
+//
+// 参数:
+//   - ctx: 调用使用的 context
+//   - op: 接口路径
+//   - resp: 业务响应数据（例如 *PaymentOrderResponse），接口无响应数据或调用失败时可能为 nil
+//   - err: 调用失败时的错误
+type AfterCallHook func(ctx context.Context, op string, resp interface{}, err error)
+
+// RequestHeaderHook 设置请求头的钩子，用于向平台传递链路追踪信息
+//
+// 参数:
+//   - ctx: 调用使用的 context，与 BeforeCallHook、AfterCallHook 收到的 context 相同
+//   - op: 接口路径
+//   - header: 本次调用的请求头，钩子可直接修改，同一次调用的重试使用相同的请求头
+type RequestHeaderHook func(ctx context.Context, op string, header http.Header)
+
+// callHooks 客户端注册的接口调用钩子
+// 客户端的所有业务服务共享同一组钩子
+type callHooks struct {
+	mu     sync.RWMutex
+	before []BeforeCallHook
+	after  []AfterCallHook
+	header []RequestHeaderHook
+}
+
+// clone 返回已注册钩子的副本，供 Client.Clone 派生的客户端使用
+func (h *callHooks) clone() *callHooks {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return &callHooks{
+		before: append([]BeforeCallHook(nil), h.before...),
+		after:  append([]AfterCallHook(nil), h.after...),
+		header: append([]RequestHeaderHook(nil), h.header...),
+	}
+}
+
+// beforeCall 按注册顺序执行调用前钩子
+func (h *callHooks) beforeCall(ctx context.Context, op string, req interface{}) {
+	if h == nil {
+		return
+	}
+	h.mu.RLock()
+	hooks := h.before
+	h.mu.RUnlock()
+
+	for _, hook := range hooks {
+		hook(ctx, op, req)
+	}
+}
+
+// afterCall 按注册顺序执行调用后钩子
+// data 为传给 apiExecutor.post 的响应解析目标，指向指针时传给钩子解引用后的值
+func (h *callHooks) afterCall(ctx context.Context, op string, data interface{}, err error) {
+	if h == nil {
+		return
+	}
+	h.mu.RLock()
+	hooks := h.after
+	h.mu.RUnlock()
+	if len(hooks) == 0 {
+		return
+	}
+
+	var resp interface{}
+	if data != nil {
+		resp = data
+		if v := reflect.ValueOf(data); v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Ptr {
+			resp = v.Elem().Interface()
+		}
+	}
+
+	for _, hook := range hooks {
+		hook(ctx, op, resp, err)
+	}
+}
+
+// requestHeader 按注册顺序执行设置请求头的钩子
+func (h *callHooks) requestHeader(ctx context.Context, op string, header http.Header) {
+	if h == nil {
+		return
+	}
+	h.mu.RLock()
+	hooks := h.header
+	h.mu.RUnlock()
+
+	for _, hook := range hooks {
+		hook(ctx, op, header)
+	}
+}
+
+// OnBeforeCall 注册接口调用前的钩子，用于审计、指标统计或修改请求参数
+// 钩子在请求参数序列化和签名之前执行，对请求参数的修改同样会被签名；
+// 多个钩子按注册顺序执行，同一次调用的重试不会重复执行钩子
+//
+// 参数:
+//   - hook: 调用前钩子，为 nil 时忽略
+//
+// 示例:
+//
+//	client.OnBeforeCall(func(ctx context.Context, op string, req interface{}) {
+//	    if order, ok := req.(*haozpay.CreatePaymentOrderRequest); ok && order.NotifyUrl == "" {
+//	        order.NotifyUrl = defaultNotifyURL
+//	    }
+//	})
+func (c *Client) OnBeforeCall(hook BeforeCallHook) {
+	if hook == nil {
+		return
+	}
+	c.hooks.mu.Lock()
+	defer c.hooks.mu.Unlock()
+	c.hooks.before = append(c.hooks.before, hook)
+}
+
+// OnAfterCall 注册接口调用完成后的钩子，用于审计、指标统计等
+// 多个钩子按注册顺序执行
+//
+// 参数:
+//   - hook: 调用后钩子，为 nil 时忽略
+//
+// 示例:
+//
+//	client.OnAfterCall(func(ctx context.Context, op string, resp interface{}, err error) {
+//	    metrics.Inc(op, err == nil)
+//	    log.Printf("haozpay %s requestId=%s err=%v", op, haozpay.RequestIDFromContext(ctx), err)
+//	})
+func (c *Client) OnAfterCall(hook AfterCallHook) {
+	if hook == nil {
+		return
+	}
+	c.hooks.mu.Lock()
+	defer c.hooks.mu.Unlock()
+	c.hooks.after = append(c.hooks.after, hook)
+}
+
+// OnRequestHeader 注册设置请求头的钩子，用于 APM 等链路追踪库向平台传递当前 span 的链路信息
+// 钩子在 OnBeforeCall 注册的钩子之后、发送请求之前执行，此时请求头中已包含
+// WithTraceContext 指定的 traceparent、tracestate；WithHeader 指定的请求头优先于钩子设置的值
+// 多个钩子按注册顺序执行
+//
+// 参数:
+//   - hook: 设置请求头的钩子，为 nil 时忽略
+//
+// 示例:
+//
+//	// OpenTelemetry
+//	client.OnRequestHeader(func(ctx context.Context, op string, header http.Header) {
+//	    otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+//	})
+func (c *Client) OnRequestHeader(hook RequestHeaderHook) {
+	if hook == nil {
+		return
+	}
+	c.hooks.mu.Lock()
+	defer c.hooks.mu.Unlock()
+	c.hooks.header = append(c.hooks.header, hook)
+}
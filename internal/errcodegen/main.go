@@ -0,0 +1,125 @@
+// errcodegen 根据 codes.csv 生成错误码表 errcode_table.go
+//
+// 在仓库根目录执行 go generate 重新生成：
+//
+//	go generate ./...
+//
+// codes.csv 每行为一个错误码：code,name,category,zh,en
+//   - code: 错误码
+//   - name: 常量名后缀，生成 Code<name> 常量
+//   - category: 处理建议，retry、fix-request 或 contact-support
+//   - zh、en: 中文和英文描述
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// categories codes.csv 中的处理建议对应的 ErrorCategory 常量
+var categories = map[string]string{
+	"retry":           "ErrorCategoryRetry",
+	"fix-request":     "ErrorCategoryFixRequest",
+	"contact-support": "ErrorCategoryContactSupport",
+}
+
+type entry struct {
+	code     int
+	name     string
+	category string
+	zh       string
+	en       string
+}
+
+func main() {
+	input := flag.String("input", "internal/errcodegen/codes.csv", "error code table")
+	output := flag.String("output", "errcode_table.go", "generated Go file")
+	flag.Parse()
+
+	entries, err := readEntries(*input)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(entries)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readEntries 读取错误码表，校验错误码和常量名不重复、处理建议合法
+func readEntries(path string) ([]entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = 5
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+
+	codes := make(map[int]bool)
+	names := make(map[string]bool)
+	var entries []entry
+	for i, record := range records[1:] {
+		line := i + 2
+		code, err := strconv.Atoi(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid code %q", path, line, record[0])
+		}
+		if codes[code] {
+			return nil, fmt.Errorf("%s:%d: duplicate code %d", path, line, code)
+		}
+		if names[record[1]] {
+			return nil, fmt.Errorf("%s:%d: duplicate name %s", path, line, record[1])
+		}
+		category, ok := categories[record[2]]
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: invalid category %q", path, line, record[2])
+		}
+		codes[code] = true
+		names[record[1]] = true
+		entries = append(entries, entry{code: code, name: record[1], category: category, zh: record[3], en: record[4]})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].code < entries[j].code })
+	return entries, nil
+}
+
+// generate 生成错误码常量和错误码表
+func generate(entries []entry) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by internal/errcodegen from internal/errcodegen/codes.csv; DO NOT EDIT.\n\n")
+	buf.WriteString("package haozpay\n\n")
+
+	buf.WriteString("// 错误码常量，与 SDKError.Code 比较\nconst (\n")
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "\t// Code%s %s\n\tCode%s = %d\n", e.name, e.zh, e.name, e.code)
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("// codeCatalog 错误码表\nvar codeCatalog = map[int]CodeInfo{\n")
+	for _, e := range entries {
+		fmt.Fprintf(&buf, "\tCode%s: {Code: Code%s, Name: %q, Category: %s, DescriptionZH: %q, DescriptionEN: %q},\n",
+			e.name, e.name, e.name, e.category, e.zh, e.en)
+	}
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}
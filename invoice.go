@@ -0,0 +1,101 @@
+package haozpay
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type InvoiceService struct {
+	executor *apiExecutor
+}
+
+func NewInvoiceService(client *resty.Client, config *Config) *InvoiceService {
+	return &InvoiceService{
+		executor: newAPIExecutor(client, config),
+	}
+}
+
+func (s *InvoiceService) ApplyInvoice(ctx context.Context, req *ApplyInvoiceRequest, opts ...RequestOption) (*InvoiceResponse, error) {
+	if !req.InvoiceType.IsValid() {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid invoiceType: %d", req.InvoiceType),
+			StatusCode: 0,
+		}
+	}
+	if !req.TitleType.IsValid() {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid titleType: %d", req.TitleType),
+			StatusCode: 0,
+		}
+	}
+	if req.OutInvoiceNo == "" || req.OrderNo == "" || req.Title == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "outInvoiceNo, orderNo and title are required",
+			StatusCode: 0,
+		}
+	}
+	if req.TitleType == InvoiceTitleTypeEnterprise && req.TaxNo == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "taxNo is required for enterprise title",
+			StatusCode: 0,
+		}
+	}
+	if req.InvoiceType == InvoiceTypeSpecial && req.TitleType != InvoiceTitleTypeEnterprise {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "special invoice requires enterprise title",
+			StatusCode: 0,
+		}
+	}
+	if req.Amount < 0 {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid amount: %s", req.Amount),
+			StatusCode: 0,
+		}
+	}
+
+	var resp *InvoiceResponse
+	if err := s.executor.post(ctx, "/pay-core/invoice/apply", req, &resp, "failed to apply invoice", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *InvoiceService) RedFlushInvoice(ctx context.Context, req *RedFlushInvoiceRequest, opts ...RequestOption) (*InvoiceResponse, error) {
+	if req.InvoiceNo == "" || req.Reason == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "invoiceNo and reason are required",
+			StatusCode: 0,
+		}
+	}
+
+	var resp *InvoiceResponse
+	if err := s.executor.post(ctx, "/pay-core/invoice/red-flush", req, &resp, "failed to red-flush invoice", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *InvoiceService) QueryInvoice(ctx context.Context, req *QueryInvoiceRequest, opts ...RequestOption) (*InvoiceResponse, error) {
+	if req.InvoiceNo == "" && req.OutInvoiceNo == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "invoiceNo or outInvoiceNo is required",
+			StatusCode: 0,
+		}
+	}
+
+	var resp *InvoiceResponse
+	if err := s.executor.post(ctx, "/pay-core/invoice/query", req, &resp, "failed to query invoice", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
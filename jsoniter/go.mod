@@ -0,0 +1,21 @@
+module github.com/haoz-cloud/haozpay-sdk/jsoniter
+
+go 1.23.0
+
+require (
+	github.com/haoz-cloud/haozpay-sdk v1.0.0
+	github.com/json-iterator/go v1.1.12
+)
+
+require (
+	github.com/emmansun/gmsm v0.30.1 // indirect
+	github.com/go-resty/resty/v2 v2.16.5 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+	golang.org/x/crypto v0.37.0 // indirect
+	golang.org/x/net v0.33.0 // indirect
+	golang.org/x/time v0.6.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/haoz-cloud/haozpay-sdk => ../
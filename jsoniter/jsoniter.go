@@ -0,0 +1,67 @@
+// Package haozpayjsoniter 提供基于 json-iterator/go 的 haozpay.Codec 实现
+//
+// 示例:
+//
+//	config := haozpay.DefaultConfig().
+//	    WithMerchantNo("HZ1971294971928846336").
+//	    WithPrivateKey(privateKeyPEM).
+//	    WithCodec(haozpayjsoniter.New())
+package haozpayjsoniter
+
+import (
+	"bytes"
+	"errors"
+
+	jsoniter "github.com/json-iterator/go"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+// errTrailingData 严格解析时 JSON 之后存在多余数据的错误
+var errTrailingData = errors.New("invalid character after top-level value")
+
+// Codec 基于 json-iterator/go 的 haozpay.Codec 实现
+type Codec struct {
+	api jsoniter.API
+}
+
+// New 创建与 encoding/json 行为一致的编解码器（jsoniter.ConfigCompatibleWithStandardLibrary）
+func New() *Codec {
+	return NewWithAPI(jsoniter.ConfigCompatibleWithStandardLibrary)
+}
+
+// NewWithAPI 使用指定的 jsoniter 配置创建编解码器
+//
+// 参数:
+//   - api: jsoniter 配置，例如通过 jsoniter.Config{...}.Froze() 创建
+//
+// 注意:
+//   - 配置需与 encoding/json 兼容（使用 json 标签、支持 json.Marshaler），否则 Money 等类型的序列化结果与平台约定不一致
+func NewWithAPI(api jsoniter.API) *Codec {
+	return &Codec{api: api}
+}
+
+// Marshal 实现 haozpay.Codec 接口
+func (c *Codec) Marshal(v interface{}) ([]byte, error) {
+	return c.api.Marshal(v)
+}
+
+// Unmarshal 实现 haozpay.Codec 接口
+func (c *Codec) Unmarshal(data []byte, v interface{}) error {
+	return c.api.Unmarshal(data, v)
+}
+
+// UnmarshalStrict 实现 haozpay.StrictCodec 接口，JSON 中包含 v 未定义的字段时返回错误
+func (c *Codec) UnmarshalStrict(data []byte, v interface{}) error {
+	dec := c.api.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errTrailingData
+	}
+	return nil
+}
+
+var _ haozpay.StrictCodec = (*Codec)(nil)
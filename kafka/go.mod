@@ -0,0 +1,21 @@
+module github.com/haoz-cloud/haozpay-sdk/kafka
+
+go 1.23.0
+
+require (
+	github.com/haoz-cloud/haozpay-sdk v1.0.0
+	github.com/segmentio/kafka-go v0.4.48
+)
+
+require (
+	github.com/emmansun/gmsm v0.30.1 // indirect
+	github.com/go-resty/resty/v2 v2.16.5 // indirect
+	github.com/klauspost/compress v1.15.9 // indirect
+	github.com/pierrec/lz4/v4 v4.1.15 // indirect
+	golang.org/x/crypto v0.37.0 // indirect
+	golang.org/x/net v0.33.0 // indirect
+	golang.org/x/time v0.6.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/haoz-cloud/haozpay-sdk => ../
@@ -0,0 +1,76 @@
+// Package haozpaykafka 提供将皓臻支付回调通知转发到 Kafka 的 notify.Publisher 实现
+//
+// 消息体为 JSON 序列化的 notify.Message，消息键为 Message.Key，
+// 同一订单的通知写入同一分区，消费方按分区顺序消费即可保证同一订单的通知有序
+//
+// 示例:
+//
+//	writer := &kafka.Writer{
+//	    Addr:         kafka.TCP("127.0.0.1:9092"),
+//	    Topic:        "haozpay-notify",
+//	    RequiredAcks: kafka.RequireAll,
+//	}
+//	defer writer.Close()
+//	dispatcher := notify.NewDispatcher(config)
+//	dispatcher.SetPublisher(haozpaykafka.NewPublisher(writer))
+package haozpaykafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/haoz-cloud/haozpay-sdk/notify"
+)
+
+const (
+	// HeaderType 消息头中通知类型的键
+	HeaderType = "haozpay-type"
+	// HeaderID 消息头中通知去重标识的键，通知没有去重标识时不设置
+	HeaderID = "haozpay-id"
+)
+
+// Publisher 基于 Kafka 的 notify.Publisher 实现
+type Publisher struct {
+	writer *kafka.Writer
+}
+
+// NewPublisher 创建基于 Kafka 的通知转发器
+//
+// 参数:
+//   - writer: Kafka 写入器，需要设置 Topic；Balancer 为空时设置为 kafka.Hash，
+//     kafka-go 默认的轮询分区会打乱同一订单通知的顺序，自行设置时应使用按消息键分区的 Balancer
+//
+// 返回:
+//   - *Publisher: 通知转发器
+//
+// 注意:
+//   - writer 需要同步写入（Async 为 false），否则写入失败时无法通知平台重新推送
+func NewPublisher(writer *kafka.Writer) *Publisher {
+	if writer.Balancer == nil {
+		writer.Balancer = &kafka.Hash{}
+	}
+	return &Publisher{writer: writer}
+}
+
+// Publish 实现 notify.Publisher 接口
+func (p *Publisher) Publish(ctx context.Context, msg *notify.Message) error {
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	headers := []kafka.Header{{Key: HeaderType, Value: []byte(msg.Type)}}
+	if msg.ID != "" {
+		headers = append(headers, kafka.Header{Key: HeaderID, Value: []byte(msg.ID)})
+	}
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Key:     []byte(msg.Key),
+		Value:   value,
+		Headers: headers,
+	})
+}
+
+var _ notify.Publisher = (*Publisher)(nil)
@@ -0,0 +1,94 @@
+package haozpay
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// keyCheckProbe 密钥校验使用的探测内容
+const keyCheckProbe = "haozpay-key-check"
+
+// ValidateKeys 在本地校验密钥配置
+// 使用商户私钥（或 Signer）对探测报文签名，设置了 MerchantPublicKey 时再用其验签，
+// 密钥不匹配时在启动阶段返回明确的错误，而不是在上线后收到平台的验签失败响应
+//
+// 校验内容:
+//   - PrivateKey 可以解析且与 SignType 匹配（设置了 Signer 时校验 Signer 能否签名）
+//   - PublicKey（平台公钥）可以解析且与 SignType 匹配
+//   - MerchantPublicKey 与商户私钥匹配（未设置时跳过）
+//
+// 返回:
+//   - error: 校验失败时返回 *ConfigError，Field 为出错的配置项
+//
+// 注意: 本地校验无法确认 MerchantPublicKey 就是平台登记的公钥，需要时可调用 Client.CheckKeys 由平台校验
+//
+// 示例:
+//
+//	config.WithMerchantPublicKey(merchantPublicKeyPEM)
+//	if err := config.ValidateKeys(); err != nil {
+//	    log.Fatal(err)
+//	}
+func (c *Config) ValidateKeys() error {
+	signType := c.signType()
+	if !isRegisteredSignType(signType) {
+		return &ConfigError{Field: "SignType", Message: fmt.Sprintf("SignType %s is not supported", signType)}
+	}
+	signer := c.Signer
+	if signer == nil {
+		privateKeySigner, err := newPrivateKeySigner(c.PrivateKey, signType)
+		if err != nil {
+			return &ConfigError{Field: "PrivateKey", Message: fmt.Sprintf("PrivateKey is invalid: %v", err)}
+		}
+		signer = privateKeySigner
+	}
+
+	if c.PublicKey != "" {
+		if _, err := newVerifier(c.PublicKey, signType); err != nil {
+			return &ConfigError{Field: "PublicKey", Message: fmt.Sprintf("PublicKey is invalid: %v", err)}
+		}
+	}
+
+	params := map[string]interface{}{
+		"merchantNo": c.MerchantNo,
+		"probe":      keyCheckProbe,
+		"timestamp":  strconv.FormatInt(time.Now().UnixMilli(), 10),
+	}
+	sign, err := GenerateSignWithSignType(params, signer, signType)
+	if err != nil {
+		return &ConfigError{Field: "Signer", Message: fmt.Sprintf("failed to sign with %s: %v", signType, err)}
+	}
+
+	if c.MerchantPublicKey == "" {
+		return nil
+	}
+	if _, err := newVerifier(c.MerchantPublicKey, signType); err != nil {
+		return &ConfigError{Field: "MerchantPublicKey", Message: fmt.Sprintf("MerchantPublicKey is invalid: %v", err)}
+	}
+	if err := VerifySignWithSignType(params, sign, c.MerchantPublicKey, signType); err != nil {
+		return &ConfigError{Field: "MerchantPublicKey", Message: "merchant private key does not match MerchantPublicKey"}
+	}
+	return nil
+}
+
+// CheckKeys 调用平台的密钥校验接口，确认商户私钥与平台登记的商户公钥匹配
+// 平台使用登记的公钥验证请求签名，配置了平台公钥时 SDK 同时验证响应签名，
+// 因此调用成功说明双方的密钥配置均正确
+//
+// 参数:
+//   - ctx: 上下文
+//   - opts: 单次调用的请求选项
+//
+// 返回:
+//   - *CheckKeysResponse: 平台登记的签名算法等信息
+//   - error: 签名不匹配时平台返回验签失败的 SDKError，响应验签失败时错误包装 ErrSignatureInvalid
+//
+// 示例:
+//
+//	if _, err := client.CheckKeys(ctx); err != nil {
+//	    log.Fatalf("haozpay key check failed: %v", err)
+//	}
+func (c *Client) CheckKeys(ctx context.Context, opts ...RequestOption) (*CheckKeysResponse, error) {
+	return call[CheckKeysResponse](ctx, c.executor, "/pay-core/merchant/key/check", &CheckKeysRequest{Probe: keyCheckProbe}, "failed to check keys", opts...)
+}
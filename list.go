@@ -0,0 +1,110 @@
+package haozpay
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"net/http"
+)
+
+// listRateLimitRetries 分页查询遇到 HTTP 429 时单页的最大重试次数
+const listRateLimitRetries = 5
+
+// ListOrdersPage 查询单页订单列表，通常使用 ListOrders 自动分页遍历
+func (s *PaymentService) ListOrdersPage(ctx context.Context, req *ListOrdersRequest, opts ...RequestOption) (*ListOrdersResponse, error) {
+	resp, err := call[ListOrdersResponse](ctx, s.executor, "/pay-core/payment/order/list", req, "failed to list payment orders", opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		resp = &ListOrdersResponse{}
+	}
+	for i := range resp.Orders {
+		resp.Orders[i].Currency = resp.Orders[i].Currency.OrDefault()
+	}
+	return resp, nil
+}
+
+// ListOrders 按条件遍历订单，自动处理分页
+//
+// 迭代器按需逐页查询，调用方提前结束遍历时不会继续请求下一页；
+// 查询被平台限流（HTTP 429）时按指数退避等待后重试当前页
+//
+// 参数:
+//   - ctx: 上下文
+//   - req: 查询条件，PageToken 为空时从第一页开始
+//   - opts: 每页查询使用的请求选项
+//
+// 返回:
+//   - iter.Seq2[*QueryOrderResponse, error]: 订单迭代器，查询失败时产出错误并结束遍历
+//
+// 示例:
+//
+//	for order, err := range client.Payment.ListOrders(ctx, &haozpay.ListOrdersRequest{
+//	    StartTime: "2024-01-01 00:00:00",
+//	    EndTime:   "2024-01-02 00:00:00",
+//	}) {
+//	    if err != nil {
+//	        return err
+//	    }
+//	    fmt.Println(order.OrderNo, order.OrderStatus)
+//	}
+func (s *PaymentService) ListOrders(ctx context.Context, req *ListOrdersRequest, opts ...RequestOption) iter.Seq2[*QueryOrderResponse, error] {
+	return func(yield func(*QueryOrderResponse, error) bool) {
+		var page ListOrdersRequest
+		if req != nil {
+			page = *req
+		}
+
+		for {
+			resp, err := s.listOrdersPageWithBackoff(ctx, &page, opts)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for i := range resp.Orders {
+				if !yield(&resp.Orders[i], nil) {
+					return
+				}
+			}
+
+			if resp.NextPageToken == "" || len(resp.Orders) == 0 {
+				return
+			}
+			page.PageToken = resp.NextPageToken
+		}
+	}
+}
+
+// listOrdersPageWithBackoff 查询单页订单，被限流时退避重试
+func (s *PaymentService) listOrdersPageWithBackoff(ctx context.Context, req *ListOrdersRequest, opts []RequestOption) (*ListOrdersResponse, error) {
+	return withRateLimitBackoff(ctx, func() (*ListOrdersResponse, error) {
+		return s.ListOrdersPage(ctx, req, opts...)
+	})
+}
+
+// withRateLimitBackoff 执行分页查询，被平台限流（HTTP 429）时按指数退避等待后重试，最多重试 listRateLimitRetries 次
+func withRateLimitBackoff[T any](ctx context.Context, fetch func() (T, error)) (T, error) {
+	options := new(WaitOptions).withDefaults()
+	interval := options.InitialInterval
+
+	for attempt := 0; ; attempt++ {
+		resp, err := fetch()
+		if err == nil || attempt >= listRateLimitRetries || !isRateLimited(err) {
+			return resp, err
+		}
+
+		if err := sleepContext(ctx, options.jittered(interval)); err != nil {
+			var zero T
+			return zero, err
+		}
+		interval = options.next(interval)
+	}
+}
+
+// isRateLimited 判断接口调用错误是否为平台限流
+func isRateLimited(err error) bool {
+	var sdkErr *SDKError
+	return errors.As(err, &sdkErr) && sdkErr.StatusCode == http.StatusTooManyRequests
+}
@@ -0,0 +1,91 @@
+package haozpay
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Locker 按键加锁的互斥锁，用于串行化同一订单的变更类请求
+// 配置 Config.Locker 后，PaymentService 在 CreateRefund、CancelOrder 前按订单号加锁，请求结束后释放，
+// 多个进程并发为同一订单退款时请求依次执行。锁只串行化请求，不识别重复提交，重复消费的退款任务在锁释放后仍会再次发送；
+// 开启 RefundAmountCheck 时可退款金额的校验与退款请求在同一把锁内完成，后执行的退款计入先提交的退款，不会超额退款。
+// 同一笔退款只提交一次需由调用方按本地退款单去重，例如通过 outbox 包以退款单号为幂等键发送
+//
+// 多实例部署时应使用 Redis 等共享存储实现（例如 haozpayredis.NewLocker），单实例可使用 MemoryLocker
+type Locker interface {
+	// Lock 获取 key 对应的锁，锁被占用时等待直到获取成功或 ctx 结束
+	// 获取成功时返回释放锁的函数，调用方必须调用且只调用一次
+	Lock(ctx context.Context, key string) (unlock func(), err error)
+}
+
+// MemoryLocker 基于内存的 Locker 实现，只能串行化同一进程内的请求
+// 通过 NewMemoryLocker 函数创建实例，可在多个 goroutine 中并发使用
+type MemoryLocker struct {
+	mu    sync.Mutex
+	locks map[string]*memoryLock
+}
+
+// memoryLock 单个键的锁，waiters 为持有和等待该锁的调用数，为 0 时从 locks 中删除
+type memoryLock struct {
+	ch      chan struct{}
+	waiters int
+}
+
+// NewMemoryLocker 创建基于内存的 Locker
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{locks: make(map[string]*memoryLock)}
+}
+
+// Lock 实现 Locker 接口
+func (l *MemoryLocker) Lock(ctx context.Context, key string) (func(), error) {
+	l.mu.Lock()
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = &memoryLock{ch: make(chan struct{}, 1)}
+		l.locks[key] = lock
+	}
+	lock.waiters++
+	l.mu.Unlock()
+
+	select {
+	case lock.ch <- struct{}{}:
+	case <-ctx.Done():
+		l.release(key, lock, false)
+		return nil, ctx.Err()
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { l.release(key, lock, true) })
+	}, nil
+}
+
+// release 释放锁或放弃等待，没有其他调用持有或等待时删除该键的锁
+func (l *MemoryLocker) release(key string, lock *memoryLock, held bool) {
+	if held {
+		<-lock.ch
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lock.waiters--
+	if lock.waiters == 0 {
+		delete(l.locks, key)
+	}
+}
+
+// lockOrder 按订单号获取 Config.Locker 的锁
+// 未配置 Locker 或订单号为空时不加锁，返回的释放函数为空操作
+func (e *apiExecutor) lockOrder(ctx context.Context, orderNo string) (func(), error) {
+	locker := e.current().config.Locker
+	if locker == nil || orderNo == "" {
+		return func() {}, nil
+	}
+
+	unlock, err := locker.Lock(ctx, "order:"+orderNo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock order %s: %w", orderNo, err)
+	}
+	return unlock, nil
+}
@@ -0,0 +1,101 @@
+package haozpay
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryLockerExclusive(t *testing.T) {
+	locker := NewMemoryLocker()
+	ctx := context.Background()
+
+	// 同一键同时只有一个持有者
+	var holders, maxHolders atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := locker.Lock(ctx, "order:P1")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			n := holders.Add(1)
+			for {
+				max := maxHolders.Load()
+				if n <= max || maxHolders.CompareAndSwap(max, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			holders.Add(-1)
+			unlock()
+		}()
+	}
+	wg.Wait()
+
+	if max := maxHolders.Load(); max != 1 {
+		t.Errorf("max concurrent holders = %d, want 1", max)
+	}
+	if n := len(locker.locks); n != 0 {
+		t.Errorf("locks left after all unlocks = %d, want 0", n)
+	}
+}
+
+func TestMemoryLockerKeys(t *testing.T) {
+	locker := NewMemoryLocker()
+	ctx := context.Background()
+
+	unlock, err := locker.Lock(ctx, "order:P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 不同的键互不影响
+	other, err := locker.Lock(ctx, "order:P2")
+	if err != nil {
+		t.Fatalf("Lock() on another key error = %v", err)
+	}
+	other()
+
+	// 锁被占用时等待到 ctx 结束，放弃等待后不影响持有者
+	waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := locker.Lock(waitCtx, "order:P1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Lock() on a held key error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// 释放后等待的调用获取锁；重复调用释放函数不会释放其他持有者的锁
+	acquired := make(chan func())
+	go func() {
+		next, err := locker.Lock(ctx, "order:P1")
+		if err != nil {
+			t.Error(err)
+			close(acquired)
+			return
+		}
+		acquired <- next
+	}()
+	unlock()
+	next := <-acquired
+	if next == nil {
+		t.FailNow()
+	}
+	unlock()
+
+	waitCtx, cancel = context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := locker.Lock(waitCtx, "order:P1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Lock() after a repeated unlock error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	next()
+
+	if n := len(locker.locks); n != 0 {
+		t.Errorf("locks left after all unlocks = %d, want 0", n)
+	}
+}
@@ -0,0 +1,283 @@
+package haozpay
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Logger SDK 日志接口
+// 请求、响应、调试信息以及底层 HTTP 客户端的日志均通过该接口输出
+// 默认使用 log/slog 输出到标准输出，可通过 Config.WithSlogLogger 接入 slog 日志，
+// 或通过 Config.WithLogger 接入 zap 等日志库
+//
+// keysAndValues 为交替出现的键值对，例如: logger.Info("request sent", "method", "POST", "url", url)
+type Logger interface {
+	// Debug 输出调试级别日志
+	Debug(msg string, keysAndValues ...interface{})
+	// Info 输出信息级别日志
+	Info(msg string, keysAndValues ...interface{})
+	// Warn 输出警告级别日志
+	Warn(msg string, keysAndValues ...interface{})
+	// Error 输出错误级别日志
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// LogLevel 日志级别
+type LogLevel int
+
+const (
+	// LogLevelDebug 调试级别
+	LogLevelDebug LogLevel = iota
+	// LogLevelInfo 信息级别
+	LogLevelInfo
+	// LogLevelWarn 警告级别
+	LogLevelWarn
+	// LogLevelError 错误级别
+	LogLevelError
+)
+
+// String 返回日志级别名称
+func (l LogLevel) String() string {
+	switch l {
+	case LogLevelDebug:
+		return "DEBUG"
+	case LogLevelInfo:
+		return "INFO"
+	case LogLevelWarn:
+		return "WARN"
+	case LogLevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LogLevel(%d)", int(l))
+}
+
+// NewStdLogger 创建输出到 io.Writer 的简单文本日志
+//
+// 参数:
+//   - w: 日志输出目标，例如 os.Stdout
+//   - level: 最低输出级别，低于该级别的日志会被忽略
+//
+// 返回:
+//   - Logger: 日志实例
+//
+// 输出格式:
+//
+//	2025-01-02 15:04:05.000 [INFO] msg key1=value1 key2=value2
+func NewStdLogger(w io.Writer, level LogLevel) Logger {
+	return &stdLogger{w: w, level: level}
+}
+
+// stdLogger 默认文本日志实现
+type stdLogger struct {
+	mu    sync.Mutex
+	w     io.Writer
+	level LogLevel
+}
+
+func (l *stdLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.log(LogLevelDebug, msg, keysAndValues)
+}
+
+func (l *stdLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.log(LogLevelInfo, msg, keysAndValues)
+}
+
+func (l *stdLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.log(LogLevelWarn, msg, keysAndValues)
+}
+
+func (l *stdLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.log(LogLevelError, msg, keysAndValues)
+}
+
+func (l *stdLogger) log(level LogLevel, msg string, keysAndValues []interface{}) {
+	if level < l.level {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(time.Now().Format("2006-01-02 15:04:05.000"))
+	sb.WriteString(" [")
+	sb.WriteString(level.String())
+	sb.WriteString("] ")
+	sb.WriteString(msg)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		sb.WriteString(" ")
+		if i+1 < len(keysAndValues) {
+			fmt.Fprintf(&sb, "%v=%v", keysAndValues[i], keysAndValues[i+1])
+		} else {
+			fmt.Fprintf(&sb, "%v", keysAndValues[i])
+		}
+	}
+	sb.WriteString("\n")
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = io.WriteString(l.w, sb.String())
+}
+
+// NewSlogLogger 使用 log/slog 创建日志
+//
+// 参数:
+//   - logger: slog 日志实例，为 nil 时使用 slog.Default()
+//
+// 返回:
+//   - Logger: 日志实例
+//
+// 示例:
+//
+//	config.WithLogger(haozpay.NewSlogLogger(slog.Default()))
+func NewSlogLogger(logger *slog.Logger) Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &slogLogger{logger: logger}
+}
+
+// slogLogger log/slog 适配
+type slogLogger struct {
+	logger *slog.Logger
+	// ctx 接口调用的 context，传给 slog.Handler，便于 Handler 从中提取链路追踪信息；为 nil 时使用 context.Background()
+	ctx context.Context
+}
+
+func (l *slogLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.log(slog.LevelDebug, msg, keysAndValues)
+}
+
+func (l *slogLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.log(slog.LevelInfo, msg, keysAndValues)
+}
+
+func (l *slogLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.log(slog.LevelWarn, msg, keysAndValues)
+}
+
+func (l *slogLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.log(slog.LevelError, msg, keysAndValues)
+}
+
+func (l *slogLogger) log(level slog.Level, msg string, keysAndValues []interface{}) {
+	ctx := l.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	l.logger.Log(ctx, level, msg, keysAndValues...)
+}
+
+// defaultLogger 未配置 Logger 时使用的默认日志
+// 以 slog 文本格式输出到标准输出，调试模式下输出调试级别日志，否则只输出警告及以上级别
+func defaultLogger(debug bool) Logger {
+	level := slog.LevelWarn
+	if debug {
+		level = slog.LevelDebug
+	}
+	return NewSlogLogger(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: level})))
+}
+
+// loggerKey context 中存储调用日志的键
+type loggerKey struct{}
+
+// WithLogger 为接口调用指定 slog 日志
+// 调用期间 SDK 输出的请求、响应、慢请求等日志使用该日志而非 Config.Logger，
+// 便于复用业务代码中已附加了用户、租户等属性的日志
+//
+// 参数:
+//   - ctx: 调用使用的 context
+//   - logger: slog 日志实例
+//
+// 返回:
+//   - context.Context: 携带日志的 context
+//
+// 示例:
+//
+//	ctx = haozpay.WithLogger(ctx, slog.Default().With("userId", userID))
+//	resp, err := client.Payment.CreateRefund(ctx, req)
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext 获取 context 中通过 WithLogger 指定的日志，未设置时返回 nil
+func LoggerFromContext(ctx context.Context) *slog.Logger {
+	if ctx == nil {
+		return nil
+	}
+	logger, _ := ctx.Value(loggerKey{}).(*slog.Logger)
+	return logger
+}
+
+// callLogger 返回接口调用期间使用的日志
+// context 中通过 WithLogger 指定了日志时使用该日志，否则使用 fallback；
+// 输出时自动附加客户端请求ID（requestId）、接口路径（op）和商户号（merchantNo）
+func callLogger(ctx context.Context, fallback Logger) Logger {
+	var attrs []interface{}
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		attrs = append(attrs, "requestId", requestID)
+	}
+	if info := callInfoFromContext(ctx); info != nil {
+		attrs = append(attrs, "op", info.op)
+		if info.merchantNo != "" {
+			attrs = append(attrs, "merchantNo", info.merchantNo)
+		}
+	}
+
+	if logger := LoggerFromContext(ctx); logger != nil {
+		return &slogLogger{logger: logger.With(attrs...), ctx: ctx}
+	}
+	if l, ok := fallback.(*slogLogger); ok {
+		return &slogLogger{logger: l.logger.With(attrs...), ctx: ctx}
+	}
+	if len(attrs) == 0 {
+		return fallback
+	}
+	return &attrLogger{logger: fallback, attrs: attrs}
+}
+
+// attrLogger 为非 slog 的 Logger 实现附加固定的键值对
+type attrLogger struct {
+	logger Logger
+	attrs  []interface{}
+}
+
+func (l *attrLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.logger.Debug(msg, l.with(keysAndValues)...)
+}
+
+func (l *attrLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.logger.Info(msg, l.with(keysAndValues)...)
+}
+
+func (l *attrLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.logger.Warn(msg, l.with(keysAndValues)...)
+}
+
+func (l *attrLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.logger.Error(msg, l.with(keysAndValues)...)
+}
+
+func (l *attrLogger) with(keysAndValues []interface{}) []interface{} {
+	return append(l.attrs[:len(l.attrs):len(l.attrs)], keysAndValues...)
+}
+
+// restyLogger 将 resty 的日志转发到 SDK Logger
+type restyLogger struct {
+	logger Logger
+}
+
+func (l *restyLogger) Errorf(format string, v ...interface{}) {
+	l.logger.Error(strings.TrimSpace(fmt.Sprintf(format, v...)), "component", "resty")
+}
+
+func (l *restyLogger) Warnf(format string, v ...interface{}) {
+	l.logger.Warn(strings.TrimSpace(fmt.Sprintf(format, v...)), "component", "resty")
+}
+
+func (l *restyLogger) Debugf(format string, v ...interface{}) {
+	l.logger.Debug(strings.TrimSpace(fmt.Sprintf(format, v...)), "component", "resty")
+}
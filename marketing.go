@@ -0,0 +1,105 @@
+package haozpay
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type MarketingService struct {
+	executor *apiExecutor
+}
+
+func NewMarketingService(client *resty.Client, config *Config) *MarketingService {
+	return &MarketingService{
+		executor: newAPIExecutor(client, config),
+	}
+}
+
+func (s *MarketingService) CreateCouponActivity(ctx context.Context, req *CreateCouponActivityRequest, opts ...RequestOption) (*CouponActivityResponse, error) {
+	if !req.CouponType.IsValid() {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid couponType: %d", req.CouponType),
+			StatusCode: 0,
+		}
+	}
+	if req.OutActivityNo == "" || req.ActivityName == "" || req.StartTime == "" || req.EndTime == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "outActivityNo, activityName, startTime and endTime are required",
+			StatusCode: 0,
+		}
+	}
+	if req.TotalCount <= 0 || req.PerUserLimit < 0 {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid totalCount %d or perUserLimit %d", req.TotalCount, req.PerUserLimit),
+			StatusCode: 0,
+		}
+	}
+	if req.ThresholdAmount < 0 || req.MaxDiscountAmount < 0 {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "thresholdAmount and maxDiscountAmount must not be negative",
+			StatusCode: 0,
+		}
+	}
+	switch req.CouponType {
+	case CouponTypeCash:
+		if req.CouponAmount <= 0 {
+			return nil, &SDKError{
+				Code:       ErrInvalidParameter.Code,
+				Message:    fmt.Sprintf("couponAmount must be positive for cash coupon, got %s", req.CouponAmount),
+				StatusCode: 0,
+			}
+		}
+	case CouponTypeDiscount:
+		if req.DiscountPercent <= 0 || req.DiscountPercent >= 100 {
+			return nil, &SDKError{
+				Code:       ErrInvalidParameter.Code,
+				Message:    fmt.Sprintf("discountPercent must be between 1 and 99 for discount coupon, got %d", req.DiscountPercent),
+				StatusCode: 0,
+			}
+		}
+	}
+
+	var resp *CouponActivityResponse
+	if err := s.executor.post(ctx, "/pay-core/coupon/create", req, &resp, "failed to create coupon activity", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *MarketingService) QueryCouponActivity(ctx context.Context, req *QueryCouponActivityRequest, opts ...RequestOption) (*CouponActivityResponse, error) {
+	if req.ActivityNo == "" && req.OutActivityNo == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "activityNo or outActivityNo is required",
+			StatusCode: 0,
+		}
+	}
+
+	var resp *CouponActivityResponse
+	if err := s.executor.post(ctx, "/pay-core/coupon/query", req, &resp, "failed to query coupon activity", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *MarketingService) QueryOrderCoupons(ctx context.Context, req *QueryOrderCouponsRequest, opts ...RequestOption) (*OrderCouponsResponse, error) {
+	if req.OrderNo == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "orderNo is required",
+			StatusCode: 0,
+		}
+	}
+
+	var resp *OrderCouponsResponse
+	if err := s.executor.post(ctx, "/pay-core/coupon/order/query", req, &resp, "failed to query order coupons", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,151 @@
+package haozpay
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// MaxQualificationFileSize 单个资质材料文件的最大大小（字节）
+const MaxQualificationFileSize = 5 << 20
+
+type MerchantService struct {
+	executor *apiExecutor
+}
+
+func NewMerchantService(client *resty.Client, config *Config) *MerchantService {
+	return &MerchantService{
+		executor: newAPIExecutor(client, config),
+	}
+}
+
+func (s *MerchantService) CreateSubMerchant(ctx context.Context, req *CreateSubMerchantRequest, opts ...RequestOption) (*SubMerchantResponse, error) {
+	if !req.MerchantType.IsValid() {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid merchantType: %d", req.MerchantType),
+			StatusCode: 0,
+		}
+	}
+	if !req.SettleAccountType.IsValid() {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid settleAccountType: %d", req.SettleAccountType),
+			StatusCode: 0,
+		}
+	}
+
+	var resp *SubMerchantResponse
+	if err := s.executor.post(ctx, "/pay-core/merchant/apply", req, &resp, "failed to create sub-merchant", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *MerchantService) UploadQualification(ctx context.Context, applyNo string, qualificationType QualificationType, fileName string, content []byte, opts ...RequestOption) (*QualificationResponse, error) {
+	if !qualificationType.IsValid() {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid qualificationType: %d", qualificationType),
+			StatusCode: 0,
+		}
+	}
+	if len(content) == 0 || len(content) > MaxQualificationFileSize {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("qualification file size must be between 1 and %d bytes, got %d", MaxQualificationFileSize, len(content)),
+			StatusCode: 0,
+		}
+	}
+
+	req := &UploadQualificationRequest{
+		ApplyNo:           applyNo,
+		QualificationType: qualificationType,
+		FileName:          fileName,
+		FileContent:       base64.StdEncoding.EncodeToString(content),
+	}
+
+	var resp *QualificationResponse
+	if err := s.executor.post(ctx, "/pay-core/merchant/qualification/upload", req, &resp, "failed to upload qualification", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *MerchantService) QuerySubMerchant(ctx context.Context, req *QuerySubMerchantRequest, opts ...RequestOption) (*QuerySubMerchantResponse, error) {
+	if req.ApplyNo == "" && req.OutApplyNo == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "applyNo or outApplyNo is required",
+			StatusCode: 0,
+		}
+	}
+
+	var resp *QuerySubMerchantResponse
+	if err := s.executor.post(ctx, "/pay-core/merchant/apply/query", req, &resp, "failed to query sub-merchant", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *MerchantService) ModifySettlementAccount(ctx context.Context, req *ModifySettlementAccountRequest, opts ...RequestOption) (*ModifySettlementAccountResponse, error) {
+	if !req.SettleAccountType.IsValid() {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid settleAccountType: %d", req.SettleAccountType),
+			StatusCode: 0,
+		}
+	}
+
+	var resp *ModifySettlementAccountResponse
+	if err := s.executor.post(ctx, "/pay-core/merchant/settlement/modify", req, &resp, "failed to modify settlement account", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *MerchantService) QueryConfig(ctx context.Context, opts ...RequestOption) (*MerchantConfigResponse, error) {
+	var resp *MerchantConfigResponse
+	if err := s.executor.post(ctx, "/pay-core/merchant/config/query", &QueryMerchantConfigRequest{}, &resp, "failed to query merchant config", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// Channel 返回指定支付方式的渠道配置，商户未开通该支付方式时返回 nil
+func (r *MerchantConfigResponse) Channel(payType PayType) *MerchantChannelConfig {
+	for i := range r.Channels {
+		if r.Channels[i].PayType == payType {
+			return &r.Channels[i]
+		}
+	}
+	return nil
+}
+
+// EnabledPayTypes 返回已启用的支付方式，顺序与平台返回的一致，可用于生成收银台的支付方式列表
+func (r *MerchantConfigResponse) EnabledPayTypes() []PayType {
+	var payTypes []PayType
+	for _, channel := range r.Channels {
+		if channel.Enabled {
+			payTypes = append(payTypes, channel.PayType)
+		}
+	}
+	return payTypes
+}
+
+// Allows 判断渠道是否已启用且金额在单笔限额范围内
+// MinAmount、MaxAmount 为 0 时表示不限制；不检查单日累计限额（DailyLimit）
+func (c *MerchantChannelConfig) Allows(amount Money) bool {
+	if !c.Enabled {
+		return false
+	}
+	if c.MinAmount > 0 && amount < c.MinAmount {
+		return false
+	}
+	if c.MaxAmount > 0 && amount > c.MaxAmount {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,226 @@
+package haozpay
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RetryOutcome 接口调用的重试结果
+type RetryOutcome string
+
+const (
+	// RetryOutcomeNone 只发送了一次请求（或未发送请求）
+	RetryOutcomeNone RetryOutcome = "none"
+	// RetryOutcomeRecovered 重试后调用成功
+	RetryOutcomeRecovered RetryOutcome = "recovered"
+	// RetryOutcomeExhausted 重试后调用仍然失败
+	RetryOutcomeExhausted RetryOutcome = "exhausted"
+)
+
+// CodeUnknownError CallResult.Code 的取值，表示错误不是 SDKError（例如获取订单锁失败）
+const CodeUnknownError = -1
+
+// CallResult 单次接口调用的结果，传给 Metrics.CallFinished
+type CallResult struct {
+	// Code 结果码：成功为 0，失败为 SDKError.Code（平台业务错误码或 SDK 预定义错误码），
+	// 其他错误为 CodeUnknownError
+	Code int
+	// StatusCode 平台响应的 HTTP 状态码，未收到响应时为 0
+	StatusCode int
+	// Attempts 发送请求的次数，包括重试；未发送请求时为 0
+	Attempts int
+	// Retry 重试结果
+	Retry RetryOutcome
+	// Duration 调用耗时，包括所有重试
+	Duration time.Duration
+}
+
+// Metrics 接口调用指标的采集接口
+// 配置 Config.Metrics 后，每次接口调用开始时调用 CallStarted，结束时调用 CallFinished，
+// 可据此统计各接口的调用次数、失败原因、重试结果和正在进行的调用数；
+// 方法在调用所在的 goroutine 中同步执行，实现不应阻塞，且需支持并发调用
+//
+// 对接 Prometheus 等监控系统时可自行实现，或使用 MemoryMetrics 并通过 WritePrometheus 输出
+type Metrics interface {
+	// CallStarted 接口调用开始
+	CallStarted(op string)
+	// CallFinished 接口调用结束
+	CallFinished(op string, result CallResult)
+}
+
+// newCallResult 根据调用错误和发送次数生成调用结果
+func newCallResult(err error, attempts int, duration time.Duration) CallResult {
+	result := CallResult{Attempts: attempts, Retry: RetryOutcomeNone, Duration: duration}
+
+	var sdkErr *SDKError
+	switch {
+	case err == nil:
+	case errors.As(err, &sdkErr):
+		result.Code = sdkErr.Code
+		result.StatusCode = sdkErr.StatusCode
+	default:
+		result.Code = CodeUnknownError
+	}
+
+	if attempts > 1 {
+		result.Retry = RetryOutcomeRecovered
+		if err != nil {
+			result.Retry = RetryOutcomeExhausted
+		}
+	}
+	return result
+}
+
+// CallCounter MemoryMetrics 按接口、结果码和重试结果统计的调用次数
+type CallCounter struct {
+	// Op 接口路径
+	Op string
+	// Code 结果码，含义与 CallResult.Code 相同
+	Code int
+	// Retry 重试结果
+	Retry RetryOutcome
+	// Count 调用次数
+	Count int64
+	// Duration 累计耗时
+	Duration time.Duration
+}
+
+// callCounterKey CallCounter 的统计维度
+type callCounterKey struct {
+	op    string
+	code  int
+	retry RetryOutcome
+}
+
+// MemoryMetrics 基于内存的 Metrics 实现
+// 按接口、结果码和重试结果累计调用次数和耗时，并记录各接口正在进行的调用数，
+// 通过 Counters、InFlight 读取，或通过 WritePrometheus 输出为 Prometheus 文本格式
+// 通过 NewMemoryMetrics 函数创建实例，可在多个 goroutine 中并发使用
+type MemoryMetrics struct {
+	mu       sync.Mutex
+	counters map[callCounterKey]*CallCounter
+	inFlight map[string]int64
+}
+
+// NewMemoryMetrics 创建基于内存的 Metrics
+//
+// 示例:
+//
+//	metrics := haozpay.NewMemoryMetrics()
+//	config.WithMetrics(metrics)
+//
+//	http.HandleFunc("/metrics/haozpay", func(w http.ResponseWriter, r *http.Request) {
+//	    metrics.WritePrometheus(w)
+//	})
+func NewMemoryMetrics() *MemoryMetrics {
+	return &MemoryMetrics{
+		counters: make(map[callCounterKey]*CallCounter),
+		inFlight: make(map[string]int64),
+	}
+}
+
+// CallStarted 实现 Metrics 接口
+func (m *MemoryMetrics) CallStarted(op string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inFlight[op]++
+}
+
+// CallFinished 实现 Metrics 接口
+func (m *MemoryMetrics) CallFinished(op string, result CallResult) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.inFlight[op]--
+	key := callCounterKey{op: op, code: result.Code, retry: result.Retry}
+	counter, ok := m.counters[key]
+	if !ok {
+		counter = &CallCounter{Op: op, Code: result.Code, Retry: result.Retry}
+		m.counters[key] = counter
+	}
+	counter.Count++
+	counter.Duration += result.Duration
+}
+
+// Counters 返回各统计维度的调用次数，按接口路径、结果码、重试结果排序
+func (m *MemoryMetrics) Counters() []CallCounter {
+	m.mu.Lock()
+	counters := make([]CallCounter, 0, len(m.counters))
+	for _, counter := range m.counters {
+		counters = append(counters, *counter)
+	}
+	m.mu.Unlock()
+
+	sort.Slice(counters, func(i, j int) bool {
+		a, b := counters[i], counters[j]
+		if a.Op != b.Op {
+			return a.Op < b.Op
+		}
+		if a.Code != b.Code {
+			return a.Code < b.Code
+		}
+		return a.Retry < b.Retry
+	})
+	return counters
+}
+
+// InFlight 返回各接口正在进行的调用数
+func (m *MemoryMetrics) InFlight() map[string]int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	inFlight := make(map[string]int64, len(m.inFlight))
+	for op, n := range m.inFlight {
+		inFlight[op] = n
+	}
+	return inFlight
+}
+
+// WritePrometheus 以 Prometheus 文本格式输出指标
+//   - haozpay_calls_total{op,code,retry}: 调用次数
+//   - haozpay_call_duration_seconds_total{op,code,retry}: 累计耗时
+//   - haozpay_calls_in_flight{op}: 正在进行的调用数
+func (m *MemoryMetrics) WritePrometheus(w io.Writer) error {
+	counters := m.Counters()
+	inFlight := m.InFlight()
+	ops := make([]string, 0, len(inFlight))
+	for op := range inFlight {
+		ops = append(ops, op)
+	}
+	sort.Strings(ops)
+
+	pw := &prometheusWriter{w: w}
+	pw.printf("# HELP haozpay_calls_total HaozPay API calls by operation, result code and retry outcome.\n")
+	pw.printf("# TYPE haozpay_calls_total counter\n")
+	for _, c := range counters {
+		pw.printf("haozpay_calls_total{op=%q,code=\"%d\",retry=%q} %d\n", c.Op, c.Code, c.Retry, c.Count)
+	}
+	pw.printf("# HELP haozpay_call_duration_seconds_total Total duration of HaozPay API calls, including retries.\n")
+	pw.printf("# TYPE haozpay_call_duration_seconds_total counter\n")
+	for _, c := range counters {
+		pw.printf("haozpay_call_duration_seconds_total{op=%q,code=\"%d\",retry=%q} %g\n", c.Op, c.Code, c.Retry, c.Duration.Seconds())
+	}
+	pw.printf("# HELP haozpay_calls_in_flight HaozPay API calls in progress.\n")
+	pw.printf("# TYPE haozpay_calls_in_flight gauge\n")
+	for _, op := range ops {
+		pw.printf("haozpay_calls_in_flight{op=%q} %d\n", op, inFlight[op])
+	}
+	return pw.err
+}
+
+// prometheusWriter 按顺序写入指标文本，记录第一个写入错误
+type prometheusWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (p *prometheusWriter) printf(format string, args ...interface{}) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = fmt.Fprintf(p.w, format, args...)
+}
@@ -1,15 +1,14 @@
 package haozpay
 
 import (
+	"bytes"
 	"crypto/rsa"
-	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"math/big"
-	"sort"
 	"strings"
 
 	"github.com/go-resty/resty/v2"
@@ -19,51 +18,49 @@ import (
 // 在每个请求发送前自动添加签名字段
 //
 // 皓臻支付签名算法:
-//  1. 收集请求参数(排除sign字段)
+//  1. 收集请求参数(排除sign和signType字段)
 //  2. 按参数名ASCII码升序排序
 //  3. 按"key=value"格式用&拼接成字符串
-//  4. 用SHA256算法生成摘要
-//  5. 用商户私钥对摘要进行RSA加密
+//  4. 按签名算法生成摘要(RSA2、RSA-PSS使用SHA256，RSA使用SHA1，SM2使用SM3)
+//  5. 用签名器对摘要进行签名(默认为商户私钥RSA加密)
+//
+// 签名算法类型写入请求报文的 signType 字段，平台据此选择验签算法
+// 表单编码的请求（RequestEncodingForm）、GET 请求和文件上传请求使用相同的签名串，签名结果写入表单、查询串或 multipart 的 sign 字段
 //
 // 参数:
-//   - privateKeyPEM: 商户私钥(PEM格式)
+//   - signer: 请求签名器
+//   - signType: 签名算法类型，决定摘要算法
 //
 // 返回:
 //   - resty.RequestMiddleware: resty 请求中间件函数
-func signatureMiddleware(privateKeyPEM string) resty.RequestMiddleware {
+func signatureMiddleware(signer Signer, signType SignType) resty.RequestMiddleware {
 	return func(c *resty.Client, r *resty.Request) error {
 		if r.Body == nil {
 			return nil
 		}
 
+		if formReq, ok := r.Body.(*formRequest); ok {
+			return signFormRequest(r, formReq, signer, signType)
+		}
+		if multipartReq, ok := r.Body.(*multipartRequest); ok {
+			return signMultipartRequest(r, multipartReq, signer, signType)
+		}
 		haozReq, ok := r.Body.(*HaozPayRequest)
 		if !ok {
 			return nil
 		}
 
-		paramsMap := make(map[string]interface{})
-
-		// 展开 bizBody JSON 到 paramsMap
-		if haozReq.BizBody != "" {
-			var bizBodyMap map[string]interface{}
-			if err := json.Unmarshal([]byte(haozReq.BizBody), &bizBodyMap); err != nil {
-				return fmt.Errorf("failed to unmarshal bizBody: %w", err)
-			}
-			// 将 bizBody 中的所有字段添加到 paramsMap
-			for k, v := range bizBodyMap {
-				paramsMap[k] = v
-			}
+		paramsMap, err := requestSignParams(haozReq)
+		if err != nil {
+			return err
 		}
 
-		// 添加 merchantNo 和 timestamp（使用数字类型，不是字符串）
-		paramsMap["merchantNo"] = haozReq.MerchantNo
-		paramsMap["timestamp"] = haozReq.Timestamp
-
-		sign, err := GenerateSign(paramsMap, privateKeyPEM)
+		sign, err := GenerateSignWithSignType(paramsMap, signer, signType)
 		if err != nil {
 			return fmt.Errorf("failed to generate signature: %w", err)
 		}
 
+		haozReq.SignType = signType
 		haozReq.Sign = sign
 		r.SetBody(haozReq)
 
@@ -71,66 +68,100 @@ func signatureMiddleware(privateKeyPEM string) resty.RequestMiddleware {
 	}
 }
 
+// requestSignParams 返回 JSON 报文的签名参数：bizBody 的各字段加上 merchantNo 和 timestamp
+func requestSignParams(haozReq *HaozPayRequest) (map[string]interface{}, error) {
+	// 展开 bizBody JSON 到 paramsMap
+	var paramsMap map[string]interface{}
+	if haozReq.BizBody != "" {
+		// 使用 json.Number 保留金额等数字的原始文本（例如 12.30），与 bizBody 中的内容保持一致
+		decoder := json.NewDecoder(strings.NewReader(haozReq.BizBody))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&paramsMap); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal bizBody: %w", err)
+		}
+	}
+	if paramsMap == nil {
+		paramsMap = make(map[string]interface{}, 2)
+	}
+
+	// 添加 merchantNo 和 timestamp（使用数字类型，不是字符串）
+	paramsMap["merchantNo"] = haozReq.MerchantNo
+	paramsMap["timestamp"] = haozReq.Timestamp
+	return paramsMap, nil
+}
+
 // verifyHaozPaySignature 验证皓臻支付回调签名
 // 验签算法流程:
 //  1. 构建签名字符串(按参数名ASCII升序排序)
-//  2. 计算SHA256摘要
-//  3. 使用平台公钥解密签名
-//  4. 比较解密后的摘要与计算的摘要是否一致
+//  2. 按签名算法计算摘要(RSA2、RSA-PSS使用SHA256，RSA使用SHA1，SM2使用SM3)
+//  3. 使用平台公钥验证签名
+//  4. 比较签名中的摘要与计算的摘要是否一致
+//
+// 公钥在首次验签时解析，之后复用解析结果
 //
 // 参数:
 //   - publicKeyPEM: 平台公钥(PEM格式)
-//   - params: 回调参数(不含sign字段)
+//   - signType: 签名算法类型，为空时根据公钥类型选择 RSA2 或 SM2
+//   - params: 回调参数(不含sign和signType字段)
 //   - signature: Base64编码的签名字符串
 //
 // 返回:
-//   - error: 验签失败时返回错误
-func verifyHaozPaySignature(publicKeyPEM string, params map[string]string, signature string) error {
-	keys := make([]string, 0, len(params))
-	for k := range params {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-
-	var sb strings.Builder
-	for i, key := range keys {
-		value := params[key]
-		if value != "" {
-			if i > 0 {
-				sb.WriteString("&")
-			}
-			sb.WriteString(key)
-			sb.WriteString("=")
-			sb.WriteString(value)
-		}
-	}
-
-	paramsStr := sb.String()
-	hash := sha256.Sum256([]byte(paramsStr))
-	hashHex := fmt.Sprintf("%x", hash)
-
-	publicKey, err := parsePublicKey(publicKeyPEM)
+//   - error: 公钥无法解析时返回错误；签名不匹配时返回包装 ErrSignatureInvalid 的错误
+func verifyHaozPaySignature(publicKeyPEM string, signType SignType, params map[string]string, signature string) error {
+	verifier, err := cachedVerifier(publicKeyPEM, signType)
 	if err != nil {
 		return fmt.Errorf("failed to parse public key: %w", err)
 	}
 
+	return verifySignature(verifier, params, signature)
+}
+
+// verifySignature 使用已解析的平台验签器验证签名
+// 验签算法与 verifyHaozPaySignature 一致
+func verifySignature(verifier signatureVerifier, params map[string]string, signature string) error {
+	// 与请求签名使用相同的签名串规则（字典序排序，空值跳过）
+	digest := verifier.digest(buildSignStringFromStrings(params))
+
 	sigBytes, err := base64.StdEncoding.DecodeString(signature)
 	if err != nil {
-		return fmt.Errorf("failed to decode signature: %w", err)
+		return fmt.Errorf("%w: failed to decode signature: %v", ErrSignatureInvalid, err)
 	}
 
-	decrypted, err := decryptWithPublicKey(publicKey, sigBytes)
-	if err != nil {
-		return fmt.Errorf("failed to decrypt with public key: %w", err)
-	}
+	return verifier.verify([]byte(digest), sigBytes)
+}
 
-	if string(decrypted) != hashHex {
-		return fmt.Errorf("signature verification failed: hash mismatch")
-	}
+// decodeSignParams 将 JSON 对象的字段展开到验签参数中
+// 使用 json.Number 保留数字的原始文本，避免浮点格式化导致签名串不一致；对象和数组按 signValueString 的规则转换
+func decodeSignParams(data []byte, params map[string]string) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
 
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return err
+	}
+	for k, v := range fields {
+		if v == nil {
+			continue
+		}
+		params[k] = signValueString(v)
+	}
 	return nil
 }
 
+// decodeSignValue 将 JSON 值转换为签名串中的文本，规则与 decodeSignParams 中的字段值相同
+func decodeSignValue(data []byte) (string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return "", err
+	}
+	return signValueString(value), nil
+}
+
 // decryptWithPublicKey 使用公钥解密数据
 // 这是非标准的RSA用法，但与Java的Hutool库行为一致
 // Java的Hutool库实际上是用公钥做"验签"操作（textbook RSA）
@@ -144,7 +175,21 @@ func decryptWithPublicKey(publicKey *rsa.PublicKey, data []byte) ([]byte, error)
 	m := new(big.Int).Exp(c, big.NewInt(int64(publicKey.E)), publicKey.N)
 
 	// 去除前导零，返回原始数据
-	return m.Bytes(), nil
+	em := m.Bytes()
+
+	// 签名方使用 PKCS1v15 填充（block type 1）时去除填充: 0x01 || PS || 0x00 || M
+	if len(em) > 0 && em[0] == 0x01 {
+		for i := 1; i < len(em); i++ {
+			if em[i] == 0x00 {
+				return em[i+1:], nil
+			}
+			if em[i] != 0xFF {
+				break
+			}
+		}
+	}
+
+	return em, nil
 }
 
 // parsePublicKey 解析PEM格式的公钥
@@ -152,20 +197,9 @@ func decryptWithPublicKey(publicKey *rsa.PublicKey, data []byte) ([]byte, error)
 //  1. 完整的 PEM 格式(带 -----BEGIN/END----- 标志)
 //  2. 纯 Base64 编码的密钥字符串(不带标志)
 func parsePublicKey(publicKeyPEM string) (*rsa.PublicKey, error) {
-	var keyBytes []byte
-
-	// 尝试 PEM 解码
-	block, _ := pem.Decode([]byte(publicKeyPEM))
-	if block != nil {
-		// PEM 格式
-		keyBytes = block.Bytes
-	} else {
-		// 可能是纯 Base64 格式，尝试直接解码
-		decoded, err := base64.StdEncoding.DecodeString(publicKeyPEM)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decode public key: not valid PEM or Base64 format")
-		}
-		keyBytes = decoded
+	keyBytes, err := decodePublicKeyBytes(publicKeyPEM)
+	if err != nil {
+		return nil, err
 	}
 
 	pubInterface, err := x509.ParsePKIXPublicKey(keyBytes)
@@ -181,13 +215,40 @@ func parsePublicKey(publicKeyPEM string) (*rsa.PublicKey, error) {
 	return pubKey, nil
 }
 
+// decodePublicKeyBytes 将 PEM 或纯 Base64 格式的公钥解码为 DER 字节
+// PEM 格式的 X.509 证书（CERTIFICATE）返回证书中的公钥
+func decodePublicKeyBytes(publicKeyPEM string) ([]byte, error) {
+	// 尝试 PEM 解码
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	if block != nil && block.Type == certificatePEMType {
+		cert, err := parseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.RawSubjectPublicKeyInfo, nil
+	}
+	if block != nil {
+		// PEM 格式
+		return block.Bytes, nil
+	}
+
+	// 可能是纯 Base64 格式，尝试直接解码
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKeyPEM))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode public key: not valid PEM or Base64 format")
+	}
+	return decoded, nil
+}
+
 // errorHandlerMiddleware 错误处理中间件
 // 在接收到响应后检查 HTTP 状态码，如果是错误状态则解析错误信息
 //
 // 处理逻辑:
 //  1. 检查 HTTP 状态码是否 >= 400
 //  2. 如果是错误状态，尝试解析响应体中的错误信息
-//  3. 将错误信息包装为 SDKError 类型返回
+//  3. 将错误信息包装为 SDKError 类型返回，明细错误码和字段校验错误保存在 SDKError.Details
+//  4. 响应体无法解析或未包含错误码时，按 HTTP 状态码使用预定义错误码
+//     （ErrUnauthorized、ErrForbidden、ErrNotFound、ErrServerError 或 ErrInvalidResponse）
 //
 // 返回:
 //   - resty.ResponseMiddleware: resty 响应中间件函数
@@ -199,76 +260,172 @@ func errorHandlerMiddleware() resty.ResponseMiddleware {
 
 			// 尝试解析错误响应
 			if err := json.Unmarshal(r.Body(), &errResp); err != nil {
-				// 解析失败时返回通用错误
+				// 解析失败时按状态码返回预定义错误
 				return NewSDKError(
-					0,
-					"failed to parse error response",
+					httpStatusError(r.StatusCode()).Code,
+					fmt.Sprintf("failed to parse error response: %s", r.Status()),
 					r.StatusCode(),
 				)
 			}
 
+			if errResp.Code == 0 {
+				statusErr := httpStatusError(r.StatusCode())
+				errResp.Code = statusErr.Code
+				if errResp.Message == "" {
+					errResp.Message = statusErr.Message
+				}
+			}
+
 			// 返回包含详细信息的 SDK 错误
-			return NewSDKErrorWithRequestID(
-				errResp.Code,
-				errResp.Message,
-				r.StatusCode(),
-				errResp.RequestID,
-			)
+			return responseError(&errResp, r.StatusCode())
 		}
 		return nil
 	}
 }
 
+// responseSignatureMiddleware 响应验签中间件
+// 在接收到成功响应后使用平台公钥验证响应签名
+//
+// 验签参数:
+//  1. 响应报文中除 sign 和 data 外的顶层字段
+//  2. data 为 JSON 对象时展开其中的所有字段，否则以 data 整体参与签名
+//
+// 演练模式的合成响应没有平台签名，不进行验签
+//
+// 参数:
+//   - verifier: 平台公钥验签器
+//   - dryRun: 客户端是否使用演练模式（Config.DryRun）
+//
+// 返回:
+//   - resty.ResponseMiddleware: resty 响应中间件函数，验签失败时返回 ErrSignatureVerification 错误码的 SDKError，并包装 ErrSignatureInvalid
+func responseSignatureMiddleware(verifier signatureVerifier, dryRun bool) resty.ResponseMiddleware {
+	return func(c *resty.Client, r *resty.Response) error {
+		// 错误响应由 errorHandlerMiddleware 处理
+		if r.StatusCode() >= 400 {
+			return nil
+		}
+		if dryRun || isDryRun(r.Request.Context()) {
+			return nil
+		}
+
+		var envelope map[string]json.RawMessage
+		if err := json.Unmarshal(r.Body(), &envelope); err != nil {
+			return newSignatureVerificationError("invalid response body", r.StatusCode(), "")
+		}
+
+		var requestID, sign string
+		_ = json.Unmarshal(envelope["request_id"], &requestID)
+		if err := json.Unmarshal(envelope["sign"], &sign); err != nil || sign == "" {
+			return newSignatureVerificationError("response sign is missing", r.StatusCode(), requestID)
+		}
+
+		params := make(map[string]string)
+		for k, v := range envelope {
+			if k == "sign" || k == "signType" || k == "data" {
+				continue
+			}
+			var value interface{}
+			decoder := json.NewDecoder(bytes.NewReader(v))
+			decoder.UseNumber()
+			if err := decoder.Decode(&value); err == nil && value != nil {
+				params[k] = signValueString(value)
+			}
+		}
+		if data, ok := envelope["data"]; ok && len(data) > 0 && string(data) != "null" {
+			if data[0] == '{' {
+				if err := decodeSignParams(data, params); err != nil {
+					return newSignatureVerificationError("invalid response data", r.StatusCode(), requestID)
+				}
+			} else {
+				// 数组、字符串等非对象的 data 作为名为 data 的参数，与其他参数值使用相同的规则转换
+				value, err := decodeSignValue(data)
+				if err != nil {
+					return newSignatureVerificationError("invalid response data", r.StatusCode(), requestID)
+				}
+				params["data"] = value
+			}
+		}
+
+		if err := verifySignature(verifier, params, sign); err != nil {
+			return newSignatureVerificationError(err.Error(), r.StatusCode(), requestID)
+		}
+
+		return nil
+	}
+}
+
+// newSignatureVerificationError 创建验签失败的 SDKError
+func newSignatureVerificationError(reason string, statusCode int, requestID string) *SDKError {
+	return &SDKError{
+		Code:       ErrSignatureVerification.Code,
+		Message:    fmt.Sprintf("invalid response signature: %s", reason),
+		RequestID:  requestID,
+		StatusCode: statusCode,
+		Err:        ErrSignatureInvalid,
+	}
+}
+
 // requestLogMiddleware 请求日志中间件
-// 在调试模式下打印请求详情
+// 在调试模式下以 Debug 级别输出请求详情
 //
-// 打印内容:
+// 输出内容（附加客户端请求ID、接口路径和商户号）:
 //   - 请求方法和 URL
-//   - 请求体内容(格式化的 JSON)
+//   - 请求体内容(JSON)
 //
 // 参数:
+//   - logger: 日志实例
 //   - debug: 是否开启调试模式
 //
 // 返回:
 //   - resty.RequestMiddleware: resty 请求中间件函数
-func requestLogMiddleware(debug bool) resty.RequestMiddleware {
+func requestLogMiddleware(logger Logger, debug bool) resty.RequestMiddleware {
 	return func(c *resty.Client, r *resty.Request) error {
 		if debug {
-			// 打印请求行
-			fmt.Printf("[SDK Request] %s %s\n", r.Method, r.URL)
-
-			// 打印请求体
+			var body string
 			if r.Body != nil {
-				bodyBytes, _ := json.MarshalIndent(r.Body, "", "  ")
-				fmt.Printf("[SDK Request Body] %s\n", string(bodyBytes))
+				bodyBytes, _ := json.Marshal(r.Body)
+				body = string(bodyBytes)
 			}
+			callLogger(r.Context(), logger).Debug("[SDK Request]",
+				"method", r.Method,
+				"url", r.URL,
+				"body", body,
+			)
 		}
 		return nil
 	}
 }
 
 // responseLogMiddleware 响应日志中间件
-// 在调试模式下打印响应详情
+// 在调试模式下以 Debug 级别输出响应详情，HTTP 错误状态码以 Warn 级别输出
 //
-// 打印内容:
+// 输出内容（附加客户端请求ID、接口路径和商户号）:
 //   - HTTP 状态码
 //   - 请求耗时
 //   - 响应体内容
 //
 // 参数:
+//   - logger: 日志实例
 //   - debug: 是否开启调试模式
 //
 // 返回:
 //   - resty.ResponseMiddleware: resty 响应中间件函数
-func responseLogMiddleware(debug bool) resty.ResponseMiddleware {
+func responseLogMiddleware(logger Logger, debug bool) resty.ResponseMiddleware {
 	return func(c *resty.Client, r *resty.Response) error {
-		if debug {
-			// 打印响应状态和耗时
-			fmt.Printf("[SDK Response] Status: %d, Time: %v\n",
-				r.StatusCode(), r.Time())
-
-			// 打印响应体
-			fmt.Printf("[SDK Response Body] %s\n", string(r.Body()))
+		switch {
+		case debug:
+			callLogger(r.Request.Context(), logger).Debug("[SDK Response]",
+				"status", r.StatusCode(),
+				"time", r.Time(),
+				"body", string(r.Body()),
+			)
+		case r.StatusCode() >= 400:
+			callLogger(r.Request.Context(), logger).Warn("[SDK Response] error status",
+				"method", r.Request.Method,
+				"url", r.Request.URL,
+				"status", r.StatusCode(),
+				"time", r.Time(),
+			)
 		}
 		return nil
 	}
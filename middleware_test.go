@@ -0,0 +1,41 @@
+package haozpay_test
+
+import (
+	"context"
+	"testing"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+	"github.com/haoz-cloud/haozpay-sdk/haozpaytest"
+)
+
+func TestResponseSignatureNonObjectData(t *testing.T) {
+	const path = "/pay-core/test/list"
+	type item struct {
+		Z string `json:"z"`
+		A string `json:"a"`
+	}
+	server, client := newTestGateway(t)
+	ctx := context.Background()
+
+	// 结构体按声明顺序序列化，签名串中为键按字典序排列的 JSON 文本
+	server.Handle(path, func(req *haozpaytest.Request) (interface{}, error) {
+		return []item{{Z: "1", A: "a<b"}}, nil
+	})
+	items, err := haozpay.Do[[]item](ctx, client, path, map[string]string{})
+	if err != nil {
+		t.Fatalf("Do() with array data error = %v", err)
+	}
+	if len(*items) != 1 || (*items)[0].A != "a<b" {
+		t.Errorf("items = %+v", *items)
+	}
+
+	// 字符串按解码后的文本签名，包含转义字符时与原始 JSON 文本不同
+	server.Respond(path, `say "hi"`)
+	text, err := haozpay.Do[string](ctx, client, path, map[string]string{})
+	if err != nil {
+		t.Fatalf("Do() with string data error = %v", err)
+	}
+	if *text != `say "hi"` {
+		t.Errorf("text = %s", *text)
+	}
+}
@@ -0,0 +1,232 @@
+package haozpay
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Money 金额，以分为单位的整数存储，避免浮点运算带来的精度问题
+//
+// JSON 序列化为保留两位小数的十进制数（例如 12.30），
+// 反序列化同时支持数字（12.3）和字符串（"12.30"）两种形式
+//
+// 示例:
+//
+//	amount := haozpay.Fen(1230)                    // 12.30 元
+//	amount, err := haozpay.ParseMoney("12.30")     // 12.30 元
+//	amount, err := haozpay.FromYuanString("12.30") // 12.30 元，严格校验格式
+//	fmt.Println(amount.String())                   // "12.30"
+//	fmt.Println(amount.FormatYuan())               // "12.30"，千分位分组，例如 "1,234,567.80"
+type Money int64
+
+// Fen 使用分值创建金额
+//
+// 参数:
+//   - fen: 金额，单位为分
+//
+// 返回:
+//   - Money: 金额
+func Fen(fen int64) Money {
+	return Money(fen)
+}
+
+// ParseMoney 解析以元为单位的十进制金额字符串
+//
+// 参数:
+//   - s: 金额字符串，例如 "12"、"12.3"、"12.30"、"-0.01"
+//
+// 返回:
+//   - Money: 金额
+//   - error: 格式错误或小数位超过两位（非零）时返回错误
+//
+// 注意:
+//   - 解析过程不经过浮点数，"0.1" + "0.2" 结果精确为 "0.30"
+func ParseMoney(s string) (Money, error) {
+	raw := s
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("invalid money %q: empty", raw)
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart, hasDot := strings.Cut(s, ".")
+	if intPart == "" && (!hasDot || fracPart == "") {
+		return 0, fmt.Errorf("invalid money %q", raw)
+	}
+	if !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, fmt.Errorf("invalid money %q", raw)
+	}
+
+	// 超过两位的小数必须为 0，例如 "12.300"
+	if len(fracPart) > 2 {
+		if strings.TrimRight(fracPart[2:], "0") != "" {
+			return 0, fmt.Errorf("invalid money %q: more than 2 decimal places", raw)
+		}
+		fracPart = fracPart[:2]
+	}
+	for len(fracPart) < 2 {
+		fracPart += "0"
+	}
+
+	var yuan uint64
+	if intPart != "" {
+		v, err := strconv.ParseUint(intPart, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid money %q: %w", raw, err)
+		}
+		yuan = v
+	}
+	fen, _ := strconv.ParseUint(fracPart, 10, 64)
+
+	// 负数的绝对值最大为 1<<63，与 String 的格式化范围一致，math.MinInt64 可以原样解析
+	limit := uint64(1<<63 - 1)
+	if negative {
+		limit++
+	}
+	if yuan > (limit-fen)/100 {
+		return 0, fmt.Errorf("invalid money %q: out of range", raw)
+	}
+	total := yuan*100 + fen
+	if negative {
+		total = -total
+	}
+	return Money(total), nil
+}
+
+// FromYuanString 严格解析以元为单位的金额字符串，适用于校验用户输入、表单和配置中的金额
+//
+// 与 ParseMoney 相比不接受首尾空白、正号、多余的前导零、
+// 省略整数或小数部分（".5"、"12."）以及超过两位的小数（包括 "12.300"）
+//
+// 参数:
+//   - s: 金额字符串，例如 "12"、"12.3"、"12.30"、"-0.01"
+//
+// 返回:
+//   - Money: 金额
+//   - error: 格式不符合上述要求时返回错误
+//
+// 示例:
+//
+//	amount, err := haozpay.FromYuanString(r.FormValue("amount"))
+//	if err != nil {
+//	    http.Error(w, "金额格式不正确", http.StatusBadRequest)
+//	    return
+//	}
+func FromYuanString(s string) (Money, error) {
+	digits := strings.TrimPrefix(s, "-")
+	intPart, fracPart, hasDot := strings.Cut(digits, ".")
+	switch {
+	case intPart == "" || !isDigits(intPart) || !isDigits(fracPart):
+		return 0, fmt.Errorf("invalid money %q", s)
+	case len(intPart) > 1 && intPart[0] == '0':
+		return 0, fmt.Errorf("invalid money %q: leading zeros", s)
+	case hasDot && fracPart == "":
+		return 0, fmt.Errorf("invalid money %q: missing decimal places", s)
+	case len(fracPart) > 2:
+		return 0, fmt.Errorf("invalid money %q: more than 2 decimal places", s)
+	}
+	return ParseMoney(s)
+}
+
+// MustParseMoney 解析金额字符串，格式错误时 panic
+// 适用于常量金额的初始化
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Fen 返回以分为单位的金额
+func (m Money) Fen() int64 {
+	return int64(m)
+}
+
+// ToFen 返回以分为单位的金额，与 Fen 相同
+func (m Money) ToFen() int64 {
+	return int64(m)
+}
+
+// FormatYuan 返回以元为单位、保留两位小数并按千分位分组的金额字符串，用于页面和报表展示，例如 "1,234,567.80"
+// 报文和签名使用 String 返回的不分组格式
+func (m Money) FormatYuan() string {
+	s := m.String()
+	sign := ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(s, ".")
+
+	var b strings.Builder
+	b.Grow(len(sign) + len(s) + len(intPart)/3)
+	b.WriteString(sign)
+	for i := 0; i < len(intPart); i++ {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte(intPart[i])
+	}
+	b.WriteByte('.')
+	b.WriteString(fracPart)
+	return b.String()
+}
+
+// String 返回以元为单位、保留两位小数的金额字符串，例如 "12.30"
+func (m Money) String() string {
+	// 使用 uint64 计算绝对值，取负不会在 math.MinInt64 上溢出
+	v := uint64(m)
+	sign := ""
+	if m < 0 {
+		sign = "-"
+		v = -v
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, v/100, v%100)
+}
+
+// MarshalJSON 实现 json.Marshaler 接口，序列化为保留两位小数的十进制数
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口，支持数字和字符串两种形式
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+		if len(bytes.TrimSpace(data)) == 0 {
+			*m = 0
+			return nil
+		}
+	}
+
+	v, err := ParseMoney(string(data))
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// isDigits 判断字符串是否只包含十进制数字（空字符串返回 true）
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,121 @@
+package haozpay
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestFromYuanString(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Money
+		wantErr bool
+	}{
+		{in: "12", want: Fen(1200)},
+		{in: "12.3", want: Fen(1230)},
+		{in: "12.30", want: Fen(1230)},
+		{in: "0.01", want: Fen(1)},
+		{in: "-0.01", want: Fen(-1)},
+		{in: "0", want: Fen(0)},
+		{in: "92233720368547758.07", want: Fen(1<<63 - 1)},
+		{in: "12.301", wantErr: true},
+		{in: "12.300", wantErr: true},
+		{in: " 1", wantErr: true},
+		{in: "1 ", wantErr: true},
+		{in: "1e2", wantErr: true},
+		{in: "+1", wantErr: true},
+		{in: "01", wantErr: true},
+		{in: ".5", wantErr: true},
+		{in: "12.", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "1,000.00", wantErr: true},
+		{in: "92233720368547758.08", wantErr: true},
+		{in: "100000000000000000000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := FromYuanString(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("FromYuanString(%q) = %s, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FromYuanString(%q) error = %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("FromYuanString(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyFormatYuan(t *testing.T) {
+	tests := []struct {
+		in   Money
+		want string
+	}{
+		{in: Fen(0), want: "0.00"},
+		{in: Fen(5), want: "0.05"},
+		{in: Fen(123456780), want: "1,234,567.80"},
+		{in: Fen(-100000), want: "-1,000.00"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.FormatYuan(); got != tt.want {
+			t.Errorf("Money(%d).FormatYuan() = %s, want %s", int64(tt.in), got, tt.want)
+		}
+	}
+}
+
+func TestMoneyStringInt64Range(t *testing.T) {
+	tests := []struct {
+		in   Money
+		want string
+	}{
+		{in: Fen(0), want: "0.00"},
+		{in: Fen(-1), want: "-0.01"},
+		{in: Fen(1230), want: "12.30"},
+		{in: Fen(math.MaxInt64), want: "92233720368547758.07"},
+		{in: Fen(math.MinInt64), want: "-92233720368547758.08"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.in.String(); got != tt.want {
+				t.Fatalf("Money(%d).String() = %s, want %s", int64(tt.in), got, tt.want)
+			}
+
+			data, err := json.Marshal(struct {
+				Amount Money `json:"amount"`
+			}{tt.in})
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			var decoded struct {
+				Amount Money `json:"amount"`
+			}
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("json.Unmarshal(%s) error = %v", data, err)
+			}
+			if decoded.Amount != tt.in {
+				t.Errorf("round trip of %s = %d, want %d", data, int64(decoded.Amount), int64(tt.in))
+			}
+		})
+	}
+}
+
+func TestParseMoneyRange(t *testing.T) {
+	if _, err := ParseMoney("-92233720368547758.09"); err == nil {
+		t.Error("ParseMoney() accepted a value below math.MinInt64")
+	}
+	if _, err := ParseMoney("92233720368547758.08"); err == nil {
+		t.Error("ParseMoney() accepted a value above math.MaxInt64")
+	}
+	if got, err := ParseMoney("0.1"); err != nil || got+MustParseMoney("0.2") != Fen(30) {
+		t.Errorf("ParseMoney(0.1) + ParseMoney(0.2) = %s, %v", got+MustParseMoney("0.2"), err)
+	}
+}
@@ -0,0 +1,50 @@
+package haozpay
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"time"
+)
+
+// clientCertificateField 客户端证书相关错误的配置项名称
+const clientCertificateField = "ClientCertificate"
+
+// loadClientCertificate 加载 mTLS 客户端证书，未配置时返回 nil
+// 证书链中的每张证书都需能够解析，且证书需在有效期内，私钥需与证书匹配
+func (c *Config) loadClientCertificate() (*tls.Certificate, error) {
+	var (
+		cert tls.Certificate
+		err  error
+	)
+	switch {
+	case c.ClientCertPEM != "":
+		cert, err = tls.X509KeyPair([]byte(c.ClientCertPEM), []byte(c.ClientKeyPEM))
+	case c.ClientCertFile != "":
+		cert, err = tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, &ConfigError{Field: clientCertificateField, Message: fmt.Sprintf("failed to load client certificate: %v", err)}
+	}
+
+	chain := make([]*x509.Certificate, 0, len(cert.Certificate))
+	for i, der := range cert.Certificate {
+		parsed, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, &ConfigError{Field: clientCertificateField, Message: fmt.Sprintf("failed to parse certificate %d in chain: %v", i, err)}
+		}
+		chain = append(chain, parsed)
+	}
+	leaf := chain[0]
+	now := time.Now()
+	if now.Before(leaf.NotBefore) {
+		return nil, &ConfigError{Field: clientCertificateField, Message: fmt.Sprintf("client certificate %s is not valid until %s", leaf.Subject, leaf.NotBefore.Format(time.RFC3339))}
+	}
+	if now.After(leaf.NotAfter) {
+		return nil, &ConfigError{Field: clientCertificateField, Message: fmt.Sprintf("client certificate %s expired at %s", leaf.Subject, leaf.NotAfter.Format(time.RFC3339))}
+	}
+	cert.Leaf = leaf
+	return &cert, nil
+}
@@ -0,0 +1,23 @@
+module github.com/haoz-cloud/haozpay-sdk/nats
+
+go 1.23.0
+
+require (
+	github.com/haoz-cloud/haozpay-sdk v1.0.0
+	github.com/nats-io/nats.go v1.41.0
+)
+
+require (
+	github.com/emmansun/gmsm v0.30.1 // indirect
+	github.com/go-resty/resty/v2 v2.16.5 // indirect
+	github.com/klauspost/compress v1.18.0 // indirect
+	github.com/nats-io/nkeys v0.4.9 // indirect
+	github.com/nats-io/nuid v1.0.1 // indirect
+	golang.org/x/crypto v0.37.0 // indirect
+	golang.org/x/net v0.33.0 // indirect
+	golang.org/x/sys v0.32.0 // indirect
+	golang.org/x/time v0.6.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/haoz-cloud/haozpay-sdk => ../
@@ -0,0 +1,103 @@
+// Package haozpaynats 提供将皓臻支付回调通知转发到 NATS 的 notify.Publisher 实现
+//
+// 消息体为 JSON 序列化的 notify.Message，主题为 {subjectPrefix}.{通知类型}.{Message.Key}，
+// 例如 haozpay.notify.PAYMENT.P202401010001。同一连接发布的消息按发布顺序投递，
+// 需要多个消费者并行消费时，可在服务端按最后一个主题片段做分区映射（partition 函数），
+// 同一订单的通知进入同一分区，从而保证同一订单的通知有序
+//
+// 示例:
+//
+//	nc, err := nats.Connect(nats.DefaultURL)
+//	if err != nil {
+//	    return err
+//	}
+//	defer nc.Close()
+//	dispatcher := notify.NewDispatcher(config)
+//	dispatcher.SetPublisher(haozpaynats.NewPublisher(nc, ""))
+package haozpaynats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/haoz-cloud/haozpay-sdk/notify"
+)
+
+const (
+	// DefaultSubjectPrefix 默认的主题前缀
+	DefaultSubjectPrefix = "haozpay.notify"
+	// HeaderType 消息头中通知类型的键
+	HeaderType = "Haozpay-Type"
+	// HeaderKey 消息头中消息分区键的键
+	HeaderKey = "Haozpay-Key"
+)
+
+// emptyKeyToken 通知没有分区键时使用的主题片段
+const emptyKeyToken = "_"
+
+// Publisher 基于 NATS 的 notify.Publisher 实现
+type Publisher struct {
+	conn          *nats.Conn
+	subjectPrefix string
+}
+
+// NewPublisher 创建基于 NATS 的通知转发器
+//
+// 参数:
+//   - conn: NATS 连接
+//   - subjectPrefix: 主题前缀，为空时使用 DefaultSubjectPrefix
+//
+// 返回:
+//   - *Publisher: 通知转发器
+//
+// 注意:
+//   - 通知带有去重标识时设置 Nats-Msg-Id 消息头，写入 JetStream 流时在去重窗口内重复发布的通知只保留一条
+//   - 每次发布后等待服务端确认收到（Flush），连接断开或超时时返回错误，以便平台重新推送
+func NewPublisher(conn *nats.Conn, subjectPrefix string) *Publisher {
+	if subjectPrefix == "" {
+		subjectPrefix = DefaultSubjectPrefix
+	}
+	return &Publisher{conn: conn, subjectPrefix: subjectPrefix}
+}
+
+// Publish 实现 notify.Publisher 接口
+func (p *Publisher) Publish(ctx context.Context, msg *notify.Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	m := nats.NewMsg(p.Subject(msg))
+	m.Data = data
+	m.Header.Set(HeaderType, string(msg.Type))
+	m.Header.Set(HeaderKey, msg.Key)
+	if msg.ID != "" {
+		m.Header.Set(nats.MsgIdHdr, msg.ID)
+	}
+	if err := p.conn.PublishMsg(m); err != nil {
+		return err
+	}
+	return p.conn.FlushWithContext(ctx)
+}
+
+// Subject 返回通知发布的主题
+// 分区键中主题不允许的字符（空白、'.'、'*'、'>'）替换为 '_'，分区键为空时最后一个片段为 "_"
+func (p *Publisher) Subject(msg *notify.Message) string {
+	key := strings.Map(func(r rune) rune {
+		switch r {
+		case '.', '*', '>', ' ', '\t', '\r', '\n':
+			return '_'
+		}
+		return r
+	}, msg.Key)
+	if key == "" {
+		key = emptyKeyToken
+	}
+	return p.subjectPrefix + "." + string(msg.Type) + "." + key
+}
+
+var _ notify.Publisher = (*Publisher)(nil)
@@ -0,0 +1,22 @@
+module github.com/haoz-cloud/haozpay-sdk/newrelic
+
+go 1.23.0
+
+require github.com/haoz-cloud/haozpay-sdk v1.0.0
+
+require (
+	github.com/emmansun/gmsm v0.30.1 // indirect
+	github.com/go-resty/resty/v2 v2.16.5 // indirect
+	github.com/newrelic/go-agent/v3 v3.40.1
+	golang.org/x/crypto v0.37.0 // indirect
+	golang.org/x/net v0.33.0 // indirect
+	golang.org/x/sys v0.32.0 // indirect
+	golang.org/x/text v0.24.0 // indirect
+	golang.org/x/time v0.6.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240528184218-531527333157 // indirect
+	google.golang.org/grpc v1.65.0 // indirect
+	google.golang.org/protobuf v1.34.2 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/haoz-cloud/haozpay-sdk => ../
@@ -0,0 +1,97 @@
+// Package haozpaynewrelic 将皓臻支付 SDK 的接口调用记录为 New Relic 外部调用 segment
+//
+// 通过客户端的 OnBeforeCall、OnAfterCall 钩子在每次接口调用前后创建和结束 segment，
+// segment 属于调用传入的 context 中的 New Relic 事务（newrelic.NewContext），context 中没有事务时不记录；
+// 通过 OnRequestHeader 钩子将事务的分布式追踪信息（包括 W3C traceparent、tracestate）发送到平台
+//
+// 示例:
+//
+//	client, err := haozpay.NewClient(config)
+//	if err != nil {
+//	    return err
+//	}
+//	haozpaynewrelic.Instrument(client)
+//
+//	txn := app.StartTransaction("refund")
+//	defer txn.End()
+//	resp, err := client.Payment.CreateRefund(newrelic.NewContext(ctx, txn), req)
+package haozpaynewrelic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/newrelic/go-agent/v3/newrelic"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+const (
+	// Library segment 的外部调用库名
+	Library = "haozpay"
+
+	// AttributeRequestID 客户端请求ID的 segment 属性
+	AttributeRequestID = "haozpay.requestId"
+	// AttributeGatewayRequestID 平台返回的请求ID的 segment 属性，收到平台响应时设置
+	AttributeGatewayRequestID = "haozpay.gatewayRequestId"
+	// AttributeCode 错误码的 segment 属性，调用失败时设置
+	AttributeCode = "haozpay.code"
+)
+
+// Instrument 为客户端注册创建 New Relic segment 的调用钩子
+// segment 的 URL 为客户端当前使用的网关地址加接口路径，调用失败时同时记录到事务的错误
+// 由 Clone 派生的客户端继承钩子，无需重复注册
+//
+// 参数:
+//   - client: 皓臻支付客户端
+func Instrument(client *haozpay.Client) {
+	// 调用前后的钩子收到同一个 context，按 context 关联 segment
+	var segments sync.Map
+
+	client.OnBeforeCall(func(ctx context.Context, op string, req interface{}) {
+		txn := newrelic.FromContext(ctx)
+		if txn == nil {
+			return
+		}
+		segment := &newrelic.ExternalSegment{
+			StartTime: txn.StartSegmentNow(),
+			URL:       client.ActiveEndpoint() + op,
+			Procedure: op,
+			Library:   Library,
+		}
+		segment.AddAttribute(AttributeRequestID, haozpay.RequestIDFromContext(ctx))
+		segments.Store(ctx, segment)
+	})
+
+	client.OnRequestHeader(func(ctx context.Context, op string, header http.Header) {
+		if txn := newrelic.FromContext(ctx); txn != nil {
+			txn.InsertDistributedTraceHeaders(header)
+		}
+	})
+
+	client.OnAfterCall(func(ctx context.Context, op string, resp interface{}, err error) {
+		value, ok := segments.LoadAndDelete(ctx)
+		if !ok {
+			return
+		}
+		segment := value.(*newrelic.ExternalSegment)
+
+		if gatewayRequestID := haozpay.GatewayRequestIDFromContext(ctx); gatewayRequestID != "" {
+			segment.AddAttribute(AttributeGatewayRequestID, gatewayRequestID)
+		}
+		var sdkErr *haozpay.SDKError
+		if errors.As(err, &sdkErr) {
+			segment.AddAttribute(AttributeCode, sdkErr.Code)
+			if sdkErr.StatusCode != 0 {
+				segment.SetStatusCode(sdkErr.StatusCode)
+			}
+		}
+		segment.End()
+
+		if err != nil {
+			newrelic.FromContext(ctx).NoticeError(err)
+		}
+	})
+}
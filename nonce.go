@@ -0,0 +1,149 @@
+package haozpay
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultNonceStoreCapacity MemoryNonceStore 默认保留的记录数
+const DefaultNonceStoreCapacity = 10000
+
+// NonceStore 回调通知防重放记录
+// NotificationVerifier 在验签和时间戳校验通过后记录通知，已记录过的通知视为重放
+// 多实例部署时应使用 Redis 等共享存储实现（例如 haozpayredis.NewNonceStore），单实例可使用 MemoryNonceStore
+type NonceStore interface {
+	// Add 记录通知标识，记录保留 ttl 时间
+	// 标识未被记录过时返回 true；已记录且未过期时返回 false
+	Add(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+	// Remove 删除通知标识的记录
+	Remove(ctx context.Context, nonce string) error
+}
+
+// MemoryNonceStore 基于内存的 NonceStore 实现
+// 记录数超过容量时淘汰最早的记录（LRU），过期记录在访问时清理
+// 通过 NewMemoryNonceStore 函数创建实例，可在多个 goroutine 中并发使用
+type MemoryNonceStore struct {
+	set *expiringSet
+}
+
+// NewMemoryNonceStore 创建基于内存的 NonceStore
+//
+// 参数:
+//   - capacity: 最多保留的记录数，小于等于 0 时使用 DefaultNonceStoreCapacity
+//     容量应大于时间戳偏差范围内可能收到的通知数，否则较早的记录会被提前淘汰
+//
+// 返回:
+//   - *MemoryNonceStore: 内存防重放记录
+func NewMemoryNonceStore(capacity int) *MemoryNonceStore {
+	if capacity <= 0 {
+		capacity = DefaultNonceStoreCapacity
+	}
+	return &MemoryNonceStore{set: newExpiringSet(capacity)}
+}
+
+// Add 实现 NonceStore 接口
+func (s *MemoryNonceStore) Add(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	return s.set.add(nonce, ttl), nil
+}
+
+// Remove 实现 NonceStore 接口
+func (s *MemoryNonceStore) Remove(ctx context.Context, nonce string) error {
+	s.set.remove(nonce)
+	return nil
+}
+
+// Len 返回当前保留的记录数
+func (s *MemoryNonceStore) Len() int {
+	return s.set.len()
+}
+
+// expiringSet 带过期时间和容量限制的字符串集合
+// 记录数超过容量时淘汰最早加入的记录（LRU），过期记录在访问时清理
+type expiringSet struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+// expiringEntry 集合中的记录
+type expiringEntry struct {
+	key       string
+	expiresAt time.Time
+}
+
+// newExpiringSet 创建集合
+func newExpiringSet(capacity int) *expiringSet {
+	return &expiringSet{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// add 加入记录，记录保留 ttl 时间
+// 记录不存在或已过期时返回 true，已存在且未过期时返回 false
+func (s *expiringSet) add(key string, ttl time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if elem, ok := s.entries[key]; ok {
+		if now.Before(elem.Value.(*expiringEntry).expiresAt) {
+			return false
+		}
+		s.removeElement(elem)
+	}
+
+	s.entries[key] = s.order.PushFront(&expiringEntry{key: key, expiresAt: now.Add(ttl)})
+
+	// 淘汰超出容量的记录和已过期的最早记录
+	for back := s.order.Back(); back != nil; back = s.order.Back() {
+		if s.order.Len() <= s.capacity && now.Before(back.Value.(*expiringEntry).expiresAt) {
+			break
+		}
+		s.removeElement(back)
+	}
+	return true
+}
+
+// contains 判断记录是否存在且未过期
+func (s *expiringSet) contains(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[key]
+	if !ok {
+		return false
+	}
+	if time.Now().Before(elem.Value.(*expiringEntry).expiresAt) {
+		return true
+	}
+	s.removeElement(elem)
+	return false
+}
+
+// remove 删除记录
+func (s *expiringSet) remove(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[key]; ok {
+		s.removeElement(elem)
+	}
+}
+
+// len 返回当前保留的记录数
+func (s *expiringSet) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+// removeElement 删除记录，调用方需持有锁
+func (s *expiringSet) removeElement(elem *list.Element) {
+	s.order.Remove(elem)
+	delete(s.entries, elem.Value.(*expiringEntry).key)
+}
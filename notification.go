@@ -0,0 +1,391 @@
+package haozpay
+
+import (
+	"context"
+	"time"
+)
+
+// NotificationTimestampTolerance 回调通知时间戳允许的最大偏差
+// 通知中的 timestamp 与本地时间相差超过该值时视为过期通知，可通过 Config.NotifyTimestampTolerance 调整
+const NotificationTimestampTolerance = 5 * time.Minute
+
+// PaymentNotification 支付结果回调通知
+// 由皓臻支付平台在订单支付完成后推送至下单时指定的 notifyUrl
+type PaymentNotification struct {
+	// MerchantNo 商户编号
+	MerchantNo string `json:"merchantNo"`
+	// OrderNo 平台订单号
+	OrderNo string `json:"orderNo"`
+	// MerchantOrderNo 商户订单号
+	MerchantOrderNo string `json:"merchantOrderNo"`
+	// SeqId 平台交易流水号
+	SeqId string `json:"seqId"`
+	// ChannelType 支付渠道类型
+	ChannelType string `json:"channelType"`
+	// ChannelTransId 渠道交易流水号
+	ChannelTransId string `json:"channelTransId"`
+	// PayType 支付方式
+	PayType PayType `json:"payType"`
+	// OrderTitle 订单标题
+	OrderTitle string `json:"orderTitle"`
+	// OrderAmount 订单金额
+	OrderAmount Money `json:"orderAmount"`
+	// Currency 币种，未返回时为 CurrencyCNY
+	Currency Currency `json:"currency"`
+	// PaidAmount 实付金额
+	PaidAmount Money `json:"paidAmount"`
+	// OrderStatus 订单状态
+	OrderStatus OrderStatus `json:"orderStatus"`
+	// FinishTime 支付完成时间
+	FinishTime HaozTime `json:"finishTime"`
+	// Timestamp 通知发送时间戳(毫秒)，取自通知报文外层
+	Timestamp int64 `json:"-"`
+}
+
+// ParsePaymentNotification 解析并验证支付结果回调通知
+// 不进行防重放校验，需要拒绝重复通知时使用 NewNotificationVerifier 创建的验证器
+// 只接受平台公钥类型的默认签名算法（RSA 公钥为 SignTypeRSA2，SM2 公钥为 SignTypeSM2），报文声明了其他 signType 时验签失败；
+// 商户登记了其他算法（例如 SignTypeRSA）时使用 NewNotificationVerifier(cfg) 创建的验证器，按 cfg.SignType 验签
+//
+// 处理流程:
+//  1. 解析通知报文(merchantNo、timestamp、bizBody、sign)
+//  2. 使用平台公钥按公钥类型的默认算法验证签名
+//  3. 校验通知时间戳是否在允许的偏差范围内
+//  4. 将 bizBody 解析为 PaymentNotification
+//
+// 参数:
+//   - body: 回调请求的原始报文
+//   - platformPublicKey: 平台公钥(PEM格式或纯Base64格式)
+//
+// 返回:
+//   - *PaymentNotification: 验证通过的支付通知
+//   - error: 报文格式错误、验签失败或通知过期时返回错误，验签失败的错误包装 ErrSignatureInvalid
+//
+// 示例:
+//
+//	body, _ := io.ReadAll(r.Body)
+//	notification, err := haozpay.ParsePaymentNotification(body, platformPublicKeyPEM)
+//	if err != nil {
+//	    // 验证失败，不要处理该通知
+//	    return
+//	}
+//	fmt.Println("订单支付完成:", notification.OrderNo)
+func ParsePaymentNotification(body []byte, platformPublicKey string) (*PaymentNotification, error) {
+	return newNotificationVerifier(platformPublicKey, "", 0, nil).ParsePaymentNotification(context.Background(), body)
+}
+
+// applyEnvelope 使用通知报文外层补充商户号和时间戳，未返回币种时使用 CurrencyCNY
+func (n *PaymentNotification) applyEnvelope(envelope *HaozPayRequest) {
+	if n.MerchantNo == "" {
+		n.MerchantNo = envelope.MerchantNo
+	}
+	n.Currency = n.Currency.OrDefault()
+	n.Timestamp = envelope.Timestamp
+}
+
+// DeductNotification 代扣扣款结果回调通知
+// 由皓臻支付平台在 CreateDeductOrder 发起的扣款完成后推送至扣款时指定的 notifyUrl
+type DeductNotification struct {
+	// MerchantNo 商户编号
+	MerchantNo string `json:"merchantNo"`
+	// OrderNo 平台订单号
+	OrderNo string `json:"orderNo"`
+	// MerchantOrderNo 商户订单号
+	MerchantOrderNo string `json:"merchantOrderNo"`
+	// SeqId 平台交易流水号
+	SeqId string `json:"seqId"`
+	// ContractId 平台协议号
+	ContractId string `json:"contractId"`
+	// ContractCode 商户协议号
+	ContractCode string `json:"contractCode"`
+	// ChannelTransId 渠道交易流水号
+	ChannelTransId string `json:"channelTransId"`
+	// PayType 支付方式
+	PayType PayType `json:"payType"`
+	// OrderAmount 扣款金额
+	OrderAmount Money `json:"orderAmount"`
+	// PaidAmount 实际扣款金额
+	PaidAmount Money `json:"paidAmount"`
+	// OrderStatus 订单状态
+	OrderStatus OrderStatus `json:"orderStatus"`
+	// FailReason 扣款失败原因（例如余额不足）
+	FailReason string `json:"failReason"`
+	// FinishTime 扣款完成时间
+	FinishTime HaozTime `json:"finishTime"`
+	// Timestamp 通知发送时间戳(毫秒)，取自通知报文外层
+	Timestamp int64 `json:"-"`
+}
+
+// ParseDeductNotification 解析并验证代扣扣款结果回调通知
+// 验签和时间戳校验规则与 ParsePaymentNotification 一致，只接受平台公钥类型的默认签名算法
+//
+// 参数:
+//   - body: 回调请求的原始报文
+//   - platformPublicKey: 平台公钥(PEM格式或纯Base64格式)
+//
+// 返回:
+//   - *DeductNotification: 验证通过的扣款通知
+//   - error: 报文格式错误、验签失败或通知过期时返回错误，验签失败的错误包装 ErrSignatureInvalid
+func ParseDeductNotification(body []byte, platformPublicKey string) (*DeductNotification, error) {
+	return newNotificationVerifier(platformPublicKey, "", 0, nil).ParseDeductNotification(context.Background(), body)
+}
+
+// applyEnvelope 使用通知报文外层补充商户号和时间戳
+func (n *DeductNotification) applyEnvelope(envelope *HaozPayRequest) {
+	if n.MerchantNo == "" {
+		n.MerchantNo = envelope.MerchantNo
+	}
+	n.Timestamp = envelope.Timestamp
+}
+
+// ContractNotification 代扣协议签约、解约结果回调通知
+// 由皓臻支付平台在用户完成签约或协议解约后推送至签约时指定的 notifyUrl
+type ContractNotification struct {
+	// MerchantNo 商户编号
+	MerchantNo string `json:"merchantNo"`
+	// ContractId 平台协议号
+	ContractId string `json:"contractId"`
+	// ContractCode 商户协议号
+	ContractCode string `json:"contractCode"`
+	// PlanId 代扣模板 ID
+	PlanId string `json:"planId"`
+	// PayType 支付方式
+	PayType PayType `json:"payType"`
+	// ContractStatus 协议状态
+	ContractStatus ContractStatus `json:"contractStatus"`
+	// SignTime 签约时间
+	SignTime string `json:"signTime"`
+	// TerminateTime 解约时间
+	TerminateTime string `json:"terminateTime"`
+	// TerminateReason 解约原因
+	TerminateReason string `json:"terminateReason"`
+	// Timestamp 通知发送时间戳(毫秒)，取自通知报文外层
+	Timestamp int64 `json:"-"`
+}
+
+// ParseContractNotification 解析并验证代扣协议签约、解约结果回调通知
+// 验签和时间戳校验规则与 ParsePaymentNotification 一致，只接受平台公钥类型的默认签名算法
+//
+// 参数:
+//   - body: 回调请求的原始报文
+//   - platformPublicKey: 平台公钥(PEM格式或纯Base64格式)
+//
+// 返回:
+//   - *ContractNotification: 验证通过的协议通知
+//   - error: 报文格式错误、验签失败或通知过期时返回错误，验签失败的错误包装 ErrSignatureInvalid
+func ParseContractNotification(body []byte, platformPublicKey string) (*ContractNotification, error) {
+	return newNotificationVerifier(platformPublicKey, "", 0, nil).ParseContractNotification(context.Background(), body)
+}
+
+// applyEnvelope 使用通知报文外层补充商户号和时间戳
+func (n *ContractNotification) applyEnvelope(envelope *HaozPayRequest) {
+	if n.MerchantNo == "" {
+		n.MerchantNo = envelope.MerchantNo
+	}
+	n.Timestamp = envelope.Timestamp
+}
+
+// RefundNotification 退款结果回调通知
+// 由皓臻支付平台在退款处理完成后推送至退款时指定的 notifyUrl
+type RefundNotification struct {
+	// MerchantNo 商户编号
+	MerchantNo string `json:"merchantNo"`
+	// OrderNo 平台订单号
+	OrderNo string `json:"orderNo"`
+	// MerchantOrderNo 商户订单号
+	MerchantOrderNo string `json:"merchantOrderNo"`
+	// RefundSeqId 平台退款流水号
+	RefundSeqId string `json:"refundSeqId"`
+	// PaySeqId 原支付交易流水号
+	PaySeqId string `json:"paySeqId"`
+	// RefundAmount 退款金额
+	RefundAmount Money `json:"refundAmount"`
+	// Currency 币种，未返回时为 CurrencyCNY
+	Currency Currency `json:"currency"`
+	// ActualRefundAmount 实际退款金额
+	ActualRefundAmount Money `json:"actualRefundAmount"`
+	// RefundStatus 退款状态
+	RefundStatus RefundStatus `json:"refundStatus"`
+	// FailReason 退款失败原因
+	FailReason string `json:"failReason"`
+	// FinishTime 退款完成时间
+	FinishTime HaozTime `json:"finishTime"`
+	// Timestamp 通知发送时间戳(毫秒)，取自通知报文外层
+	Timestamp int64 `json:"-"`
+}
+
+// ParseRefundNotification 解析并验证退款结果回调通知
+// 验签和时间戳校验规则与 ParsePaymentNotification 一致，只接受平台公钥类型的默认签名算法
+//
+// 参数:
+//   - body: 回调请求的原始报文
+//   - platformPublicKey: 平台公钥(PEM格式或纯Base64格式)
+//
+// 返回:
+//   - *RefundNotification: 验证通过的退款通知
+//   - error: 报文格式错误、验签失败或通知过期时返回错误，验签失败的错误包装 ErrSignatureInvalid
+func ParseRefundNotification(body []byte, platformPublicKey string) (*RefundNotification, error) {
+	return newNotificationVerifier(platformPublicKey, "", 0, nil).ParseRefundNotification(context.Background(), body)
+}
+
+// applyEnvelope 使用通知报文外层补充商户号和时间戳，未返回币种时使用 CurrencyCNY
+func (n *RefundNotification) applyEnvelope(envelope *HaozPayRequest) {
+	if n.MerchantNo == "" {
+		n.MerchantNo = envelope.MerchantNo
+	}
+	n.Currency = n.Currency.OrDefault()
+	n.Timestamp = envelope.Timestamp
+}
+
+// WithdrawNotification 提现结果回调通知
+// 由皓臻支付平台在提现处理完成后推送至提现时指定的 notifyUrl
+type WithdrawNotification struct {
+	// MerchantNo 商户编号
+	MerchantNo string `json:"merchantNo"`
+	// ReqSeqId 商户提现请求流水号
+	ReqSeqId string `json:"reqSeqId"`
+	// SeqId 平台提现流水号
+	SeqId string `json:"seqId"`
+	// PayChannel 提现渠道
+	PayChannel string `json:"payChannel"`
+	// WithdrawAmount 提现金额
+	WithdrawAmount Money `json:"withdrawAmount"`
+	// FeeAmount 手续费
+	FeeAmount Money `json:"feeAmount"`
+	// ArrivalAmount 到账金额
+	ArrivalAmount Money `json:"arrivalAmount"`
+	// WithdrawStatus 提现状态
+	WithdrawStatus WithdrawStatus `json:"withdrawStatus"`
+	// ArrivalTime 到账时间
+	ArrivalTime string `json:"arrivalTime"`
+	// FailReason 提现失败原因
+	FailReason string `json:"failReason"`
+	// Timestamp 通知发送时间戳(毫秒)，取自通知报文外层
+	Timestamp int64 `json:"-"`
+}
+
+// ParseWithdrawNotification 解析并验证提现结果回调通知
+// 验签和时间戳校验规则与 ParsePaymentNotification 一致，只接受平台公钥类型的默认签名算法
+//
+// 参数:
+//   - body: 回调请求的原始报文
+//   - platformPublicKey: 平台公钥(PEM格式或纯Base64格式)
+//
+// 返回:
+//   - *WithdrawNotification: 验证通过的提现通知
+//   - error: 报文格式错误、验签失败或通知过期时返回错误，验签失败的错误包装 ErrSignatureInvalid
+func ParseWithdrawNotification(body []byte, platformPublicKey string) (*WithdrawNotification, error) {
+	return newNotificationVerifier(platformPublicKey, "", 0, nil).ParseWithdrawNotification(context.Background(), body)
+}
+
+// applyEnvelope 使用通知报文外层补充商户号和时间戳
+func (n *WithdrawNotification) applyEnvelope(envelope *HaozPayRequest) {
+	if n.MerchantNo == "" {
+		n.MerchantNo = envelope.MerchantNo
+	}
+	n.Timestamp = envelope.Timestamp
+}
+
+// TransferNotification 转账（代付）结果回调通知
+// 由皓臻支付平台在转账处理完成后推送至转账时指定的 notifyUrl，批量转账的每笔明细单独通知
+type TransferNotification struct {
+	// MerchantNo 商户编号
+	MerchantNo string `json:"merchantNo"`
+	// ReqSeqId 商户转账请求流水号
+	ReqSeqId string `json:"reqSeqId"`
+	// TransferNo 平台转账单号
+	TransferNo string `json:"transferNo"`
+	// BatchNo 平台批次号，仅批量转账的明细返回
+	BatchNo string `json:"batchNo"`
+	// TransferAmount 转账金额
+	TransferAmount Money `json:"transferAmount"`
+	// FeeAmount 手续费
+	FeeAmount Money `json:"feeAmount"`
+	// TransferStatus 转账状态
+	TransferStatus TransferStatus `json:"transferStatus"`
+	// ChannelTransId 渠道交易号
+	ChannelTransId string `json:"channelTransId"`
+	// FinishTime 转账完成时间
+	FinishTime HaozTime `json:"finishTime"`
+	// FailReason 转账失败原因
+	FailReason string `json:"failReason"`
+	// Timestamp 通知发送时间戳(毫秒)，取自通知报文外层
+	Timestamp int64 `json:"-"`
+}
+
+// ParseTransferNotification 解析并验证转账结果回调通知
+// 验签和时间戳校验规则与 ParsePaymentNotification 一致，只接受平台公钥类型的默认签名算法
+//
+// 参数:
+//   - body: 回调请求的原始报文
+//   - platformPublicKey: 平台公钥(PEM格式或纯Base64格式)
+//
+// 返回:
+//   - *TransferNotification: 验证通过的转账通知
+//   - error: 报文格式错误、验签失败或通知过期时返回错误，验签失败的错误包装 ErrSignatureInvalid
+func ParseTransferNotification(body []byte, platformPublicKey string) (*TransferNotification, error) {
+	return newNotificationVerifier(platformPublicKey, "", 0, nil).ParseTransferNotification(context.Background(), body)
+}
+
+// applyEnvelope 使用通知报文外层补充商户号和时间戳
+func (n *TransferNotification) applyEnvelope(envelope *HaozPayRequest) {
+	if n.MerchantNo == "" {
+		n.MerchantNo = envelope.MerchantNo
+	}
+	n.Timestamp = envelope.Timestamp
+}
+
+// InvoiceNotification 电子发票开具、红冲结果回调通知
+// 由皓臻支付平台在发票开具或红冲处理完成后推送至申请时指定的 notifyUrl
+type InvoiceNotification struct {
+	// MerchantNo 商户编号
+	MerchantNo string `json:"merchantNo"`
+	// InvoiceNo 平台发票申请单号
+	InvoiceNo string `json:"invoiceNo"`
+	// OutInvoiceNo 商户发票申请单号
+	OutInvoiceNo string `json:"outInvoiceNo"`
+	// OrderNo 平台订单号
+	OrderNo string `json:"orderNo"`
+	// InvoiceStatus 发票状态
+	InvoiceStatus InvoiceStatus `json:"invoiceStatus"`
+	// InvoiceCode 发票代码
+	InvoiceCode string `json:"invoiceCode"`
+	// InvoiceNumber 发票号码
+	InvoiceNumber string `json:"invoiceNumber"`
+	// Amount 开票金额（价税合计）
+	Amount Money `json:"amount"`
+	// TaxAmount 税额
+	TaxAmount Money `json:"taxAmount"`
+	// PdfUrl 发票 PDF 下载地址
+	PdfUrl string `json:"pdfUrl"`
+	// IssueTime 开具时间
+	IssueTime string `json:"issueTime"`
+	// RedFlushTime 红冲时间
+	RedFlushTime string `json:"redFlushTime"`
+	// FailReason 开具或红冲失败原因
+	FailReason string `json:"failReason"`
+	// Timestamp 通知发送时间戳(毫秒)，取自通知报文外层
+	Timestamp int64 `json:"-"`
+}
+
+// ParseInvoiceNotification 解析并验证电子发票开具、红冲结果回调通知
+// 验签和时间戳校验规则与 ParsePaymentNotification 一致，只接受平台公钥类型的默认签名算法
+//
+// 参数:
+//   - body: 回调请求的原始报文
+//   - platformPublicKey: 平台公钥(PEM格式或纯Base64格式)
+//
+// 返回:
+//   - *InvoiceNotification: 验证通过的发票通知
+//   - error: 报文格式错误、验签失败或通知过期时返回错误，验签失败的错误包装 ErrSignatureInvalid
+func ParseInvoiceNotification(body []byte, platformPublicKey string) (*InvoiceNotification, error) {
+	return newNotificationVerifier(platformPublicKey, "", 0, nil).ParseInvoiceNotification(context.Background(), body)
+}
+
+// applyEnvelope 使用通知报文外层补充商户号和时间戳
+func (n *InvoiceNotification) applyEnvelope(envelope *HaozPayRequest) {
+	if n.MerchantNo == "" {
+		n.MerchantNo = envelope.MerchantNo
+	}
+	n.Timestamp = envelope.Timestamp
+}
@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+// AckReasonHeader 应答失败原因的响应头
+// 平台只识别应答报文本身，失败原因写入该响应头，便于在网关或访问日志中排查
+const AckReasonHeader = "X-Notify-Fail-Reason"
+
+// AckSuccess 应答回调处理成功，平台收到后停止重复推送
+// 写入 HTTP 200 和报文 SUCCESS，业务已处理成功（包括重复收到已处理的通知）时调用
+//
+// 示例:
+//
+//	if err := handle(ctx, notification); err != nil {
+//	    notify.AckRetry(w, err.Error())
+//	    return
+//	}
+//	notify.AckSuccess(w)
+func AckSuccess(w http.ResponseWriter) {
+	writeAck(w, http.StatusOK, haozpay.NotifyAckSuccess)
+}
+
+// AckRetry 应答回调处理失败，平台会按策略重新推送该通知
+// 写入 HTTP 500 和报文 FAIL，失败原因写入 AckReasonHeader 响应头
+//
+// 注意: 只有业务暂时无法处理、重新推送后可能成功时才应调用；
+// 报文格式错误或验签失败的通知重新推送也无法处理，不应要求平台重推
+//
+// 参数:
+//   - w: 回调请求的 http.ResponseWriter
+//   - reason: 失败原因，为空时不写入响应头
+func AckRetry(w http.ResponseWriter, reason string) {
+	if reason != "" {
+		// 响应头不能包含换行
+		w.Header().Set(AckReasonHeader, strings.Join(strings.Fields(reason), " "))
+	}
+	writeAck(w, http.StatusInternalServerError, haozpay.NotifyAckFail)
+}
+
+// writeAck 写入回调应答报文
+func writeAck(w http.ResponseWriter, statusCode int, ack string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_, _ = io.WriteString(w, ack)
+}
@@ -0,0 +1,455 @@
+// Package notify 提供皓臻支付回调通知的分发处理
+//
+// Dispatcher 负责读取回调请求、验证平台签名、时间戳和防重放，
+// 识别通知类型并解析为对应的通知结构体，再按类型调用注册的业务处理函数：
+//
+//	dispatcher := notify.NewDispatcher(config)
+//	dispatcher.OnPaymentSuccess(func(ctx context.Context, n *haozpay.PaymentNotification) error {
+//	    return orderService.MarkPaid(ctx, n.MerchantOrderNo, n.PaidAmount)
+//	})
+//	dispatcher.OnRefundSuccess(func(ctx context.Context, n *haozpay.RefundNotification) error {
+//	    return refundService.MarkRefunded(ctx, n.RefundSeqId, n.ActualRefundAmount)
+//	})
+//	dispatcher.OnUnknown(func(ctx context.Context, raw *notify.RawNotification) error {
+//	    log.Printf("unhandled notification: %s", raw.BizBody)
+//	    return nil
+//	})
+//	http.Handle("/haozpay/notify", dispatcher)
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+// maxNotifyBodySize 回调报文的最大长度
+const maxNotifyBodySize = 1 << 20
+
+// EventType 回调通知类型
+type EventType string
+
+const (
+	// EventPayment 支付结果通知
+	EventPayment EventType = "PAYMENT"
+	// EventRefund 退款结果通知
+	EventRefund EventType = "REFUND"
+	// EventWithdraw 提现结果通知
+	EventWithdraw EventType = "WITHDRAW"
+	// EventTransfer 转账（代付）结果通知
+	EventTransfer EventType = "TRANSFER"
+	// EventDeduct 代扣扣款结果通知
+	EventDeduct EventType = "DEDUCT"
+	// EventContract 代扣协议签约、解约结果通知
+	EventContract EventType = "CONTRACT"
+	// EventInvoice 电子发票开具、红冲结果通知
+	EventInvoice EventType = "INVOICE"
+	// EventUnknown 无法识别类型的通知
+	EventUnknown EventType = "UNKNOWN"
+)
+
+// RawNotification 验签通过的通知报文，业务数据保留原始 JSON
+// 没有对应业务处理函数的通知以该类型交给 OnUnknown，可通过 Decode 解析为自定义结构体
+type RawNotification struct {
+	// Type 识别出的通知类型，无法识别时为 EventUnknown
+	Type EventType
+	// MerchantNo 商户编号，取自通知报文外层
+	MerchantNo string
+	// Timestamp 通知发送时间戳(毫秒)，取自通知报文外层
+	Timestamp int64
+	// BizBody 通知的业务数据(JSON)
+	BizBody json.RawMessage
+}
+
+// Decode 将业务数据解析到 v
+func (n *RawNotification) Decode(v interface{}) error {
+	return json.Unmarshal(n.BizBody, v)
+}
+
+// Dispatcher 按通知类型分发回调通知的 http.Handler 实现
+// 通过 NewDispatcher 函数创建实例，注册业务处理函数后可在多个 goroutine 中并发使用
+//
+// 通知类型优先取业务数据中的 notifyType 字段，未返回时按业务数据包含的字段识别：
+//   - refundSeqId: 退款结果通知
+//   - withdrawStatus: 提现结果通知
+//   - transferStatus: 转账结果通知
+//   - invoiceStatus: 电子发票通知
+//   - contractId 且不含 orderStatus: 代扣协议通知
+//   - contractId 且含 orderStatus: 代扣扣款通知
+//   - orderStatus: 支付结果通知
+type Dispatcher struct {
+	// verifier 回调通知验证器，负责验签、时间戳和防重放校验
+	verifier *haozpay.NotificationVerifier
+	// dedup 通知处理记录，为 nil 时不去重
+	dedup haozpay.DedupStore
+
+	mu             sync.RWMutex
+	publisher      Publisher
+	paymentSuccess func(ctx context.Context, n *haozpay.PaymentNotification) error
+	refundSuccess  func(ctx context.Context, n *haozpay.RefundNotification) error
+	withdrawResult func(ctx context.Context, n *haozpay.WithdrawNotification) error
+	transferResult func(ctx context.Context, n *haozpay.TransferNotification) error
+	deductResult   func(ctx context.Context, n *haozpay.DeductNotification) error
+	contractResult func(ctx context.Context, n *haozpay.ContractNotification) error
+	invoiceResult  func(ctx context.Context, n *haozpay.InvoiceNotification) error
+	unknown        func(ctx context.Context, raw *RawNotification) error
+}
+
+// NewDispatcher 创建回调通知分发器
+//
+// 参数:
+//   - cfg: 客户端配置，需要设置平台公钥 PublicKey，防重放校验使用 NotifyNonceStore，去重使用 NotifyDedupStore
+//
+// 返回:
+//   - *Dispatcher: 可直接注册到 http.ServeMux 的回调分发器
+//
+// 应答规则与 haozpay.NotifyHandler 一致:
+//   - 验签通过且业务处理成功: HTTP 200，应答 SUCCESS
+//   - 重复收到已处理成功的通知（防重放或去重）: HTTP 200，应答 SUCCESS，不调用业务处理函数
+//   - 没有对应的业务处理函数且未注册 OnUnknown: HTTP 200，应答 SUCCESS
+//   - 报文格式错误、验签失败或通知过期: HTTP 400，应答 FAIL
+//   - 业务处理或发布通知返回错误、查询处理记录失败: HTTP 500，应答 FAIL，并撤销防重放记录以便平台重新推送
+func NewDispatcher(cfg *haozpay.Config) *Dispatcher {
+	return &Dispatcher{
+		verifier: haozpay.NewNotificationVerifier(cfg),
+		dedup:    cfg.NotifyDedupStore,
+	}
+}
+
+// OnPaymentSuccess 注册支付成功通知的处理函数
+// 仅在订单状态为支付成功（OrderStatus.IsSuccess）时调用，其他状态的支付通知交给 OnUnknown
+func (d *Dispatcher) OnPaymentSuccess(handle func(ctx context.Context, n *haozpay.PaymentNotification) error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.paymentSuccess = handle
+}
+
+// OnRefundSuccess 注册退款成功通知的处理函数
+// 仅在退款状态为退款成功时调用，其他状态的退款通知交给 OnUnknown
+func (d *Dispatcher) OnRefundSuccess(handle func(ctx context.Context, n *haozpay.RefundNotification) error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.refundSuccess = handle
+}
+
+// OnWithdrawResult 注册提现结果通知的处理函数，提现成功和失败均会调用
+func (d *Dispatcher) OnWithdrawResult(handle func(ctx context.Context, n *haozpay.WithdrawNotification) error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.withdrawResult = handle
+}
+
+// OnTransferResult 注册转账结果通知的处理函数，转账成功、失败和退票均会调用
+func (d *Dispatcher) OnTransferResult(handle func(ctx context.Context, n *haozpay.TransferNotification) error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.transferResult = handle
+}
+
+// OnDeductResult 注册代扣扣款结果通知的处理函数
+func (d *Dispatcher) OnDeductResult(handle func(ctx context.Context, n *haozpay.DeductNotification) error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deductResult = handle
+}
+
+// OnContractResult 注册代扣协议签约、解约结果通知的处理函数
+func (d *Dispatcher) OnContractResult(handle func(ctx context.Context, n *haozpay.ContractNotification) error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.contractResult = handle
+}
+
+// OnInvoiceResult 注册电子发票开具、红冲结果通知的处理函数，开具成功、失败和红冲均会调用
+func (d *Dispatcher) OnInvoiceResult(handle func(ctx context.Context, n *haozpay.InvoiceNotification) error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.invoiceResult = handle
+}
+
+// OnUnknown 注册兜底处理函数
+// 无法识别类型的通知，以及没有注册对应处理函数的通知均交给该函数处理
+func (d *Dispatcher) OnUnknown(handle func(ctx context.Context, raw *RawNotification) error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.unknown = handle
+}
+
+// ServeHTTP 实现 http.Handler 接口
+func (d *Dispatcher) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeAck(w, http.StatusMethodNotAllowed, haozpay.NotifyAckFail)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxNotifyBodySize))
+	if err != nil {
+		writeAck(w, http.StatusBadRequest, haozpay.NotifyAckFail)
+		return
+	}
+
+	err = d.Dispatch(haozpay.WithNotificationContentType(r.Context(), r.Header.Get("Content-Type")), body)
+	var handlerErr *HandlerError
+	switch {
+	case err == nil, errors.Is(err, haozpay.ErrNotificationReplayed):
+		// 通知已处理成功时平台可能未收到上次的应答，直接应答成功
+		AckSuccess(w)
+	case errors.As(err, &handlerErr):
+		AckRetry(w, handlerErr.Error())
+	default:
+		writeAck(w, http.StatusBadRequest, haozpay.NotifyAckFail)
+	}
+}
+
+// HandlerError 业务处理函数、Publisher 或通知处理记录返回的错误
+// 通知本身有效，平台应重新推送
+type HandlerError struct {
+	// Type 通知类型
+	Type EventType
+	// Err 原始错误
+	Err error
+}
+
+// Error 实现 error 接口
+func (e *HandlerError) Error() string {
+	return fmt.Sprintf("failed to handle %s notification: %v", e.Type, e.Err)
+}
+
+// Unwrap 返回原始错误
+func (e *HandlerError) Unwrap() error {
+	return e.Err
+}
+
+// Dispatch 验证回调通知并调用对应的业务处理函数，设置了 Publisher 时随后发布通知
+// 适用于不使用 net/http 接收回调的场景，应答规则参见 NewDispatcher
+//
+// 参数:
+//   - ctx: 上下文，传给业务处理函数；表单格式的报文需通过 haozpay.WithNotificationContentType 指定 Content-Type
+//   - body: 回调请求的原始报文
+//
+// 返回:
+//   - error: 报文格式错误、验签失败、通知过期时返回 haozpay.NotificationVerifier 的错误；
+//     重复的通知包装 haozpay.ErrNotificationReplayed；
+//     业务处理、发布通知或查询处理记录失败时返回 *HandlerError，此时已撤销防重放记录
+func (d *Dispatcher) Dispatch(ctx context.Context, body []byte) error {
+	envelope, err := d.verifier.Verify(ctx, body)
+	if err != nil {
+		return err
+	}
+
+	bizBody := json.RawMessage(envelope.BizBody)
+	route, err := d.route(detectType(bizBody), envelope, bizBody)
+	if err != nil {
+		// 业务数据无法解析，平台重新推送也无法处理，不撤销防重放记录
+		return err
+	}
+	d.mu.RLock()
+	publisher := d.publisher
+	d.mu.RUnlock()
+	if route.handle == nil && publisher == nil {
+		return nil
+	}
+
+	if d.dedup != nil && route.id != "" {
+		processed, err := d.dedup.Processed(ctx, route.id)
+		if err != nil {
+			_ = d.verifier.Release(ctx, body)
+			return &HandlerError{Type: route.typ, Err: err}
+		}
+		if processed {
+			return nil
+		}
+	}
+
+	if route.handle != nil {
+		if err := route.handle(ctx); err != nil {
+			_ = d.verifier.Release(ctx, body)
+			return &HandlerError{Type: route.typ, Err: err}
+		}
+	}
+
+	if publisher != nil {
+		msg := &Message{
+			Type:       route.typ,
+			Key:        messageKey(bizBody),
+			ID:         route.id,
+			MerchantNo: envelope.MerchantNo,
+			Timestamp:  envelope.Timestamp,
+			BizBody:    bizBody,
+		}
+		if err := publisher.Publish(ctx, msg); err != nil {
+			_ = d.verifier.Release(ctx, body)
+			return &HandlerError{Type: route.typ, Err: fmt.Errorf("failed to publish notification: %w", err)}
+		}
+	}
+
+	// 业务已处理成功，记录失败时平台重新推送的通知会再次调用业务处理函数，仍视为成功
+	if d.dedup != nil && route.id != "" {
+		_ = d.dedup.MarkProcessed(ctx, route.id)
+	}
+	return nil
+}
+
+// dispatchRoute 一条通知的处理方式
+type dispatchRoute struct {
+	// typ 通知类型
+	typ EventType
+	// id 去重标识，为空时不去重
+	id string
+	// handle 调用业务处理函数，为 nil 时直接应答成功
+	handle func(ctx context.Context) error
+}
+
+// route 解析通知的业务数据，返回对应的处理方式
+// 通知按 haozpay.DecodeNotification 解析，与 NotificationVerifier 的 Parse* 方法一致
+func (d *Dispatcher) route(typ EventType, envelope *haozpay.HaozPayRequest, bizBody json.RawMessage) (dispatchRoute, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	switch typ {
+	case EventPayment:
+		if d.paymentSuccess == nil {
+			break
+		}
+		n, err := haozpay.DecodeNotification[haozpay.PaymentNotification](envelope)
+		if err != nil {
+			return dispatchRoute{}, err
+		}
+		if !n.OrderStatus.IsSuccess() {
+			break
+		}
+		return handlerRoute(typ, n, d.paymentSuccess), nil
+
+	case EventRefund:
+		if d.refundSuccess == nil {
+			break
+		}
+		n, err := haozpay.DecodeNotification[haozpay.RefundNotification](envelope)
+		if err != nil {
+			return dispatchRoute{}, err
+		}
+		if !n.RefundStatus.IsSuccess() {
+			break
+		}
+		return handlerRoute(typ, n, d.refundSuccess), nil
+
+	case EventWithdraw:
+		if d.withdrawResult == nil {
+			break
+		}
+		n, err := haozpay.DecodeNotification[haozpay.WithdrawNotification](envelope)
+		if err != nil {
+			return dispatchRoute{}, err
+		}
+		return handlerRoute(typ, n, d.withdrawResult), nil
+
+	case EventTransfer:
+		if d.transferResult == nil {
+			break
+		}
+		n, err := haozpay.DecodeNotification[haozpay.TransferNotification](envelope)
+		if err != nil {
+			return dispatchRoute{}, err
+		}
+		return handlerRoute(typ, n, d.transferResult), nil
+
+	case EventDeduct:
+		if d.deductResult == nil {
+			break
+		}
+		n, err := haozpay.DecodeNotification[haozpay.DeductNotification](envelope)
+		if err != nil {
+			return dispatchRoute{}, err
+		}
+		return handlerRoute(typ, n, d.deductResult), nil
+
+	case EventContract:
+		if d.contractResult == nil {
+			break
+		}
+		n, err := haozpay.DecodeNotification[haozpay.ContractNotification](envelope)
+		if err != nil {
+			return dispatchRoute{}, err
+		}
+		return handlerRoute(typ, n, d.contractResult), nil
+
+	case EventInvoice:
+		if d.invoiceResult == nil {
+			break
+		}
+		n, err := haozpay.DecodeNotification[haozpay.InvoiceNotification](envelope)
+		if err != nil {
+			return dispatchRoute{}, err
+		}
+		return handlerRoute(typ, n, d.invoiceResult), nil
+	}
+
+	if d.unknown == nil {
+		return dispatchRoute{typ: typ}, nil
+	}
+	raw := &RawNotification{
+		Type:       typ,
+		MerchantNo: envelope.MerchantNo,
+		Timestamp:  envelope.Timestamp,
+		BizBody:    bizBody,
+	}
+	handle := d.unknown
+	return dispatchRoute{typ: typ, handle: func(ctx context.Context) error {
+		return handle(ctx, raw)
+	}}, nil
+}
+
+// handlerRoute 返回调用业务处理函数的处理方式，按通知的 NotificationID 去重
+func handlerRoute[N interface{ NotificationID() string }](typ EventType, n N, handle func(ctx context.Context, n N) error) dispatchRoute {
+	return dispatchRoute{typ: typ, id: n.NotificationID(), handle: func(ctx context.Context) error {
+		return handle(ctx, n)
+	}}
+}
+
+// detectType 识别通知类型
+func detectType(bizBody json.RawMessage) EventType {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bizBody, &fields); err != nil {
+		return EventUnknown
+	}
+
+	if raw, ok := fields["notifyType"]; ok {
+		var notifyType string
+		if err := json.Unmarshal(raw, &notifyType); err == nil {
+			switch typ := EventType(notifyType); typ {
+			case EventPayment, EventRefund, EventWithdraw, EventTransfer, EventDeduct, EventContract, EventInvoice:
+				return typ
+			}
+			return EventUnknown
+		}
+	}
+
+	has := func(name string) bool {
+		_, ok := fields[name]
+		return ok
+	}
+	switch {
+	case has("refundSeqId"):
+		return EventRefund
+	case has("withdrawStatus"):
+		return EventWithdraw
+	case has("transferStatus"):
+		return EventTransfer
+	case has("invoiceStatus"):
+		return EventInvoice
+	case has("contractId") && !has("orderStatus"):
+		return EventContract
+	case has("contractId"):
+		return EventDeduct
+	case has("orderStatus"):
+		return EventPayment
+	}
+	return EventUnknown
+}
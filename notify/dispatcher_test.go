@@ -0,0 +1,282 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+	"github.com/haoz-cloud/haozpay-sdk/haozpaytest"
+)
+
+// newTestNotifier 启动模拟网关，返回用于签名通知的网关和验证平台签名的配置
+func newTestNotifier(t *testing.T) (*haozpaytest.Server, *haozpay.Config) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := haozpaytest.NewServer(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Close)
+
+	cfg := server.ClientConfig().
+		WithNotifyNonceStore(haozpay.NewMemoryNonceStore(100)).
+		WithNotifyDedupStore(haozpay.NewMemoryDedupStore(0, time.Hour))
+	return server, cfg
+}
+
+// signNotification 返回模拟网关签名的通知报文
+func signNotification(t *testing.T, server *haozpaytest.Server, bizBody interface{}) []byte {
+	t.Helper()
+	body, err := server.SignNotification(bizBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+// serve 通过 ServeHTTP 投递通知，返回状态码和应答报文
+func serve(d *Dispatcher, body []byte) (int, string) {
+	recorder := httptest.NewRecorder()
+	d.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/notify", bytes.NewReader(body)))
+	return recorder.Code, recorder.Body.String()
+}
+
+// fakePublisher 记录发布的消息，err 不为 nil 时发布失败
+type fakePublisher struct {
+	messages []*Message
+	err      error
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, msg *Message) error {
+	p.messages = append(p.messages, msg)
+	return p.err
+}
+
+func TestDispatcherRoute(t *testing.T) {
+	server, cfg := newTestNotifier(t)
+	dispatcher := NewDispatcher(cfg)
+
+	// got 记录被调用的处理函数和收到的商户编号、时间戳
+	var got struct {
+		handler    string
+		merchantNo string
+		timestamp  int64
+		currency   haozpay.Currency
+	}
+	record := func(handler, merchantNo string, timestamp int64) {
+		got.handler, got.merchantNo, got.timestamp = handler, merchantNo, timestamp
+	}
+	dispatcher.OnPaymentSuccess(func(ctx context.Context, n *haozpay.PaymentNotification) error {
+		record("payment", n.MerchantNo, n.Timestamp)
+		got.currency = n.Currency
+		return nil
+	})
+	dispatcher.OnRefundSuccess(func(ctx context.Context, n *haozpay.RefundNotification) error {
+		record("refund", n.MerchantNo, n.Timestamp)
+		got.currency = n.Currency
+		return nil
+	})
+	dispatcher.OnWithdrawResult(func(ctx context.Context, n *haozpay.WithdrawNotification) error {
+		record("withdraw", n.MerchantNo, n.Timestamp)
+		return nil
+	})
+	dispatcher.OnTransferResult(func(ctx context.Context, n *haozpay.TransferNotification) error {
+		record("transfer", n.MerchantNo, n.Timestamp)
+		return nil
+	})
+	dispatcher.OnDeductResult(func(ctx context.Context, n *haozpay.DeductNotification) error {
+		record("deduct", n.MerchantNo, n.Timestamp)
+		return nil
+	})
+	dispatcher.OnContractResult(func(ctx context.Context, n *haozpay.ContractNotification) error {
+		record("contract", n.MerchantNo, n.Timestamp)
+		return nil
+	})
+	dispatcher.OnInvoiceResult(func(ctx context.Context, n *haozpay.InvoiceNotification) error {
+		record("invoice", n.MerchantNo, n.Timestamp)
+		return nil
+	})
+	dispatcher.OnUnknown(func(ctx context.Context, raw *RawNotification) error {
+		record("unknown:"+string(raw.Type), raw.MerchantNo, raw.Timestamp)
+		return nil
+	})
+
+	tests := []struct {
+		name    string
+		bizBody map[string]interface{}
+		want    string
+	}{
+		{name: "payment", bizBody: map[string]interface{}{"orderNo": "P1", "orderStatus": 2}, want: "payment"},
+		{name: "unpaid payment", bizBody: map[string]interface{}{"orderNo": "P2", "orderStatus": 1}, want: "unknown:PAYMENT"},
+		{name: "refund", bizBody: map[string]interface{}{"orderNo": "P1", "refundSeqId": "R1", "refundStatus": 1}, want: "refund"},
+		{name: "processing refund", bizBody: map[string]interface{}{"orderNo": "P1", "refundSeqId": "R2", "refundStatus": 0}, want: "unknown:REFUND"},
+		{name: "withdraw", bizBody: map[string]interface{}{"reqSeqId": "W1", "withdrawStatus": 1}, want: "withdraw"},
+		{name: "transfer", bizBody: map[string]interface{}{"reqSeqId": "T1", "transferStatus": 1}, want: "transfer"},
+		{name: "deduct", bizBody: map[string]interface{}{"orderNo": "P3", "contractId": "C1", "orderStatus": 2}, want: "deduct"},
+		{name: "contract", bizBody: map[string]interface{}{"contractId": "C1", "contractStatus": 1}, want: "contract"},
+		{name: "invoice", bizBody: map[string]interface{}{"invoiceNo": "I1", "invoiceStatus": 1}, want: "invoice"},
+		{name: "notifyType overrides field detection", bizBody: map[string]interface{}{"notifyType": "INVOICE", "invoiceNo": "I2", "orderStatus": 2}, want: "invoice"},
+		{name: "unknown notifyType", bizBody: map[string]interface{}{"notifyType": "COUPON", "orderStatus": 2}, want: "unknown:UNKNOWN"},
+		{name: "unrecognized fields", bizBody: map[string]interface{}{"couponId": "X1"}, want: "unknown:UNKNOWN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got.handler, got.currency = "", ""
+			code, ack := serve(dispatcher, signNotification(t, server, tt.bizBody))
+			if code != http.StatusOK || ack != haozpay.NotifyAckSuccess {
+				t.Fatalf("ServeHTTP() = %d %q, want %d %q", code, ack, http.StatusOK, haozpay.NotifyAckSuccess)
+			}
+			if got.handler != tt.want {
+				t.Fatalf("handler = %q, want %q", got.handler, tt.want)
+			}
+			// 报文外层的商户编号和时间戳由 haozpay.DecodeNotification 填入
+			if got.merchantNo != server.MerchantNo || got.timestamp == 0 {
+				t.Errorf("merchantNo/timestamp = %q/%d", got.merchantNo, got.timestamp)
+			}
+			if (tt.want == "payment" || tt.want == "refund") && got.currency != haozpay.CurrencyCNY {
+				t.Errorf("currency = %q, want %q", got.currency, haozpay.CurrencyCNY)
+			}
+		})
+	}
+}
+
+func TestDispatcherRejectsInvalidNotification(t *testing.T) {
+	server, cfg := newTestNotifier(t)
+	dispatcher := NewDispatcher(cfg)
+	called := false
+	dispatcher.OnUnknown(func(ctx context.Context, raw *RawNotification) error {
+		called = true
+		return nil
+	})
+
+	tampered := bytes.Replace(signNotification(t, server, map[string]interface{}{"orderNo": "P1"}), []byte("P1"), []byte("P2"), 1)
+	for name, body := range map[string][]byte{"malformed": []byte("{"), "tampered": tampered} {
+		if code, ack := serve(dispatcher, body); code != http.StatusBadRequest || ack != haozpay.NotifyAckFail {
+			t.Errorf("ServeHTTP(%s) = %d %q, want %d %q", name, code, ack, http.StatusBadRequest, haozpay.NotifyAckFail)
+		}
+	}
+	if called {
+		t.Error("handler called for an invalid notification")
+	}
+
+	recorder := httptest.NewRecorder()
+	dispatcher.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/notify", nil))
+	if recorder.Code != http.StatusMethodNotAllowed || recorder.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("GET = %d, Allow %q", recorder.Code, recorder.Header().Get("Allow"))
+	}
+}
+
+func TestDispatcherDedup(t *testing.T) {
+	server, cfg := newTestNotifier(t)
+	dispatcher := NewDispatcher(cfg)
+	calls := 0
+	dispatcher.OnPaymentSuccess(func(ctx context.Context, n *haozpay.PaymentNotification) error {
+		calls++
+		return nil
+	})
+	ctx := context.Background()
+	bizBody := map[string]interface{}{"orderNo": "P1", "orderStatus": 2}
+
+	body := signNotification(t, server, bizBody)
+	if err := dispatcher.Dispatch(ctx, body); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+
+	// 同一报文再次推送被防重放记录拦截，ServeHTTP 仍应答成功
+	if err := dispatcher.Dispatch(ctx, body); !errors.Is(err, haozpay.ErrNotificationReplayed) {
+		t.Fatalf("Dispatch() with the same body error = %v, want %v", err, haozpay.ErrNotificationReplayed)
+	}
+	if code, ack := serve(dispatcher, body); code != http.StatusOK || ack != haozpay.NotifyAckSuccess {
+		t.Errorf("ServeHTTP() with the same body = %d %q", code, ack)
+	}
+
+	// 重新签名的同一通知可以通过验签，按 NotificationID 去重后不再调用处理函数
+	time.Sleep(2 * time.Millisecond)
+	if err := dispatcher.Dispatch(ctx, signNotification(t, server, bizBody)); err != nil {
+		t.Fatalf("Dispatch() with a re-signed body error = %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("handler calls = %d, want 1", calls)
+	}
+}
+
+func TestDispatcherReleaseOnHandlerError(t *testing.T) {
+	server, cfg := newTestNotifier(t)
+	dispatcher := NewDispatcher(cfg)
+	failure := errors.New("database unavailable")
+	calls := 0
+	dispatcher.OnRefundSuccess(func(ctx context.Context, n *haozpay.RefundNotification) error {
+		calls++
+		if calls == 1 {
+			return failure
+		}
+		return nil
+	})
+	body := signNotification(t, server, map[string]interface{}{"orderNo": "P1", "refundSeqId": "R1", "refundStatus": 1})
+
+	err := dispatcher.Dispatch(context.Background(), body)
+	var handlerErr *HandlerError
+	if !errors.As(err, &handlerErr) || handlerErr.Type != EventRefund || !errors.Is(err, failure) {
+		t.Fatalf("Dispatch() error = %v, want *HandlerError wrapping %v", err, failure)
+	}
+
+	// 防重放记录已撤销，平台重新推送的同一报文再次调用处理函数
+	if code, ack := serve(dispatcher, body); code != http.StatusOK || ack != haozpay.NotifyAckSuccess {
+		t.Fatalf("ServeHTTP() after handler error = %d %q", code, ack)
+	}
+	if calls != 2 {
+		t.Errorf("handler calls = %d, want 2", calls)
+	}
+}
+
+func TestDispatcherReleaseOnPublisherError(t *testing.T) {
+	server, cfg := newTestNotifier(t)
+	dispatcher := NewDispatcher(cfg)
+	calls := 0
+	dispatcher.OnPaymentSuccess(func(ctx context.Context, n *haozpay.PaymentNotification) error {
+		calls++
+		return nil
+	})
+	publisher := &fakePublisher{err: errors.New("broker unavailable")}
+	dispatcher.SetPublisher(publisher)
+	body := signNotification(t, server, map[string]interface{}{"orderNo": "P1", "orderStatus": 2})
+
+	code, ack := serve(dispatcher, body)
+	if code != http.StatusInternalServerError || ack != haozpay.NotifyAckFail {
+		t.Fatalf("ServeHTTP() with publisher error = %d %q", code, ack)
+	}
+
+	// 发布失败时不记录处理结果，重新推送的报文再次调用处理函数并发布
+	publisher.err = nil
+	if err := dispatcher.Dispatch(context.Background(), body); err != nil {
+		t.Fatalf("Dispatch() after publisher error = %v", err)
+	}
+	if calls != 2 || len(publisher.messages) != 2 {
+		t.Fatalf("handler calls = %d, messages = %d, want 2 and 2", calls, len(publisher.messages))
+	}
+
+	msg := publisher.messages[1]
+	if msg.Type != EventPayment || msg.Key != "P1" || msg.MerchantNo != server.MerchantNo || msg.Timestamp == 0 {
+		t.Errorf("message = %+v", msg)
+	}
+	if want := (&haozpay.PaymentNotification{MerchantNo: server.MerchantNo, OrderNo: "P1", OrderStatus: haozpay.OrderStatusPaid}).NotificationID(); msg.ID != want {
+		t.Errorf("message ID = %q, want %q", msg.ID, want)
+	}
+}
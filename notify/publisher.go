@@ -0,0 +1,73 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Message 转发到消息总线的回调通知
+// 通过 json.Marshal 序列化后即可作为消息体，消费方按 Type 解析 BizBody
+type Message struct {
+	// Type 识别出的通知类型，无法识别时为 EventUnknown
+	Type EventType `json:"type"`
+	// Key 消息的分区键，同一订单的通知使用相同的键以保证消费顺序，取值参见 messageKey
+	Key string `json:"key"`
+	// ID 通知的去重标识，与 haozpay.DedupStore 使用的标识一致；通知没有对应的业务处理函数时为空
+	ID string `json:"id,omitempty"`
+	// MerchantNo 商户编号，取自通知报文外层
+	MerchantNo string `json:"merchantNo"`
+	// Timestamp 通知发送时间戳(毫秒)，取自通知报文外层
+	Timestamp int64 `json:"timestamp"`
+	// BizBody 通知的业务数据(JSON)
+	BizBody json.RawMessage `json:"bizBody"`
+}
+
+// Publisher 将验签通过的回调通知转发到消息总线
+// Kafka 和 NATS 的实现分别位于 haozpaykafka 和 haozpaynats 子模块，也可基于其他消息系统自行实现
+type Publisher interface {
+	// Publish 发布一条通知，返回错误时 Dispatcher 应答平台重新推送
+	Publish(ctx context.Context, msg *Message) error
+}
+
+// SetPublisher 设置通知转发器
+//
+// 每条验签通过的通知（包括无法识别类型的通知）在业务处理函数成功返回后发布到 p，
+// 未注册任何业务处理函数时仅发布通知。发布失败时撤销防重放记录并应答平台重新推送，
+// 重新推送的通知会再次调用业务处理函数并再次发布，消费方需要按 Message.ID 或业务单号幂等处理
+//
+// 参数:
+//   - p: 通知转发器，为 nil 时不转发
+//
+// 示例:
+//
+//	writer := &kafka.Writer{Addr: kafka.TCP("127.0.0.1:9092"), Topic: "haozpay-notify"}
+//	dispatcher.SetPublisher(haozpaykafka.NewPublisher(writer))
+func (d *Dispatcher) SetPublisher(p Publisher) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.publisher = p
+}
+
+// messageKeyFields 依次尝试作为消息分区键的业务数据字段
+// 支付、退款、代扣扣款通知都带有 orderNo，同一订单的通知因此进入同一分区；
+// 提现、转账通知使用商户请求流水号，代扣协议通知使用协议号，电子发票通知使用发票号
+var messageKeyFields = []string{"orderNo", "reqSeqId", "contractId", "invoiceNo"}
+
+// messageKey 返回通知的消息分区键，业务数据不含 messageKeyFields 中的字段时返回空字符串
+func messageKey(bizBody json.RawMessage) string {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bizBody, &fields); err != nil {
+		return ""
+	}
+	for _, name := range messageKeyFields {
+		raw, ok := fields[name]
+		if !ok {
+			continue
+		}
+		var key string
+		if err := json.Unmarshal(raw, &key); err == nil && key != "" {
+			return key
+		}
+	}
+	return ""
+}
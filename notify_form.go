@@ -0,0 +1,164 @@
+package haozpay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// contentTypeJSON JSON 格式的回调报文
+	contentTypeJSON = "application/json"
+	// contentTypeForm 表单格式的回调报文
+	contentTypeForm = "application/x-www-form-urlencoded"
+)
+
+// notificationContentTypeKey context 中存储回调报文 Content-Type 的键
+type notificationContentTypeKey struct{}
+
+// WithNotificationContentType 指定回调报文的 Content-Type，NotificationVerifier 按其选择报文的解析方式
+// 未指定时按 JSON 格式解析；NotifyHandler、notify.Dispatcher 和 Gin 中间件会自动使用回调请求的 Content-Type
+//
+// 参数:
+//   - ctx: 传给 NotificationVerifier 的 context
+//   - contentType: 回调请求的 Content-Type 请求头，支持 application/json 和 application/x-www-form-urlencoded
+//
+// 返回:
+//   - context.Context: 携带 Content-Type 的 context
+//
+// 示例:
+//
+//	ctx := haozpay.WithNotificationContentType(r.Context(), r.Header.Get("Content-Type"))
+//	notification, err := verifier.ParsePaymentNotification(ctx, body)
+func WithNotificationContentType(ctx context.Context, contentType string) context.Context {
+	return context.WithValue(ctx, notificationContentTypeKey{}, contentType)
+}
+
+// notificationContentType 获取 context 中通过 WithNotificationContentType 指定的 Content-Type
+func notificationContentType(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	contentType, _ := ctx.Value(notificationContentTypeKey{}).(string)
+	return contentType
+}
+
+// decodeNotification 按 Content-Type 解析通知报文，返回报文外层和验签参数
+// Content-Type 为空时按 JSON 格式解析
+func decodeNotification(contentType string, body []byte) (*HaozPayRequest, map[string]string, error) {
+	isForm, err := isFormContentType(contentType)
+	if err != nil {
+		return nil, nil, err
+	}
+	if isForm {
+		return decodeFormNotification(string(body))
+	}
+
+	var envelope HaozPayRequest
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal notification: %w", err)
+	}
+	params, err := notificationSignParams(&envelope)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &envelope, params, nil
+}
+
+// isFormContentType 判断回调报文是否为表单格式，既不是 JSON 也不是表单的 Content-Type 返回错误
+func isFormContentType(contentType string) (bool, error) {
+	if strings.TrimSpace(contentType) == "" {
+		return false, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false, fmt.Errorf("invalid notification Content-Type %q: %w", contentType, err)
+	}
+	switch {
+	case mediaType == contentTypeForm:
+		return true, nil
+	case mediaType == contentTypeJSON, strings.HasSuffix(mediaType, "+json"):
+		return false, nil
+	}
+	return false, fmt.Errorf("unsupported notification Content-Type %q", contentType)
+}
+
+// decodeFormNotification 解析表单格式的通知报文，返回报文外层和验签参数
+//
+// 支持两种字段布局:
+//   - 与 JSON 报文一致：merchantNo、timestamp、bizBody（JSON 字符串）、signType 和 sign，
+//     验签参数与 JSON 报文相同，为展开的 bizBody 字段加上 merchantNo 和 timestamp
+//   - 平铺布局（没有 bizBody 字段）：业务参数与 merchantNo、timestamp 同为顶层字段，与表单请求（RequestEncodingForm）一致，
+//     除 sign 和 signType 外的全部字段参与验签；业务参数转换为 JSON 对象存入 BizBody，
+//     值为 JSON 对象或数组的字段保留原样，其余字段为字符串
+//
+// URL 解码规则:
+//   - %XX 按 UTF-8 字节解码，+ 解码为空格，与 url.ParseQuery 一致
+//   - sign 为 Base64 字符串，不会包含空格；部分渠道推送时未对 + 编码，解码后的空格还原为 +
+//   - 同一字段出现多次时视为报文错误，避免签名字段与处理字段不一致
+func decodeFormNotification(body string) (*HaozPayRequest, map[string]string, error) {
+	values, err := url.ParseQuery(strings.TrimSpace(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal form notification: %w", err)
+	}
+
+	fields := make(map[string]string, len(values))
+	for key, vs := range values {
+		if len(vs) > 1 {
+			return nil, nil, fmt.Errorf("failed to unmarshal form notification: duplicate field %q", key)
+		}
+		fields[key] = vs[0]
+	}
+
+	envelope := &HaozPayRequest{
+		MerchantNo: fields["merchantNo"],
+		SignType:   SignType(fields["signType"]),
+		Sign:       strings.ReplaceAll(fields["sign"], " ", "+"),
+	}
+	if timestamp := fields["timestamp"]; timestamp != "" {
+		if envelope.Timestamp, err = strconv.ParseInt(timestamp, 10, 64); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal form notification: invalid timestamp %q", timestamp)
+		}
+	}
+
+	if bizBody, ok := fields["bizBody"]; ok {
+		envelope.BizBody = bizBody
+		params, err := notificationSignParams(envelope)
+		if err != nil {
+			return nil, nil, err
+		}
+		return envelope, params, nil
+	}
+
+	delete(fields, "sign")
+	delete(fields, "signType")
+	if envelope.BizBody, err = formFieldsBizBody(fields); err != nil {
+		return nil, nil, err
+	}
+	return envelope, fields, nil
+}
+
+// formFieldsBizBody 将平铺布局的业务参数转换为 JSON 对象，不包含 merchantNo 和 timestamp
+func formFieldsBizBody(fields map[string]string) (string, error) {
+	bizBody := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		if key == "merchantNo" || key == "timestamp" {
+			continue
+		}
+		if trimmed := strings.TrimSpace(value); (strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed)) {
+			bizBody[key] = json.RawMessage(trimmed)
+			continue
+		}
+		bizBody[key] = value
+	}
+
+	data, err := json.Marshal(bizBody)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal form notification: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,150 @@
+package haozpay
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// signedFormFields 返回使用测试密钥签名的平铺布局表单字段
+func signedFormFields(t *testing.T, business map[string]string) url.Values {
+	t.Helper()
+	signer, _ := newTestRSAKey(t)
+
+	values := url.Values{}
+	params := map[string]interface{}{}
+	fields := map[string]string{
+		"merchantNo": "M1",
+		"timestamp":  strconv.FormatInt(time.Now().UnixMilli(), 10),
+	}
+	for key, value := range business {
+		fields[key] = value
+	}
+	for key, value := range fields {
+		values.Set(key, value)
+		params[key] = value
+	}
+
+	sign, err := GenerateSignWithSignType(params, signer, SignTypeRSA2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values.Set("signType", string(SignTypeRSA2))
+	values.Set("sign", sign)
+	return values
+}
+
+func TestVerifyFormNotification(t *testing.T) {
+	_, publicKey := testKeyPair(t)
+	verifier := NewNotificationVerifier(&Config{PublicKey: publicKey})
+
+	flattened := signedFormFields(t, map[string]string{
+		"orderNo":     "P1",
+		"orderTitle":  "测试 商品",
+		"orderAmount": "1.00",
+		"orderStatus": "2",
+		"payType":     "1",
+	})
+	plusEncoded := flattened.Encode()
+	if !strings.Contains(plusEncoded, "+") {
+		t.Fatalf("encoded form %s does not use + for spaces", plusEncoded)
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "space encoded as +", body: plusEncoded},
+		{name: "space encoded as %20", body: strings.ReplaceAll(plusEncoded, "+", "%20")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := WithNotificationContentType(context.Background(), "application/x-www-form-urlencoded; charset=utf-8")
+			notification, err := verifier.ParsePaymentNotification(ctx, []byte(tt.body))
+			if err != nil {
+				t.Fatalf("ParsePaymentNotification() error = %v", err)
+			}
+			if notification.OrderTitle != "测试 商品" || notification.OrderNo != "P1" || notification.MerchantNo != "M1" {
+				t.Errorf("notification = %+v", notification)
+			}
+			if notification.OrderAmount != Fen(100) || notification.OrderStatus != OrderStatusPaid || notification.PayType != PayTypeWechat {
+				t.Errorf("notification amount/status/payType = %s/%d/%d", notification.OrderAmount, notification.OrderStatus, notification.PayType)
+			}
+		})
+	}
+
+	t.Run("tampered field", func(t *testing.T) {
+		tampered := url.Values{}
+		for key, vs := range flattened {
+			tampered[key] = vs
+		}
+		tampered.Set("orderAmount", "100.00")
+		ctx := WithNotificationContentType(context.Background(), "application/x-www-form-urlencoded")
+		if _, err := verifier.Verify(ctx, []byte(tampered.Encode())); !errors.Is(err, ErrSignatureInvalid) {
+			t.Fatalf("Verify() error = %v, want %v", err, ErrSignatureInvalid)
+		}
+	})
+}
+
+func TestVerifyFormNotificationEnvelopeLayout(t *testing.T) {
+	_, publicKey := testKeyPair(t)
+	verifier := NewNotificationVerifier(&Config{PublicKey: publicKey})
+
+	jsonBody := signedNotification(t, SignTypeRSA2, SignTypeRSA2, `{"orderNo":"P1","orderTitle":"a b","orderAmount":1.00}`)
+	envelope, _, err := decodeNotification("", jsonBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	form := url.Values{
+		"merchantNo": {envelope.MerchantNo},
+		"timestamp":  {strconv.FormatInt(envelope.Timestamp, 10)},
+		"bizBody":    {envelope.BizBody},
+		"signType":   {string(envelope.SignType)},
+		// 部分渠道未对 sign 中的 + 编码
+		"sign": {envelope.Sign},
+	}
+	body := strings.Replace(form.Encode(), "sign="+url.QueryEscape(envelope.Sign), "sign="+envelope.Sign, 1)
+
+	ctx := WithNotificationContentType(context.Background(), "application/x-www-form-urlencoded")
+	notification, err := verifier.ParsePaymentNotification(ctx, []byte(body))
+	if err != nil {
+		t.Fatalf("ParsePaymentNotification() error = %v", err)
+	}
+	if notification.OrderTitle != "a b" || notification.OrderAmount != Fen(100) {
+		t.Errorf("notification = %+v", notification)
+	}
+}
+
+func TestVerifyNotificationContentType(t *testing.T) {
+	_, publicKey := testKeyPair(t)
+	verifier := NewNotificationVerifier(&Config{PublicKey: publicKey})
+	jsonBody := signedNotification(t, SignTypeRSA2, SignTypeRSA2, `{"orderNo":"P1"}`)
+	formBody := signedFormFields(t, map[string]string{"orderNo": "P1"}).Encode()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantErr     bool
+	}{
+		{name: "json without Content-Type", body: string(jsonBody)},
+		{name: "json", contentType: "application/json; charset=utf-8", body: string(jsonBody)},
+		{name: "form", contentType: "application/x-www-form-urlencoded", body: formBody},
+		{name: "form without Content-Type", body: formBody, wantErr: true},
+		{name: "json declared as form", contentType: "application/x-www-form-urlencoded", body: string(jsonBody), wantErr: true},
+		{name: "unsupported Content-Type", contentType: "text/plain", body: string(jsonBody), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := WithNotificationContentType(context.Background(), tt.contentType)
+			_, err := verifier.Verify(ctx, []byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
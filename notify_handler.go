@@ -0,0 +1,122 @@
+package haozpay
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+)
+
+const (
+	// NotifyAckSuccess 回调处理成功时返回给平台的应答报文，平台收到后停止重复推送
+	NotifyAckSuccess = "SUCCESS"
+	// NotifyAckFail 回调处理失败时返回给平台的应答报文，平台会按策略重新推送
+	NotifyAckFail = "FAIL"
+
+	// maxNotifyBodySize 回调报文的最大长度
+	maxNotifyBodySize = 1 << 20
+)
+
+// PaymentNotifyFunc 支付结果通知的业务处理函数
+// 返回 nil 表示处理成功，返回错误时平台会重新推送该通知
+type PaymentNotifyFunc func(ctx context.Context, notification *PaymentNotification) error
+
+// NotifyHandler 支付结果回调的 http.Handler 实现
+// 负责读取请求、验证平台签名、调用业务处理函数并按平台要求应答
+// 通过 NewNotifyHandler 函数创建实例
+type NotifyHandler struct {
+	// verifier 回调通知验证器，负责验签、时间戳和防重放校验
+	verifier *NotificationVerifier
+	// dedup 通知处理记录，为 nil 时不去重
+	dedup DedupStore
+	// handle 业务处理函数
+	handle PaymentNotifyFunc
+}
+
+// NewNotifyHandler 创建支付结果回调处理器
+//
+// 参数:
+//   - cfg: 客户端配置，需要设置平台公钥 PublicKey，防重放校验使用 NotifyNonceStore，去重使用 NotifyDedupStore
+//   - handle: 业务处理函数，仅在验签通过后调用
+//
+// 返回:
+//   - *NotifyHandler: 可直接注册到 http.ServeMux 的回调处理器
+//
+// 应答规则:
+//   - 验签通过且业务处理成功: HTTP 200，应答 SUCCESS
+//   - 重复收到已处理成功的通知（防重放或去重）: HTTP 200，应答 SUCCESS，不调用业务处理函数
+//   - 报文格式错误、验签失败或通知过期: HTTP 400，应答 FAIL
+//   - 业务处理返回错误或查询处理记录失败: HTTP 500，应答 FAIL，并撤销防重放记录以便平台重新推送
+//
+// 示例:
+//
+//	handler := haozpay.NewNotifyHandler(config, func(ctx context.Context, n *haozpay.PaymentNotification) error {
+//	    return orderService.MarkPaid(ctx, n.MerchantOrderNo, n.PaidAmount)
+//	})
+//	http.Handle("/haozpay/notify", handler)
+func NewNotifyHandler(cfg *Config, handle PaymentNotifyFunc) *NotifyHandler {
+	return &NotifyHandler{
+		verifier: NewNotificationVerifier(cfg),
+		dedup:    cfg.NotifyDedupStore,
+		handle:   handle,
+	}
+}
+
+// ServeHTTP 实现 http.Handler 接口
+func (h *NotifyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeNotifyAck(w, http.StatusMethodNotAllowed, NotifyAckFail)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxNotifyBodySize))
+	if err != nil {
+		writeNotifyAck(w, http.StatusBadRequest, NotifyAckFail)
+		return
+	}
+
+	ctx := WithNotificationContentType(r.Context(), r.Header.Get("Content-Type"))
+	notification, err := h.verifier.ParsePaymentNotification(ctx, body)
+	if errors.Is(err, ErrNotificationReplayed) {
+		// 通知已处理成功，平台可能未收到上次的应答，直接应答成功
+		writeNotifyAck(w, http.StatusOK, NotifyAckSuccess)
+		return
+	}
+	if err != nil {
+		writeNotifyAck(w, http.StatusBadRequest, NotifyAckFail)
+		return
+	}
+
+	if h.dedup != nil {
+		processed, err := h.dedup.Processed(ctx, notification.NotificationID())
+		if err != nil {
+			_ = h.verifier.Release(ctx, body)
+			writeNotifyAck(w, http.StatusInternalServerError, NotifyAckFail)
+			return
+		}
+		if processed {
+			writeNotifyAck(w, http.StatusOK, NotifyAckSuccess)
+			return
+		}
+	}
+
+	if err := h.handle(ctx, notification); err != nil {
+		_ = h.verifier.Release(ctx, body)
+		writeNotifyAck(w, http.StatusInternalServerError, NotifyAckFail)
+		return
+	}
+
+	// 业务已处理成功，记录失败时平台重新推送的通知会再次调用业务处理函数，仍应答成功
+	if h.dedup != nil {
+		_ = h.dedup.MarkProcessed(ctx, notification.NotificationID())
+	}
+	writeNotifyAck(w, http.StatusOK, NotifyAckSuccess)
+}
+
+// writeNotifyAck 写入回调应答报文
+func writeNotifyAck(w http.ResponseWriter, statusCode int, ack string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_, _ = io.WriteString(w, ack)
+}
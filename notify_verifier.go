@@ -0,0 +1,255 @@
+package haozpay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrNotificationExpired 通知时间戳与本地时间的偏差超过允许范围
+	ErrNotificationExpired = errors.New("notification timestamp expired")
+	// ErrNotificationReplayed 通知已被接收过，可能是重放攻击
+	ErrNotificationReplayed = errors.New("notification replayed")
+)
+
+// NotificationVerifier 回调通知验证器
+// 在验签的基础上校验通知时间戳，并在配置了 NonceStore 时拒绝重复接收的通知，防止重放攻击
+// 通过 NewNotificationVerifier 函数创建实例，可在多个 goroutine 中并发使用
+type NotificationVerifier struct {
+	// publicKey 平台公钥，用于验证回调签名
+	publicKey string
+	// signType 签名算法类型，为空时根据公钥类型选择；不使用通知报文的 signType 字段，报文声明的算法不一致时拒绝
+	signType SignType
+	// tolerance 通知时间戳允许的最大偏差
+	tolerance time.Duration
+	// nonces 已接收通知的记录，为 nil 时不进行防重放校验
+	nonces NonceStore
+}
+
+// NewNotificationVerifier 创建回调通知验证器
+//
+// 参数:
+//   - cfg: 客户端配置，使用其中的 PublicKey、SignType、NotifyTimestampTolerance 和 NotifyNonceStore，
+//     SignType 未设置时与请求签名相同，默认为 SignTypeRSA2
+//
+// 返回:
+//   - *NotificationVerifier: 回调通知验证器
+//
+// 示例:
+//
+//	config.WithNotifyNonceStore(haozpay.NewMemoryNonceStore(10000))
+//	verifier := haozpay.NewNotificationVerifier(config)
+//
+//	notification, err := verifier.ParsePaymentNotification(r.Context(), body)
+//	if errors.Is(err, haozpay.ErrNotificationReplayed) {
+//	    // 重复的通知，不要再次处理
+//	}
+func NewNotificationVerifier(cfg *Config) *NotificationVerifier {
+	return newNotificationVerifier(cfg.PublicKey, cfg.signType(), cfg.NotifyTimestampTolerance, cfg.NotifyNonceStore)
+}
+
+// newNotificationVerifier 创建回调通知验证器，tolerance 小于等于 0 时使用 NotificationTimestampTolerance
+func newNotificationVerifier(publicKey string, signType SignType, tolerance time.Duration, nonces NonceStore) *NotificationVerifier {
+	if tolerance <= 0 {
+		tolerance = NotificationTimestampTolerance
+	}
+	return &NotificationVerifier{
+		publicKey: publicKey,
+		signType:  signType,
+		tolerance: tolerance,
+		nonces:    nonces,
+	}
+}
+
+// Verify 解析通知报文外层，验证签名、时间戳，并进行防重放校验
+//
+// 处理流程:
+//  1. 按 WithNotificationContentType 指定的 Content-Type 解析通知报文(merchantNo、timestamp、bizBody、signType、sign)，
+//     支持 JSON 和表单(application/x-www-form-urlencoded)格式，未指定时按 JSON 格式解析
+//  2. 使用平台公钥按验证器的签名算法验证签名，报文声明了其他 signType 时拒绝
+//  3. 校验通知时间戳是否在允许的偏差范围内
+//  4. 配置了 NonceStore 时记录通知，已记录过的通知视为重放
+//
+// 参数:
+//   - ctx: 上下文，传给 NonceStore；表单格式的报文需通过 WithNotificationContentType 指定 Content-Type
+//   - body: 回调请求的原始报文
+//
+// 返回:
+//   - *HaozPayRequest: 验证通过的通知报文，表单格式的报文同样转换为该结构
+//   - error: 验签失败时包装 ErrSignatureInvalid，通知过期时包装 ErrNotificationExpired，
+//     重复的通知包装 ErrNotificationReplayed
+func (v *NotificationVerifier) Verify(ctx context.Context, body []byte) (*HaozPayRequest, error) {
+	envelope, params, err := decodeNotification(notificationContentType(ctx), body)
+	if err != nil {
+		return nil, err
+	}
+	if envelope.Sign == "" {
+		return nil, fmt.Errorf("%w: notification sign is missing", ErrSignatureInvalid)
+	}
+
+	verifier, err := cachedVerifier(v.publicKey, v.signType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	// 报文中的 signType 未经认证，只用于校验，不接受与验证器不一致的算法，避免被降级为较弱的摘要算法
+	if envelope.SignType != "" && envelope.SignType != verifier.signType() {
+		return nil, fmt.Errorf("%w: notification signType %s does not match %s", ErrSignatureInvalid, envelope.SignType, verifier.signType())
+	}
+	if err := verifySignature(verifier, params, envelope.Sign); err != nil {
+		return nil, err
+	}
+
+	notifyTime := time.UnixMilli(envelope.Timestamp)
+	if skew := time.Since(notifyTime); skew > v.tolerance || skew < -v.tolerance {
+		return nil, fmt.Errorf("%w: %s", ErrNotificationExpired, notifyTime.Format(time.RFC3339))
+	}
+
+	if v.nonces != nil {
+		// 超出时间戳偏差范围的通知已被拒绝，记录保留两倍偏差时间即可覆盖整个有效期
+		fresh, err := v.nonces.Add(ctx, notificationNonce(envelope), 2*v.tolerance)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check notification nonce: %w", err)
+		}
+		if !fresh {
+			return nil, fmt.Errorf("%w: notification at %s", ErrNotificationReplayed, notifyTime.Format(time.RFC3339))
+		}
+	}
+
+	return envelope, nil
+}
+
+// Release 撤销通知的防重放记录
+// 业务处理失败、需要平台重新推送同一通知时调用，否则重新推送的通知会被视为重放
+//
+// 参数:
+//   - ctx: 上下文，传给 NonceStore，Content-Type 与 Verify 相同
+//   - body: 回调请求的原始报文
+//
+// 返回:
+//   - error: 报文格式错误或 NonceStore 删除失败时返回错误
+func (v *NotificationVerifier) Release(ctx context.Context, body []byte) error {
+	if v.nonces == nil {
+		return nil
+	}
+
+	envelope, _, err := decodeNotification(notificationContentType(ctx), body)
+	if err != nil {
+		return err
+	}
+	return v.nonces.Remove(ctx, notificationNonce(envelope))
+}
+
+// notificationNonce 通知的防重放标识
+// 签名覆盖了通知的全部内容和时间戳，同一商户下可唯一标识一次推送
+func notificationNonce(envelope *HaozPayRequest) string {
+	return envelope.MerchantNo + ":" + envelope.Sign
+}
+
+// ParsePaymentNotification 解析并验证支付结果回调通知
+// 与包级函数 ParsePaymentNotification 相同，时间戳偏差和防重放按验证器的配置校验
+func (v *NotificationVerifier) ParsePaymentNotification(ctx context.Context, body []byte) (*PaymentNotification, error) {
+	return parseNotification[PaymentNotification](ctx, v, body)
+}
+
+// ParseDeductNotification 解析并验证代扣扣款结果回调通知
+// 与包级函数 ParseDeductNotification 相同，时间戳偏差和防重放按验证器的配置校验
+func (v *NotificationVerifier) ParseDeductNotification(ctx context.Context, body []byte) (*DeductNotification, error) {
+	return parseNotification[DeductNotification](ctx, v, body)
+}
+
+// ParseContractNotification 解析并验证代扣协议签约、解约结果回调通知
+// 与包级函数 ParseContractNotification 相同，时间戳偏差和防重放按验证器的配置校验
+func (v *NotificationVerifier) ParseContractNotification(ctx context.Context, body []byte) (*ContractNotification, error) {
+	return parseNotification[ContractNotification](ctx, v, body)
+}
+
+// ParseRefundNotification 解析并验证退款结果回调通知
+// 与包级函数 ParseRefundNotification 相同，时间戳偏差和防重放按验证器的配置校验
+func (v *NotificationVerifier) ParseRefundNotification(ctx context.Context, body []byte) (*RefundNotification, error) {
+	return parseNotification[RefundNotification](ctx, v, body)
+}
+
+// ParseWithdrawNotification 解析并验证提现结果回调通知
+// 与包级函数 ParseWithdrawNotification 相同，时间戳偏差和防重放按验证器的配置校验
+func (v *NotificationVerifier) ParseWithdrawNotification(ctx context.Context, body []byte) (*WithdrawNotification, error) {
+	return parseNotification[WithdrawNotification](ctx, v, body)
+}
+
+// ParseTransferNotification 解析并验证转账结果回调通知
+// 与包级函数 ParseTransferNotification 相同，时间戳偏差和防重放按验证器的配置校验
+func (v *NotificationVerifier) ParseTransferNotification(ctx context.Context, body []byte) (*TransferNotification, error) {
+	return parseNotification[TransferNotification](ctx, v, body)
+}
+
+// ParseInvoiceNotification 解析并验证电子发票开具、红冲结果回调通知
+// 与包级函数 ParseInvoiceNotification 相同，时间戳偏差和防重放按验证器的配置校验
+func (v *NotificationVerifier) ParseInvoiceNotification(ctx context.Context, body []byte) (*InvoiceNotification, error) {
+	return parseNotification[InvoiceNotification](ctx, v, body)
+}
+
+// envelopeApplier 由回调通知类型实现，用于将通知报文外层的商户号、时间戳等字段补充到通知中
+type envelopeApplier interface {
+	applyEnvelope(envelope *HaozPayRequest)
+}
+
+// parseNotification 验证通知报文并将 bizBody 解析为 T，解析规则参见 DecodeNotification
+func parseNotification[T any](ctx context.Context, v *NotificationVerifier, body []byte) (*T, error) {
+	envelope, err := v.Verify(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeNotification[T](envelope)
+}
+
+// DecodeNotification 将已验签通知报文的 bizBody 解析为 T，规则与 NotificationVerifier 的 Parse* 方法相同
+// T 为 SDK 的回调通知类型时，使用报文外层补充商户号和时间戳，支付和退款通知未返回币种时使用 CurrencyCNY；
+// 其他类型只解析 bizBody
+// 适用于先调用 Verify、再按通知类型解析的场景，例如 notify.Dispatcher
+//
+// 类型参数:
+//   - T: 通知类型，例如 PaymentNotification
+//
+// 参数:
+//   - envelope: Verify 返回的通知报文
+//
+// 返回:
+//   - *T: 回调通知
+//   - error: bizBody 与 T 的类型不匹配时返回错误
+//
+// 示例:
+//
+//	envelope, err := verifier.Verify(ctx, body)
+//	if err != nil {
+//	    return err
+//	}
+//	notification, err := haozpay.DecodeNotification[haozpay.RefundNotification](envelope)
+func DecodeNotification[T any](envelope *HaozPayRequest) (*T, error) {
+	notification := new(T)
+	if err := json.Unmarshal([]byte(envelope.BizBody), notification); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification bizBody: %w", err)
+	}
+	if applier, ok := any(notification).(envelopeApplier); ok {
+		applier.applyEnvelope(envelope)
+	}
+	return notification, nil
+}
+
+// notificationSignParams 构建通知验签参数
+// 与请求签名一致：展开 bizBody 中的字段，并加入 merchantNo 和 timestamp
+func notificationSignParams(envelope *HaozPayRequest) (map[string]string, error) {
+	params := make(map[string]string)
+
+	if envelope.BizBody != "" {
+		if err := decodeSignParams([]byte(envelope.BizBody), params); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal notification bizBody: %w", err)
+		}
+	}
+
+	params["merchantNo"] = envelope.MerchantNo
+	params["timestamp"] = fmt.Sprintf("%d", envelope.Timestamp)
+
+	return params, nil
+}
@@ -0,0 +1,165 @@
+package haozpay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// signedNotification 生成使用测试密钥按 signType 签名的 JSON 回调通知报文
+// declared 为报文中声明的 signType，可与实际签名算法不同
+func signedNotification(t *testing.T, signType, declared SignType, bizBody string) []byte {
+	t.Helper()
+	signer, _ := newTestRSAKey(t)
+
+	envelope := HaozPayRequest{
+		MerchantNo: "M1",
+		Timestamp:  time.Now().UnixMilli(),
+		BizBody:    bizBody,
+		SignType:   declared,
+	}
+	params := map[string]interface{}{
+		"merchantNo": envelope.MerchantNo,
+		"timestamp":  strconv.FormatInt(envelope.Timestamp, 10),
+	}
+	decoder := json.NewDecoder(strings.NewReader(bizBody))
+	decoder.UseNumber()
+	if err := decoder.Decode(&params); err != nil {
+		t.Fatal(err)
+	}
+
+	sign, err := GenerateSignWithSignType(params, signer, signType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope.Sign = sign
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func TestNotificationVerifierSignType(t *testing.T) {
+	_, publicKey := testKeyPair(t)
+	const bizBody = `{"orderNo":"P1","orderAmount":1.00}`
+
+	tests := []struct {
+		name     string
+		config   SignType
+		signType SignType
+		declared SignType
+		wantErr  error
+	}{
+		{name: "default RSA2", signType: SignTypeRSA2, declared: SignTypeRSA2},
+		{name: "default RSA2 without declared signType", signType: SignTypeRSA2},
+		{name: "declared RSA is rejected by default", signType: SignTypeRSA, declared: SignTypeRSA, wantErr: ErrSignatureInvalid},
+		{name: "undeclared RSA is rejected by default", signType: SignTypeRSA, wantErr: ErrSignatureInvalid},
+		{name: "configured RSA", config: SignTypeRSA, signType: SignTypeRSA, declared: SignTypeRSA},
+		{name: "declared RSA2 is rejected when RSA is configured", config: SignTypeRSA, signType: SignTypeRSA2, declared: SignTypeRSA2, wantErr: ErrSignatureInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{PublicKey: publicKey, SignType: tt.config}
+			body := signedNotification(t, tt.signType, tt.declared, bizBody)
+
+			_, err := NewNotificationVerifier(cfg).Verify(context.Background(), body)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseNotificationDefaultSignType(t *testing.T) {
+	_, publicKey := testKeyPair(t)
+	body := signedNotification(t, SignTypeRSA, SignTypeRSA, `{"orderNo":"P1","orderAmount":1.00}`)
+
+	// 包级函数只接受公钥类型的默认算法（RSA 公钥为 SignTypeRSA2），声明了 SignTypeRSA 的通知被拒绝
+	if _, err := ParsePaymentNotification(body, publicKey); !errors.Is(err, ErrSignatureInvalid) {
+		t.Fatalf("ParsePaymentNotification() error = %v, want %v", err, ErrSignatureInvalid)
+	}
+
+	// 使用 SignTypeRSA 的商户通过 NewNotificationVerifier 按配置的算法验签
+	verifier := NewNotificationVerifier(&Config{PublicKey: publicKey, SignType: SignTypeRSA})
+	if _, err := verifier.ParsePaymentNotification(context.Background(), body); err != nil {
+		t.Fatalf("NotificationVerifier.ParsePaymentNotification() error = %v", err)
+	}
+}
+
+func TestParseNotificationAppliesEnvelope(t *testing.T) {
+	_, publicKey := testKeyPair(t)
+	verifier := NewNotificationVerifier(&Config{PublicKey: publicKey})
+	ctx := context.Background()
+	body := signedNotification(t, SignTypeRSA2, SignTypeRSA2, `{"orderNo":"P1","refundSeqId":"R1","orderStatus":2}`)
+	envelope, _, err := decodeNotification("", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payment, err := verifier.ParsePaymentNotification(ctx, body)
+	if err != nil {
+		t.Fatalf("ParsePaymentNotification() error = %v", err)
+	}
+	if payment.MerchantNo != "M1" || payment.Timestamp != envelope.Timestamp || payment.Currency != CurrencyCNY || payment.OrderStatus != OrderStatusPaid {
+		t.Errorf("payment notification = %+v", payment)
+	}
+
+	refund, err := verifier.ParseRefundNotification(ctx, body)
+	if err != nil {
+		t.Fatalf("ParseRefundNotification() error = %v", err)
+	}
+	if refund.MerchantNo != "M1" || refund.RefundSeqId != "R1" || refund.Currency != CurrencyCNY {
+		t.Errorf("refund notification = %+v", refund)
+	}
+
+	invoice, err := verifier.ParseInvoiceNotification(ctx, body)
+	if err != nil {
+		t.Fatalf("ParseInvoiceNotification() error = %v", err)
+	}
+	if invoice.MerchantNo != "M1" || invoice.Timestamp != envelope.Timestamp || invoice.OrderNo != "P1" {
+		t.Errorf("invoice notification = %+v", invoice)
+	}
+}
+
+func TestNotificationVerifierReplay(t *testing.T) {
+	_, publicKey := testKeyPair(t)
+	cfg := (&Config{PublicKey: publicKey}).WithNotifyNonceStore(NewMemoryNonceStore(100))
+	verifier := NewNotificationVerifier(cfg)
+	ctx := context.Background()
+	body := signedNotification(t, SignTypeRSA2, SignTypeRSA2, `{"orderNo":"P1"}`)
+
+	if _, err := verifier.Verify(ctx, body); err != nil {
+		t.Fatalf("first Verify() error = %v", err)
+	}
+	if _, err := verifier.Verify(ctx, body); !errors.Is(err, ErrNotificationReplayed) {
+		t.Fatalf("second Verify() error = %v, want %v", err, ErrNotificationReplayed)
+	}
+
+	// 业务处理失败后撤销记录，平台重新推送的同一通知可以再次处理
+	if err := verifier.Release(ctx, body); err != nil {
+		t.Fatalf("Release() error = %v", err)
+	}
+	if _, err := verifier.Verify(ctx, body); err != nil {
+		t.Fatalf("Verify() after Release error = %v", err)
+	}
+}
+
+func TestNotificationVerifierExpired(t *testing.T) {
+	_, publicKey := testKeyPair(t)
+	cfg := (&Config{PublicKey: publicKey}).WithNotifyTimestampTolerance(time.Nanosecond)
+	body := signedNotification(t, SignTypeRSA2, SignTypeRSA2, `{"orderNo":"P1"}`)
+	time.Sleep(time.Millisecond)
+
+	if _, err := NewNotificationVerifier(cfg).Verify(context.Background(), body); !errors.Is(err, ErrNotificationExpired) {
+		t.Fatalf("Verify() error = %v, want %v", err, ErrNotificationExpired)
+	}
+}
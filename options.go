@@ -0,0 +1,174 @@
+package haozpay
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RequestOption 单次调用的请求选项
+// 所有业务接口方法都支持传入 RequestOption，用于覆盖客户端级别的配置
+type RequestOption func(*requestOptions)
+
+// requestOptions 单次调用的请求选项
+type requestOptions struct {
+	// timeout 单个请求的超时时间，为 0 时使用 Config.Timeout
+	timeout time.Duration
+	// headers 附加的请求头
+	headers map[string]string
+	// retryPolicy 重试策略，为 nil 时使用 context 中的策略
+	retryPolicy *RetryPolicy
+	// dryRun 本次调用是否使用演练模式
+	dryRun bool
+	// encoding 请求报文编码方式
+	encoding RequestEncoding
+	// file 随请求上传的文件，不为 nil 时以 multipart/form-data 格式发送
+	file *uploadFile
+	// stats 耗时统计的写入目标，为 nil 时按 Config.Trace 决定是否统计
+	stats *CallStats
+	// progress 文件下载进度回调，为 nil 时不回调
+	progress DownloadProgressFunc
+	// attempts 本次调用实际发送请求的次数，由 apiExecutor 在请求完成后写入
+	attempts int
+	// envelope 响应报文外层字段的写入目标，为 nil 时不写入
+	envelope *Response
+}
+
+// WithRequestTimeout 设置本次调用单个请求的超时时间，覆盖 Config.Timeout
+// 与 Config.Timeout 相同，超时时间作用于每一次请求（包括重试），并且包含读取响应体的时间
+//
+// 参数:
+//   - timeout: 超时时间，小于等于 0 时忽略
+//
+// 示例:
+//
+//	// 对账单文件较大，本次下载延长超时时间
+//	statement, err := client.Bill.DownloadStatement(ctx, billDate, haozpay.BillTypeTrade,
+//	    haozpay.WithRequestTimeout(5*time.Minute))
+func WithRequestTimeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		if timeout > 0 {
+			o.timeout = timeout
+		}
+	}
+}
+
+// WithHeader 为本次调用添加请求头，同名请求头覆盖客户端的公共请求头
+//
+// 参数:
+//   - key: 请求头名称
+//   - value: 请求头的值
+//
+// 示例:
+//
+//	order, err := client.Payment.QueryOrder(ctx, req, haozpay.WithHeader("X-Trace-Id", traceID))
+func WithHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.headers == nil {
+			o.headers = make(map[string]string)
+		}
+		o.headers[key] = value
+	}
+}
+
+// WithRequestRetryPolicy 设置本次调用的重试策略，效果与 WithRetryPolicy 相同
+//
+// 参数:
+//   - policy: 重试策略
+func WithRequestRetryPolicy(policy RetryPolicy) RequestOption {
+	return func(o *requestOptions) {
+		o.retryPolicy = &policy
+	}
+}
+
+// WithNoRetry 本次调用不重试，等同于 WithRequestRetryPolicy(RetryNever)
+func WithNoRetry() RequestOption {
+	return WithRequestRetryPolicy(RetryNever)
+}
+
+// newRequestOptions 合并请求选项
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	options := &requestOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	return options
+}
+
+// context 将超时、重试策略和演练模式写入 context，供传输层和重试条件读取
+func (o *requestOptions) context(ctx context.Context) context.Context {
+	if o.timeout > 0 {
+		ctx = context.WithValue(ctx, requestTimeoutKey{}, o.timeout)
+	}
+	if o.retryPolicy != nil {
+		ctx = WithRetryPolicy(ctx, *o.retryPolicy)
+	}
+	if o.dryRun {
+		ctx = context.WithValue(ctx, dryRunKey{}, true)
+	}
+	return ctx
+}
+
+// setHeaders 将附加请求头写入 HTTP 请求头
+func (o *requestOptions) setHeaders(header http.Header) {
+	for key, value := range o.headers {
+		header.Set(key, value)
+	}
+}
+
+// requestTimeoutKey context 中存储单次调用超时时间的键
+type requestTimeoutKey struct{}
+
+// timeoutTransport 为每个请求设置超时时间的 http.RoundTripper
+//
+// http.Client.Timeout 对客户端的所有请求生效，无法按单次调用调整，
+// 因此由传输层按 context 中的超时时间（未设置时使用默认值）为每个请求单独计时，
+// 计时覆盖到响应体关闭为止，与 http.Client.Timeout 的语义一致
+type timeoutTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+// newTimeoutTransport 创建按请求计时的传输层
+func newTimeoutTransport(base http.RoundTripper, timeout time.Duration) *timeoutTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &timeoutTransport{base: base, timeout: timeout}
+}
+
+// RoundTrip 实现 http.RoundTripper 接口
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	timeout := t.timeout
+	if d, ok := req.Context().Value(requestTimeoutKey{}).(time.Duration); ok {
+		timeout = d
+	}
+	if timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnCloseBody 关闭响应体时释放请求的超时计时器
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close 关闭响应体并释放计时器
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,98 @@
+package haozpay
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// orderTransitions 订单状态的合法流转
+//   - 待支付 → 支付中、支付成功（用户扫码即付）、支付失败、已关闭
+//   - 支付中 → 支付成功、支付失败、已关闭
+//   - 支付成功 → 退款中、已退款（全额退款直接完成）
+//   - 退款中 → 已退款、已关闭，或回到支付成功（部分退款完成或退款失败）
+//
+// 支付失败、已退款、已关闭为终态，不再流转
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusCreated:   {OrderStatusPaying, OrderStatusPaid, OrderStatusFailed, OrderStatusClosed},
+	OrderStatusPaying:    {OrderStatusPaid, OrderStatusFailed, OrderStatusClosed},
+	OrderStatusPaid:      {OrderStatusRefunding, OrderStatusRefunded},
+	OrderStatusRefunding: {OrderStatusPaid, OrderStatusRefunded, OrderStatusClosed},
+}
+
+// CanTransition 判断订单状态能否从 from 流转到 to
+// 状态不变视为合法（平台可能重复推送同一状态的通知），未知状态返回 false
+//
+// 参数:
+//   - from: 本地记录的订单状态
+//   - to: 通知或查询得到的订单状态
+//
+// 返回:
+//   - bool: 流转合法时返回 true
+//
+// 示例:
+//
+//	// 乱序到达的通知不应覆盖本地更新的状态，例如已退款的订单收到迟到的支付成功通知
+//	if !haozpay.CanTransition(local.Status, n.OrderStatus) {
+//	    return nil
+//	}
+func CanTransition(from, to OrderStatus) bool {
+	if _, ok := orderStatusNames[from]; !ok {
+		return false
+	}
+	if _, ok := orderStatusNames[to]; !ok {
+		return false
+	}
+	if from == to {
+		return true
+	}
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// orderStatusCodes 订单状态的英文代码，与 OrderStatus 常量名一致
+var orderStatusCodes = map[string]OrderStatus{
+	"CREATED":   OrderStatusCreated,
+	"PAYING":    OrderStatusPaying,
+	"PAID":      OrderStatusPaid,
+	"FAILED":    OrderStatusFailed,
+	"CLOSED":    OrderStatusClosed,
+	"REFUNDING": OrderStatusRefunding,
+	"REFUNDED":  OrderStatusRefunded,
+}
+
+// ParseOrderStatus 解析平台返回的订单状态字符串
+//
+// 支持以下形式:
+//   - 数字状态码，例如 "2"
+//   - 状态名称，与 OrderStatus.String 一致，例如 "支付成功"（即 orderStatusDesc 字段）
+//   - 英文代码，不区分大小写，例如 "PAID"、"refunding"
+//
+// 参数:
+//   - s: 订单状态字符串
+//
+// 返回:
+//   - OrderStatus: 订单状态
+//   - error: 无法识别时返回错误
+func ParseOrderStatus(s string) (OrderStatus, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.Atoi(s); err == nil {
+		if _, ok := orderStatusNames[OrderStatus(n)]; ok {
+			return OrderStatus(n), nil
+		}
+		return 0, fmt.Errorf("unknown order status %q", s)
+	}
+	if status, ok := orderStatusCodes[strings.ToUpper(s)]; ok {
+		return status, nil
+	}
+	for status, name := range orderStatusNames {
+		if name == s {
+			return status, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown order status %q", s)
+}
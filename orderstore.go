@@ -0,0 +1,104 @@
+package haozpay
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// OrderSnapshot 本地保存的订单状态快照
+type OrderSnapshot struct {
+	// OrderNo 平台订单号
+	OrderNo string
+	// MerchantOrderNo 商户订单号
+	MerchantOrderNo string
+	// OrderStatus 订单状态
+	OrderStatus OrderStatus
+	// OrderAmount 订单金额
+	OrderAmount Money
+	// PaidAmount 实付金额
+	PaidAmount Money
+	// Currency 币种
+	Currency Currency
+	// FinishTime 支付完成时间
+	FinishTime HaozTime
+	// UpdateTime 快照的保存时间
+	UpdateTime time.Time
+}
+
+// NewOrderSnapshot 使用订单查询结果创建快照，UpdateTime 为当前时间
+func NewOrderSnapshot(order *QueryOrderResponse) *OrderSnapshot {
+	return &OrderSnapshot{
+		OrderNo:         order.OrderNo,
+		MerchantOrderNo: order.MerchantOrderNo,
+		OrderStatus:     order.OrderStatus,
+		OrderAmount:     order.OrderAmount,
+		PaidAmount:      order.PaidAmount,
+		Currency:        order.Currency.OrDefault(),
+		FinishTime:      order.FinishTime,
+		UpdateTime:      time.Now(),
+	}
+}
+
+// OrderStore 本地订单快照存储
+// 供 WaitForPayment 保存轮询到的订单状态、bill.ReconcileStore 读取本地订单，
+// SDK 不关心快照保存在哪里，调用方可基于自己的数据库实现；单实例或测试场景可使用 MemoryOrderStore
+type OrderStore interface {
+	// GetOrder 按平台订单号读取快照，不存在时返回 nil, nil
+	GetOrder(ctx context.Context, orderNo string) (*OrderSnapshot, error)
+	// SaveOrder 保存快照，已存在时覆盖
+	SaveOrder(ctx context.Context, snapshot *OrderSnapshot) error
+}
+
+// MemoryOrderStore 基于内存的 OrderStore 实现，进程重启后快照丢失
+// 通过 NewMemoryOrderStore 函数创建实例，可在多个 goroutine 中并发使用
+type MemoryOrderStore struct {
+	mu     sync.RWMutex
+	orders map[string]OrderSnapshot
+}
+
+// NewMemoryOrderStore 创建基于内存的 OrderStore
+func NewMemoryOrderStore() *MemoryOrderStore {
+	return &MemoryOrderStore{orders: make(map[string]OrderSnapshot)}
+}
+
+// GetOrder 实现 OrderStore 接口，返回快照的副本
+func (s *MemoryOrderStore) GetOrder(ctx context.Context, orderNo string) (*OrderSnapshot, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	snapshot, ok := s.orders[orderNo]
+	if !ok {
+		return nil, nil
+	}
+	return &snapshot, nil
+}
+
+// SaveOrder 实现 OrderStore 接口，保存快照的副本
+func (s *MemoryOrderStore) SaveOrder(ctx context.Context, snapshot *OrderSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders[snapshot.OrderNo] = *snapshot
+	return nil
+}
+
+// saveOrderSnapshot 将订单查询结果保存到 Config.OrderStore
+// 本地快照的状态不能流转到查询结果的状态时（例如回调已将订单更新为已退款，查询结果仍为支付成功）不覆盖
+func (e *apiExecutor) saveOrderSnapshot(ctx context.Context, order *QueryOrderResponse) error {
+	store := e.current().config.OrderStore
+	if store == nil {
+		return nil
+	}
+
+	existing, err := store.GetOrder(ctx, order.OrderNo)
+	if err != nil {
+		return fmt.Errorf("failed to get order snapshot: %w", err)
+	}
+	if existing != nil && !CanTransition(existing.OrderStatus, order.OrderStatus) {
+		return nil
+	}
+	if err := store.SaveOrder(ctx, NewOrderSnapshot(order)); err != nil {
+		return fmt.Errorf("failed to save order snapshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Package outbox 提供下单、退款请求的事务性发件箱（Transactional Outbox）
+//
+// 商户在写入本地订单、退款单的同一个数据库事务中保存发件记录，再由 Worker 在后台将记录发送到平台，
+// 本地数据和平台状态不会因进程崩溃或平台不可用而不一致：
+//
+//	// 与本地订单在同一事务中写入发件记录，store 为基于商户数据库实现的 outbox.Store
+//	record := outbox.NewCreateOrderRecord(localOrder.ID, &haozpay.CreatePaymentOrderRequest{...})
+//	if err := store.InsertTx(tx, record); err != nil {
+//	    return err
+//	}
+//
+//	worker := outbox.NewWorker(client, store, &outbox.WorkerOptions{
+//	    OnSent: func(ctx context.Context, record *outbox.Record) {
+//	        if record.Kind == outbox.KindCreateOrder {
+//	            orders.SetPayInfo(ctx, record.ID, record.OrderResponse.MerchantOrderNo, record.OrderResponse.PayInfo)
+//	        }
+//	    },
+//	})
+//	go worker.Run(ctx)
+//
+// 记录 ID 作为幂等键，通过 IdempotencyKeyHeader 请求头随请求发送；
+// 请求超时等结果未知的失败以相同的幂等键重发，平台据此识别重复请求
+package outbox
+
+import (
+	"time"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+// IdempotencyKeyHeader 发送记录时携带幂等键的请求头
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// Kind 发件记录的请求类型
+type Kind string
+
+const (
+	// KindCreateOrder 下单
+	KindCreateOrder Kind = "createOrder"
+	// KindRefund 退款
+	KindRefund Kind = "refund"
+)
+
+// Status 发件记录的状态
+type Status string
+
+const (
+	// StatusPending 待发送，包括暂时性失败后等待重发的记录
+	StatusPending Status = "pending"
+	// StatusSent 平台已受理
+	StatusSent Status = "sent"
+	// StatusFailed 平台拒绝请求或达到最大尝试次数，不再发送
+	StatusFailed Status = "failed"
+)
+
+// Record 发件记录
+type Record struct {
+	// ID 记录标识，同时作为请求的幂等键，通常使用本地订单号或退款单号
+	ID string `json:"id"`
+	// Kind 请求类型
+	Kind Kind `json:"kind"`
+	// CreateOrder 下单请求，Kind 为 KindCreateOrder 时有效
+	CreateOrder *haozpay.CreatePaymentOrderRequest `json:"createOrder,omitempty"`
+	// Refund 退款请求，Kind 为 KindRefund 时有效
+	Refund *haozpay.CreateRefundRequest `json:"refund,omitempty"`
+	// Status 记录状态
+	Status Status `json:"status"`
+	// Attempts 已发送的次数
+	Attempts int `json:"attempts"`
+	// LastError 最近一次发送失败的错误信息
+	LastError string `json:"lastError,omitempty"`
+	// OrderResponse 下单结果，Kind 为 KindCreateOrder 且发送成功时有效
+	OrderResponse *haozpay.PaymentOrderResponse `json:"orderResponse,omitempty"`
+	// RefundResponse 退款结果，Kind 为 KindRefund 且发送成功时有效
+	RefundResponse *haozpay.RefundResponse `json:"refundResponse,omitempty"`
+	// CreatedAt 记录的创建时间
+	CreatedAt time.Time `json:"createdAt"`
+	// SentAt 平台受理的时间
+	SentAt time.Time `json:"sentAt,omitempty"`
+}
+
+// NewCreateOrderRecord 创建待发送的下单记录
+//
+// 参数:
+//   - id: 记录标识和幂等键，同一笔本地订单必须使用相同的值
+//   - req: 下单请求
+//
+// 返回:
+//   - *Record: 发件记录
+func NewCreateOrderRecord(id string, req *haozpay.CreatePaymentOrderRequest) *Record {
+	return &Record{
+		ID:          id,
+		Kind:        KindCreateOrder,
+		CreateOrder: req,
+		Status:      StatusPending,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// NewRefundRecord 创建待发送的退款记录
+//
+// 参数:
+//   - id: 记录标识和幂等键，同一笔本地退款单必须使用相同的值
+//   - req: 退款请求
+//
+// 返回:
+//   - *Record: 发件记录
+func NewRefundRecord(id string, req *haozpay.CreateRefundRequest) *Record {
+	return &Record{
+		ID:        id,
+		Kind:      KindRefund,
+		Refund:    req,
+		Status:    StatusPending,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,101 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store 发件记录存储
+//
+// 记录通常与业务数据保存在同一个数据库中，由商户在业务事务内写入；
+// Worker 只通过 ClaimPending 领取待发送的记录、通过 Save 保存发送结果。
+// 多个 Worker 共用一个 Store 时，ClaimPending 必须保证同一条记录在租约期内只被一个 Worker 领取，
+// 基于关系数据库实现时可使用 SELECT ... FOR UPDATE SKIP LOCKED 并更新记录的租约到期时间
+type Store interface {
+	// ClaimPending 领取最多 limit 条待发送（StatusPending）且未被领取的记录，按 CreatedAt 升序
+	// 领取的记录在 lease 时间内不会再被领取，Save 后释放
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*Record, error)
+	// Save 保存记录的发送结果并释放领取
+	Save(ctx context.Context, record *Record) error
+}
+
+// MemoryStore 基于内存的 Store 实现，进程重启后记录丢失，适用于测试和示例
+// 通过 NewMemoryStore 函数创建实例，可在多个 goroutine 中并发使用
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]*memoryRecord
+}
+
+// memoryRecord 内存中的发件记录及其租约
+type memoryRecord struct {
+	record      Record
+	leasedUntil time.Time
+}
+
+// NewMemoryStore 创建基于内存的 Store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]*memoryRecord)}
+}
+
+// Insert 保存新的发件记录，ID 已存在时返回错误
+func (s *MemoryStore) Insert(record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.records[record.ID]; ok {
+		return fmt.Errorf("outbox record %s already exists", record.ID)
+	}
+	s.records[record.ID] = &memoryRecord{record: *record}
+	return nil
+}
+
+// Get 按 ID 读取记录的副本，不存在时返回 nil
+func (s *MemoryStore) Get(id string) *Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.records[id]
+	if !ok {
+		return nil
+	}
+	record := entry.record
+	return &record
+}
+
+// ClaimPending 实现 Store 接口，返回记录的副本
+func (s *MemoryStore) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	var pending []*memoryRecord
+	for _, entry := range s.records {
+		if entry.record.Status == StatusPending && !entry.leasedUntil.After(now) {
+			pending = append(pending, entry)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].record.CreatedAt.Before(pending[j].record.CreatedAt) })
+	if limit > 0 && len(pending) > limit {
+		pending = pending[:limit]
+	}
+
+	records := make([]*Record, 0, len(pending))
+	for _, entry := range pending {
+		entry.leasedUntil = now.Add(lease)
+		record := entry.record
+		records = append(records, &record)
+	}
+	return records, nil
+}
+
+// Save 实现 Store 接口，保存记录的副本
+func (s *MemoryStore) Save(ctx context.Context, record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[record.ID] = &memoryRecord{record: *record}
+	return nil
+}
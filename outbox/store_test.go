@@ -0,0 +1,144 @@
+package outbox
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+func TestMemoryStoreClaimPending(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now()
+	for i, id := range []string{"R3", "R1", "R2"} {
+		record := NewRefundRecord(id, &haozpay.CreateRefundRequest{OrderNo: "P" + id})
+		record.CreatedAt = now.Add(time.Duration(i) * time.Second)
+		if err := store.Insert(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Insert(NewRefundRecord("R1", nil)); err == nil {
+		t.Error("Insert() with a duplicate ID succeeded")
+	}
+
+	// 按 CreatedAt 升序领取，最多 limit 条
+	claimed, err := store.ClaimPending(ctx, 2, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 2 || claimed[0].ID != "R3" || claimed[1].ID != "R1" {
+		t.Fatalf("ClaimPending() = %v", recordIDs(claimed))
+	}
+
+	// 租约期内的记录不会再被领取
+	claimed, err = store.ClaimPending(ctx, 10, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != "R2" {
+		t.Fatalf("second ClaimPending() = %v, want [R2]", recordIDs(claimed))
+	}
+
+	// Save 释放领取，仍为待发送的记录可以再次领取，其他状态的记录不再领取
+	claimed[0].Attempts = 1
+	if err := store.Save(ctx, claimed[0]); err != nil {
+		t.Fatal(err)
+	}
+	sent := store.Get("R3")
+	sent.Status = StatusSent
+	if err := store.Save(ctx, sent); err != nil {
+		t.Fatal(err)
+	}
+	claimed, err = store.ClaimPending(ctx, 10, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != "R2" || claimed[0].Attempts != 1 {
+		t.Fatalf("ClaimPending() after Save = %v", recordIDs(claimed))
+	}
+
+	// 返回副本，修改领取的记录不影响存储
+	claimed[0].Status = StatusFailed
+	if record := store.Get("R2"); record.Status != StatusPending {
+		t.Errorf("stored status = %s, want %s", record.Status, StatusPending)
+	}
+}
+
+func TestMemoryStoreLeaseExpiry(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	if err := store.Insert(NewRefundRecord("R1", nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	if claimed, _ := store.ClaimPending(ctx, 10, 10*time.Millisecond); len(claimed) != 1 {
+		t.Fatalf("ClaimPending() = %v", recordIDs(claimed))
+	}
+	if claimed, _ := store.ClaimPending(ctx, 10, 10*time.Millisecond); len(claimed) != 0 {
+		t.Fatalf("ClaimPending() within lease = %v", recordIDs(claimed))
+	}
+
+	// 租约到期后未保存的记录可以被其他 Worker 领取
+	time.Sleep(20 * time.Millisecond)
+	if claimed, _ := store.ClaimPending(ctx, 10, time.Hour); len(claimed) != 1 {
+		t.Fatalf("ClaimPending() after lease expiry = %v", recordIDs(claimed))
+	}
+}
+
+func TestMemoryStoreConcurrentClaim(t *testing.T) {
+	store := NewMemoryStore()
+	ctx := context.Background()
+	const records = 50
+	for i := 0; i < records; i++ {
+		if err := store.Insert(NewRefundRecord(string(rune('A'+i)), nil)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 多个 Worker 并发领取，每条记录只被领取一次
+	var (
+		mu     sync.Mutex
+		counts = map[string]int{}
+		wg     sync.WaitGroup
+	)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				claimed, err := store.ClaimPending(ctx, 3, time.Hour)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				mu.Lock()
+				for _, record := range claimed {
+					counts[record.ID]++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(counts) != records {
+		t.Errorf("claimed %d records, want %d", len(counts), records)
+	}
+	for id, n := range counts {
+		if n != 1 {
+			t.Errorf("record %s claimed %d times", id, n)
+		}
+	}
+}
+
+// recordIDs 返回记录 ID，便于输出
+func recordIDs(records []*Record) []string {
+	ids := make([]string, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.ID)
+	}
+	return ids
+}
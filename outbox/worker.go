@@ -0,0 +1,172 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+const (
+	// DefaultPollInterval Worker 默认的扫描间隔，暂时性失败的记录在下一次扫描时重发
+	DefaultPollInterval = 5 * time.Second
+	// DefaultBatchSize Worker 每次扫描默认领取的最大记录数
+	DefaultBatchSize = 100
+	// DefaultLease 领取记录的默认租约时间，应大于单次请求的最长耗时（含 SDK 自动重试）
+	DefaultLease = 5 * time.Minute
+	// DefaultMaxAttempts 默认的最大发送次数
+	DefaultMaxAttempts = 50
+)
+
+// WorkerOptions Worker 参数，各字段为零值时使用默认值
+type WorkerOptions struct {
+	// PollInterval 扫描间隔，默认 DefaultPollInterval
+	PollInterval time.Duration
+	// BatchSize 每次扫描领取的最大记录数，默认 DefaultBatchSize
+	BatchSize int
+	// Lease 领取记录的租约时间，默认 DefaultLease
+	Lease time.Duration
+	// MaxAttempts 最大发送次数，达到后记录标记为 StatusFailed，默认 DefaultMaxAttempts
+	MaxAttempts int
+	// OnSent 平台受理记录后调用，可在此将平台订单号写回本地订单
+	OnSent func(ctx context.Context, record *Record)
+	// OnFailed 记录标记为 StatusFailed 后调用，err 为最后一次发送的错误
+	OnFailed func(ctx context.Context, record *Record, err error)
+}
+
+// Worker 将待发送的发件记录发送到平台
+// 通过 NewWorker 函数创建实例；多个实例可同时运行，由 Store.ClaimPending 保证同一条记录不被并发发送
+type Worker struct {
+	client  *haozpay.Client
+	store   Store
+	options WorkerOptions
+}
+
+// NewWorker 创建发件箱 Worker
+//
+// 参数:
+//   - client: 用于发送请求的客户端
+//   - store: 发件记录存储
+//   - opts: Worker 参数，为 nil 时使用默认值
+//
+// 返回:
+//   - *Worker: 发件箱 Worker
+func NewWorker(client *haozpay.Client, store Store, opts *WorkerOptions) *Worker {
+	var options WorkerOptions
+	if opts != nil {
+		options = *opts
+	}
+	if options.PollInterval <= 0 {
+		options.PollInterval = DefaultPollInterval
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultBatchSize
+	}
+	if options.Lease <= 0 {
+		options.Lease = DefaultLease
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = DefaultMaxAttempts
+	}
+	return &Worker{client: client, store: store, options: options}
+}
+
+// Run 按 PollInterval 扫描并发送待发送的记录，直到 ctx 结束
+//
+// 返回:
+//   - error: ctx 结束时返回 ctx.Err()
+func (w *Worker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// 读取或保存记录失败时等待下一次扫描
+		_, _ = w.ProcessPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessPending 领取并发送一批待发送的记录
+//
+// 返回:
+//   - int: 发送的记录数
+//   - error: 领取或保存记录失败时返回错误
+func (w *Worker) ProcessPending(ctx context.Context) (int, error) {
+	records, err := w.store.ClaimPending(ctx, w.options.BatchSize, w.options.Lease)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim outbox records: %w", err)
+	}
+
+	for i, record := range records {
+		if ctx.Err() != nil {
+			return i, ctx.Err()
+		}
+		if err := w.process(ctx, record); err != nil {
+			return i, err
+		}
+	}
+	return len(records), nil
+}
+
+// process 发送单条记录并保存结果
+// 暂时性失败（网络错误、超时、HTTP 429、5xx）的记录保持待发送状态，下一次扫描时以相同的幂等键重发
+func (w *Worker) process(ctx context.Context, record *Record) error {
+	record.Attempts++
+	err := w.send(ctx, record)
+
+	var sdkErr *haozpay.SDKError
+	switch {
+	case err == nil:
+		record.Status = StatusSent
+		record.LastError = ""
+		record.SentAt = time.Now()
+	case errors.As(err, &sdkErr) && sdkErr.Retryable() && record.Attempts < w.options.MaxAttempts:
+		record.LastError = err.Error()
+	default:
+		record.Status = StatusFailed
+		record.LastError = err.Error()
+	}
+
+	if saveErr := w.store.Save(ctx, record); saveErr != nil {
+		return fmt.Errorf("failed to save outbox record %s: %w", record.ID, saveErr)
+	}
+
+	switch {
+	case record.Status == StatusSent && w.options.OnSent != nil:
+		w.options.OnSent(ctx, record)
+	case record.Status == StatusFailed && w.options.OnFailed != nil:
+		w.options.OnFailed(ctx, record, err)
+	}
+	return nil
+}
+
+// send 按记录类型发送请求，请求携带记录 ID 作为幂等键
+func (w *Worker) send(ctx context.Context, record *Record) error {
+	key := haozpay.WithHeader(IdempotencyKeyHeader, record.ID)
+
+	switch {
+	case record.Kind == KindCreateOrder && record.CreateOrder != nil:
+		resp, err := w.client.Payment.CreateOrder(ctx, record.CreateOrder, key)
+		if err != nil {
+			return err
+		}
+		record.OrderResponse = resp
+		return nil
+	case record.Kind == KindRefund && record.Refund != nil:
+		resp, err := w.client.Payment.CreateRefund(ctx, record.Refund, key)
+		if err != nil {
+			return err
+		}
+		record.RefundResponse = resp
+		return nil
+	default:
+		return fmt.Errorf("invalid outbox record %s: kind %q", record.ID, record.Kind)
+	}
+}
@@ -0,0 +1,237 @@
+package outbox
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+	"github.com/haoz-cloud/haozpay-sdk/haozpaytest"
+)
+
+const (
+	createOrderPath = "/pay-core/payment/order"
+	refundPath      = "/pay-core/payment/refund"
+)
+
+// newTestClient 启动模拟网关，返回网关和指向网关的客户端，客户端不自动重试
+func newTestClient(t *testing.T) (*haozpaytest.Server, *haozpay.Client) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := haozpaytest.NewServer(string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Close)
+
+	cfg := server.ClientConfig().
+		WithPrivateKey(string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))).
+		WithLogger(haozpay.NewSlogLogger(slog.New(slog.NewTextHandler(io.Discard, nil))))
+	client, err := haozpay.NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, client
+}
+
+// insertRefund 保存一条待发送的退款记录
+func insertRefund(t *testing.T, store *MemoryStore, id string) {
+	t.Helper()
+	if err := store.Insert(NewRefundRecord(id, &haozpay.CreateRefundRequest{OrderNo: "P" + id, RefundAmount: haozpay.Fen(100)})); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWorkerSent(t *testing.T) {
+	server, client := newTestClient(t)
+	server.Respond(createOrderPath, &haozpay.PaymentOrderResponse{MerchantOrderNo: "M1", PayInfo: "weixin://pay"})
+	server.Respond(refundPath, &haozpay.RefundResponse{OrderNo: "PR1"})
+	store := NewMemoryStore()
+	if err := store.Insert(NewCreateOrderRecord("O1", &haozpay.CreatePaymentOrderRequest{OrderTitle: "test", OrderAmount: haozpay.Fen(100), PayType: haozpay.PayTypeWechatQR})); err != nil {
+		t.Fatal(err)
+	}
+	insertRefund(t, store, "R1")
+
+	var sent []string
+	worker := NewWorker(client, store, &WorkerOptions{
+		OnSent: func(ctx context.Context, record *Record) {
+			sent = append(sent, record.ID)
+		},
+		OnFailed: func(ctx context.Context, record *Record, err error) {
+			t.Errorf("OnFailed(%s, %v) called", record.ID, err)
+		},
+	})
+
+	n, err := worker.ProcessPending(context.Background())
+	if err != nil || n != 2 {
+		t.Fatalf("ProcessPending() = %d, %v, want 2", n, err)
+	}
+	if len(sent) != 2 {
+		t.Errorf("OnSent called for %q, want both records", sent)
+	}
+
+	order := store.Get("O1")
+	if order.Status != StatusSent || order.Attempts != 1 || order.SentAt.IsZero() || order.OrderResponse == nil || order.OrderResponse.PayInfo != "weixin://pay" {
+		t.Errorf("order record = %+v", order)
+	}
+	if refund := store.Get("R1"); refund.Status != StatusSent || refund.RefundResponse == nil || refund.RefundResponse.OrderNo != "PR1" {
+		t.Errorf("refund record = %+v", refund)
+	}
+
+	// 记录 ID 作为幂等键随请求发送
+	keys := map[string]string{}
+	for _, req := range server.Requests() {
+		keys[req.Path] = req.Header.Get(IdempotencyKeyHeader)
+	}
+	if keys[createOrderPath] != "O1" || keys[refundPath] != "R1" {
+		t.Errorf("%s headers = %v", IdempotencyKeyHeader, keys)
+	}
+
+	// 已发送的记录不再被领取
+	if n, err := worker.ProcessPending(context.Background()); err != nil || n != 0 {
+		t.Errorf("second ProcessPending() = %d, %v, want 0", n, err)
+	}
+}
+
+func TestWorkerRetryable(t *testing.T) {
+	server, client := newTestClient(t)
+	server.Respond(refundPath,
+		&haozpaytest.Error{StatusCode: http.StatusServiceUnavailable, Code: haozpaytest.CodeInternalError, Message: "busy"},
+		&haozpaytest.Error{StatusCode: http.StatusServiceUnavailable, Code: haozpaytest.CodeInternalError, Message: "busy"},
+		&haozpay.RefundResponse{OrderNo: "PR1"},
+	)
+	store := NewMemoryStore()
+	insertRefund(t, store, "R1")
+
+	var sent int
+	worker := NewWorker(client, store, &WorkerOptions{
+		// 租约为 1ns，每次扫描都能重新领取暂时性失败的记录
+		Lease:  1,
+		OnSent: func(ctx context.Context, record *Record) { sent++ },
+	})
+	ctx := context.Background()
+
+	// 暂时性失败的记录保持待发送状态并记录错误，不调用回调
+	if _, err := worker.ProcessPending(ctx); err != nil {
+		t.Fatal(err)
+	}
+	record := store.Get("R1")
+	if record.Status != StatusPending || record.Attempts != 1 || record.LastError == "" || sent != 0 {
+		t.Fatalf("record after retryable error = %+v, OnSent calls %d", record, sent)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := worker.ProcessPending(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	record = store.Get("R1")
+	if record.Status != StatusSent || record.Attempts != 3 || record.LastError != "" || sent != 1 {
+		t.Errorf("record after retries = %+v, OnSent calls %d", record, sent)
+	}
+
+	// 每次重发使用相同的幂等键
+	for _, req := range server.Requests() {
+		if got := req.Header.Get(IdempotencyKeyHeader); got != "R1" {
+			t.Errorf("%s = %q, want %q", IdempotencyKeyHeader, got, "R1")
+		}
+	}
+}
+
+func TestWorkerFailed(t *testing.T) {
+	tests := []struct {
+		name     string
+		response *haozpaytest.Error
+		// attempts 标记为 StatusFailed 前的发送次数
+		attempts int
+	}{
+		{
+			name:     "non-retryable error",
+			response: &haozpaytest.Error{Code: haozpaytest.CodeInvalidRequest, Message: "refund amount exceeds paid amount"},
+			attempts: 1,
+		},
+		{
+			name:     "retryable error until MaxAttempts",
+			response: &haozpaytest.Error{StatusCode: http.StatusServiceUnavailable, Code: haozpaytest.CodeInternalError, Message: "busy"},
+			attempts: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := newTestClient(t)
+			server.Respond(refundPath, tt.response)
+			store := NewMemoryStore()
+			insertRefund(t, store, "R1")
+
+			var failed []error
+			worker := NewWorker(client, store, &WorkerOptions{
+				Lease:       1,
+				MaxAttempts: 3,
+				OnSent: func(ctx context.Context, record *Record) {
+					t.Errorf("OnSent(%s) called", record.ID)
+				},
+				OnFailed: func(ctx context.Context, record *Record, err error) {
+					failed = append(failed, err)
+				},
+			})
+
+			for i := 0; i < 5; i++ {
+				if _, err := worker.ProcessPending(context.Background()); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			record := store.Get("R1")
+			if record.Status != StatusFailed || record.Attempts != tt.attempts || record.LastError == "" {
+				t.Errorf("record = %+v", record)
+			}
+			// OnFailed 只调用一次，err 为最后一次发送的错误
+			var sdkErr *haozpay.SDKError
+			if len(failed) != 1 || !errors.As(failed[0], &sdkErr) {
+				t.Fatalf("OnFailed errors = %v, want one *haozpay.SDKError", failed)
+			}
+			if n := len(server.Requests()); n != tt.attempts {
+				t.Errorf("requests = %d, want %d", n, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestWorkerInvalidRecord(t *testing.T) {
+	server, client := newTestClient(t)
+	store := NewMemoryStore()
+	if err := store.Insert(&Record{ID: "X1", Kind: KindRefund, Status: StatusPending}); err != nil {
+		t.Fatal(err)
+	}
+
+	var failed error
+	worker := NewWorker(client, store, &WorkerOptions{
+		OnFailed: func(ctx context.Context, record *Record, err error) { failed = err },
+	})
+	if _, err := worker.ProcessPending(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// 缺少请求内容的记录不发送，直接标记为 StatusFailed
+	if record := store.Get("X1"); record.Status != StatusFailed || failed == nil {
+		t.Errorf("record = %+v, OnFailed error = %v", record, failed)
+	}
+	if n := len(server.Requests()); n != 0 {
+		t.Errorf("requests = %d, want 0", n)
+	}
+}
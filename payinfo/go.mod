@@ -0,0 +1,5 @@
+module github.com/haoz-cloud/haozpay-sdk/payinfo
+
+go 1.23.0
+
+require github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
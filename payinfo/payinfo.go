@@ -0,0 +1,66 @@
+// Package payinfo 提供下单响应 PayInfo 的辅助处理
+//
+// 扫码支付（例如 haozpay.PayTypeWechatQR、haozpay.PayTypeAlipayQR）下单返回的 PayInfo 为二维码内容，
+// QRCodePNG 将其渲染为 PNG 图片。二维码依赖只在引入本模块时才会加入依赖树
+//
+// 示例:
+//
+//	order, err := client.Payment.CreateOrder(ctx, req)
+//	if err != nil {
+//	    return err
+//	}
+//	png, err := payinfo.QRCodePNG(order.PayInfo, 256)
+package payinfo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// DefaultQRCodeSize 默认二维码图片边长（像素）
+const DefaultQRCodeSize = 256
+
+// QRCodePNG 将 PayInfo 渲染为二维码 PNG 图片
+//
+// 参数:
+//   - payInfo: 下单响应中的 PayInfo（二维码内容）
+//   - size: 图片边长（像素），小于等于 0 时使用 DefaultQRCodeSize
+//
+// 返回:
+//   - []byte: PNG 图片内容
+//   - error: PayInfo 为空或内容过长无法编码时返回错误
+func QRCodePNG(payInfo string, size int) ([]byte, error) {
+	if payInfo == "" {
+		return nil, errors.New("payInfo is empty")
+	}
+	if size <= 0 {
+		size = DefaultQRCodeSize
+	}
+
+	png, err := qrcode.Encode(payInfo, qrcode.Medium, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode qr code: %w", err)
+	}
+	return png, nil
+}
+
+// WriteQRCodePNG 将 PayInfo 渲染为二维码 PNG 图片并写入 w
+// 适用于直接输出到 HTTP 响应，参数规则与 QRCodePNG 一致
+//
+// 示例:
+//
+//	w.Header().Set("Content-Type", "image/png")
+//	if err := payinfo.WriteQRCodePNG(w, order.PayInfo, 0); err != nil {
+//	    http.Error(w, err.Error(), http.StatusInternalServerError)
+//	}
+func WriteQRCodePNG(w io.Writer, payInfo string, size int) error {
+	png, err := QRCodePNG(payInfo, size)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(png)
+	return err
+}
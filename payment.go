@@ -2,210 +2,150 @@ package haozpay
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"time"
 
 	"github.com/go-resty/resty/v2"
 )
 
 type PaymentService struct {
-	client *resty.Client
-	config *Config
+	executor *apiExecutor
 }
 
 func NewPaymentService(client *resty.Client, config *Config) *PaymentService {
 	return &PaymentService{
-		client: client,
-		config: config,
+		executor: newAPIExecutor(client, config),
 	}
 }
 
-func (s *PaymentService) CreateOrder(ctx context.Context, req *CreatePaymentOrderRequest) (*PaymentOrderResponse, error) {
-	bizBodyBytes, err := json.Marshal(req)
-	if err != nil {
+func (s *PaymentService) CreateOrder(ctx context.Context, req *CreatePaymentOrderRequest, opts ...RequestOption) (*PaymentOrderResponse, error) {
+	if !req.PayType.IsValid() {
 		return nil, &SDKError{
-			Code:       ErrInvalidResponse.Code,
-			Message:    fmt.Sprintf("failed to marshal request: %v", err),
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid payType: %d", req.PayType),
 			StatusCode: 0,
 		}
 	}
-
-	haozReq := &HaozPayRequest{
-		MerchantNo: s.config.MerchantNo,
-		Timestamp:  currentTimestampMillis(),
-		BizBody:    string(bizBodyBytes),
+	if err := checkCurrency(req.Currency); err != nil {
+		return nil, err
 	}
-
-	var result struct {
-		Response
-		Data *PaymentOrderResponse `json:"data"`
+	if req.PayType.IsH5() {
+		if err := validateH5Order(req); err != nil {
+			return nil, err
+		}
 	}
 
-	_, err = s.client.R().
-		SetContext(ctx).
-		SetBody(haozReq).
-		SetResult(&result).
-		Post("/pay-core/payment/order")
-
+	resp, err := call[PaymentOrderResponse](ctx, s.executor, "/pay-core/payment/order", req, "failed to create payment order", opts...)
 	if err != nil {
-		return nil, &SDKError{
-			Code:       ErrNetworkError.Code,
-			Message:    fmt.Sprintf("failed to create payment order: %v", err),
-			StatusCode: 0,
-		}
+		return nil, err
 	}
-
-	if result.Code != 0 {
-		return nil, NewSDKErrorWithRequestID(
-			result.Code,
-			result.Message,
-			0,
-			result.RequestID,
-		)
+	if resp != nil {
+		resp.Currency = resp.Currency.OrDefault()
 	}
-
-	return result.Data, nil
+	return resp, nil
 }
 
-func (s *PaymentService) CancelOrder(ctx context.Context, req *CancelPaymentOrderRequest) error {
-	bizBodyBytes, err := json.Marshal(req)
+func (s *PaymentService) CancelOrder(ctx context.Context, req *CancelPaymentOrderRequest, opts ...RequestOption) error {
+	unlock, err := s.executor.lockOrder(ctx, req.OrderNo)
 	if err != nil {
-		return &SDKError{
-			Code:       ErrInvalidResponse.Code,
-			Message:    fmt.Sprintf("failed to marshal request: %v", err),
-			StatusCode: 0,
-		}
+		return err
 	}
+	defer unlock()
 
-	haozReq := &HaozPayRequest{
-		MerchantNo: s.config.MerchantNo,
-		Timestamp:  currentTimestampMillis(),
-		BizBody:    string(bizBodyBytes),
-	}
-
-	var result Response
-
-	_, err = s.client.R().
-		SetContext(ctx).
-		SetBody(haozReq).
-		SetResult(&result).
-		Post("/pay-core/payment/cancel")
+	return s.executor.post(ctx, "/pay-core/payment/cancel", req, nil, "failed to cancel payment order", opts...)
+}
 
+func (s *PaymentService) QueryOrder(ctx context.Context, req *QueryOrderRequest, opts ...RequestOption) (*QueryOrderResponse, error) {
+	resp, err := call[QueryOrderResponse](ctx, s.executor, "/pay-core/payment/order/query", req, "failed to query payment order", opts...)
 	if err != nil {
-		return &SDKError{
-			Code:       ErrNetworkError.Code,
-			Message:    fmt.Sprintf("failed to cancel payment order: %v", err),
-			StatusCode: 0,
-		}
+		return nil, err
 	}
-
-	if result.Code != 0 {
-		return NewSDKErrorWithRequestID(
-			result.Code,
-			result.Message,
-			0,
-			result.RequestID,
-		)
+	if resp != nil {
+		resp.Currency = resp.Currency.OrDefault()
 	}
-
-	return nil
+	return resp, nil
 }
 
-func (s *PaymentService) CreateRefund(ctx context.Context, req *CreateRefundRequest) (*RefundResponse, error) {
-	bizBodyBytes, err := json.Marshal(req)
-	if err != nil {
-		return nil, &SDKError{
-			Code:       ErrInvalidResponse.Code,
-			Message:    fmt.Sprintf("failed to marshal request: %v", err),
-			StatusCode: 0,
-		}
+func (s *PaymentService) CreateRefund(ctx context.Context, req *CreateRefundRequest, opts ...RequestOption) (*RefundResponse, error) {
+	if err := checkCurrency(req.Currency); err != nil {
+		return nil, err
 	}
 
-	haozReq := &HaozPayRequest{
-		MerchantNo: s.config.MerchantNo,
-		Timestamp:  currentTimestampMillis(),
-		BizBody:    string(bizBodyBytes),
+	unlock, err := s.executor.lockOrder(ctx, req.OrderNo)
+	if err != nil {
+		return nil, err
 	}
+	defer unlock()
 
-	var result struct {
-		Response
-		Data *RefundResponse `json:"data"`
+	if config := s.executor.current().config; config.RefundAmountCheck && !config.DryRun && !newRequestOptions(opts).dryRun {
+		if err := s.checkRefundable(ctx, req, opts); err != nil {
+			return nil, err
+		}
 	}
 
-	_, err = s.client.R().
-		SetContext(ctx).
-		SetBody(haozReq).
-		SetResult(&result).
-		Post("/pay-core/payment/refund")
-
+	resp, err := call[RefundResponse](ctx, s.executor, "/pay-core/payment/refund", req, "failed to create refund", opts...)
 	if err != nil {
-		return nil, &SDKError{
-			Code:       ErrNetworkError.Code,
-			Message:    fmt.Sprintf("failed to create refund: %v", err),
-			StatusCode: 0,
-		}
+		return nil, err
 	}
-
-	if result.Code != 0 {
-		return nil, NewSDKErrorWithRequestID(
-			result.Code,
-			result.Message,
-			0,
-			result.RequestID,
-		)
+	if resp != nil {
+		resp.Currency = resp.Currency.OrDefault()
 	}
-
-	return result.Data, nil
+	return resp, nil
 }
 
-func (s *PaymentService) QueryRefund(ctx context.Context, req *QueryRefundRequest) (*QueryRefundResponse, error) {
-	bizBodyBytes, err := json.Marshal(req)
+func (s *PaymentService) QueryRefund(ctx context.Context, req *QueryRefundRequest, opts ...RequestOption) (*QueryRefundResponse, error) {
+	resp, err := call[QueryRefundResponse](ctx, s.executor, "/pay-core/payment/refund/query", req, "failed to query refund", opts...)
 	if err != nil {
-		return nil, &SDKError{
-			Code:       ErrInvalidResponse.Code,
-			Message:    fmt.Sprintf("failed to marshal request: %v", err),
-			StatusCode: 0,
-		}
+		return nil, err
 	}
-
-	haozReq := &HaozPayRequest{
-		MerchantNo: s.config.MerchantNo,
-		Timestamp:  currentTimestampMillis(),
-		BizBody:    string(bizBodyBytes),
+	if resp != nil {
+		resp.Currency = resp.Currency.OrDefault()
 	}
+	return resp, nil
+}
 
-	var result struct {
-		Response
-		Data *QueryRefundResponse `json:"data"`
+func (s *PaymentService) ListRefunds(ctx context.Context, orderNo string, opts ...RequestOption) (*ListRefundsResponse, error) {
+	resp, err := call[ListRefundsResponse](ctx, s.executor, "/pay-core/payment/refund/list", &ListRefundsRequest{OrderNo: orderNo}, "failed to list refunds", opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		resp = &ListRefundsResponse{OrderNo: orderNo}
+	}
+	resp.Currency = resp.Currency.OrDefault()
+	for i := range resp.Refunds {
+		resp.Refunds[i].Currency = resp.Refunds[i].Currency.OrDefault()
 	}
 
-	_, err = s.client.R().
-		SetContext(ctx).
-		SetBody(haozReq).
-		SetResult(&result).
-		Post("/pay-core/payment/refund/query")
+	// 平台未返回汇总信息时按退款记录计算，累计退款金额只统计退款成功的记录
+	if resp.RefundCount == 0 {
+		resp.RefundCount = len(resp.Refunds)
+	}
+	if resp.TotalRefundAmount == 0 {
+		for _, refund := range resp.Refunds {
+			if refund.RefundStatus.IsSuccess() {
+				resp.TotalRefundAmount += refund.RefundAmount
+			}
+		}
+	}
+	return resp, nil
+}
 
-	if err != nil {
+func (s *PaymentService) CreateDeductOrder(ctx context.Context, req *CreateDeductOrderRequest, opts ...RequestOption) (*DeductOrderResponse, error) {
+	if req.ContractId == "" {
 		return nil, &SDKError{
-			Code:       ErrNetworkError.Code,
-			Message:    fmt.Sprintf("failed to query refund: %v", err),
+			Code:       ErrInvalidParameter.Code,
+			Message:    "contractId is required",
 			StatusCode: 0,
 		}
 	}
 
-	if result.Code != 0 {
-		return nil, NewSDKErrorWithRequestID(
-			result.Code,
-			result.Message,
-			0,
-			result.RequestID,
-		)
-	}
+	return call[DeductOrderResponse](ctx, s.executor, "/pay-core/payment/deduct", req, "failed to create deduct order", opts...)
+}
 
-	return result.Data, nil
+func (s *PaymentService) CreateWithdraw(ctx context.Context, req *CreateWithdrawRequest, opts ...RequestOption) (*WithdrawResponse, error) {
+	return call[WithdrawResponse](ctx, s.executor, "/pay-core/withdraw/apply", req, "failed to create withdraw", opts...)
 }
 
-func currentTimestampMillis() int64 {
-	return time.Now().UnixMilli()
-}
\ No newline at end of file
+func (s *PaymentService) QueryWithdraw(ctx context.Context, req *QueryWithdrawRequest, opts ...RequestOption) (*QueryWithdrawResponse, error) {
+	return call[QueryWithdrawResponse](ctx, s.executor, "/pay-core/withdraw/query", req, "failed to query withdraw", opts...)
+}
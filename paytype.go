@@ -0,0 +1,444 @@
+package haozpay
+
+import "fmt"
+
+// PayType 支付方式
+type PayType int
+
+const (
+	// PayTypeAlipay 支付宝
+	PayTypeAlipay PayType = 0
+	// PayTypeWechat 微信
+	PayTypeWechat PayType = 1
+	// PayTypeWechatJSAPI 微信公众号/JSAPI 支付
+	PayTypeWechatJSAPI PayType = 2
+	// PayTypeWechatMiniProgram 微信小程序支付
+	PayTypeWechatMiniProgram PayType = 3
+	// PayTypeWechatQR 微信扫码支付（Native）
+	PayTypeWechatQR PayType = 4
+	// PayTypeAlipayQR 支付宝扫码支付
+	PayTypeAlipayQR PayType = 5
+	// PayTypeUnionPay 银联云闪付
+	PayTypeUnionPay PayType = 6
+	// PayTypeWechatH5 微信 H5 支付（手机浏览器）
+	PayTypeWechatH5 PayType = 7
+	// PayTypeAlipayWap 支付宝手机网站支付
+	PayTypeAlipayWap PayType = 8
+	// PayTypeWechatApp 微信 App 支付
+	PayTypeWechatApp PayType = 9
+	// PayTypeAlipayApp 支付宝 App 支付
+	PayTypeAlipayApp PayType = 10
+)
+
+// payTypeNames 支付方式名称
+var payTypeNames = map[PayType]string{
+	PayTypeAlipay:            "支付宝",
+	PayTypeWechat:            "微信",
+	PayTypeWechatJSAPI:       "微信公众号支付",
+	PayTypeWechatMiniProgram: "微信小程序支付",
+	PayTypeWechatQR:          "微信扫码支付",
+	PayTypeAlipayQR:          "支付宝扫码支付",
+	PayTypeUnionPay:          "银联云闪付",
+	PayTypeWechatH5:          "微信H5支付",
+	PayTypeAlipayWap:         "支付宝手机网站支付",
+	PayTypeWechatApp:         "微信App支付",
+	PayTypeAlipayApp:         "支付宝App支付",
+}
+
+// String 返回支付方式名称，未知支付方式返回 PayType(n)
+func (p PayType) String() string {
+	if name, ok := payTypeNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("PayType(%d)", int(p))
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口，支持数字和字符串两种形式
+func (p *PayType) UnmarshalJSON(data []byte) error {
+	return unmarshalIntEnum(data, p)
+}
+
+// IsValid 判断是否为 SDK 已知的支付方式
+func (p PayType) IsValid() bool {
+	_, ok := payTypeNames[p]
+	return ok
+}
+
+// IsH5 判断是否为手机浏览器内跳转支付的 H5 支付方式
+func (p PayType) IsH5() bool {
+	return p == PayTypeWechatH5 || p == PayTypeAlipayWap
+}
+
+// IsApp 判断是否为 iOS/Android 客户端调起支付 SDK 的 App 支付方式
+func (p PayType) IsApp() bool {
+	return p == PayTypeWechatApp || p == PayTypeAlipayApp
+}
+
+// PayeeType 转账收款方类型
+type PayeeType int
+
+const (
+	// PayeeTypeBankCard 银行卡
+	PayeeTypeBankCard PayeeType = 0
+	// PayeeTypeAlipay 支付宝账户
+	PayeeTypeAlipay PayeeType = 1
+	// PayeeTypeWechat 微信零钱
+	PayeeTypeWechat PayeeType = 2
+)
+
+// payeeTypeNames 收款方类型名称
+var payeeTypeNames = map[PayeeType]string{
+	PayeeTypeBankCard: "银行卡",
+	PayeeTypeAlipay:   "支付宝账户",
+	PayeeTypeWechat:   "微信零钱",
+}
+
+// String 返回收款方类型名称，未知类型返回 PayeeType(n)
+func (p PayeeType) String() string {
+	if name, ok := payeeTypeNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("PayeeType(%d)", int(p))
+}
+
+// IsValid 判断是否为 SDK 已知的收款方类型
+func (p PayeeType) IsValid() bool {
+	_, ok := payeeTypeNames[p]
+	return ok
+}
+
+// BankCardType 银行卡类型
+type BankCardType int
+
+const (
+	// BankCardTypeDebit 借记卡
+	BankCardTypeDebit BankCardType = 0
+	// BankCardTypeCredit 信用卡
+	BankCardTypeCredit BankCardType = 1
+	// BankCardTypePrepaid 预付费卡
+	BankCardTypePrepaid BankCardType = 2
+)
+
+// bankCardTypeNames 银行卡类型名称
+var bankCardTypeNames = map[BankCardType]string{
+	BankCardTypeDebit:   "借记卡",
+	BankCardTypeCredit:  "信用卡",
+	BankCardTypePrepaid: "预付费卡",
+}
+
+// String 返回银行卡类型名称，未知类型返回 BankCardType(n)
+func (t BankCardType) String() string {
+	if name, ok := bankCardTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("BankCardType(%d)", int(t))
+}
+
+// IsValid 判断是否为 SDK 已知的银行卡类型
+func (t BankCardType) IsValid() bool {
+	_, ok := bankCardTypeNames[t]
+	return ok
+}
+
+// AccountTransactionType 账户流水类型
+type AccountTransactionType int
+
+const (
+	// AccountTransactionTypeIncome 交易收入
+	AccountTransactionTypeIncome AccountTransactionType = 0
+	// AccountTransactionTypeRefund 退款支出
+	AccountTransactionTypeRefund AccountTransactionType = 1
+	// AccountTransactionTypeFee 手续费
+	AccountTransactionTypeFee AccountTransactionType = 2
+	// AccountTransactionTypeWithdraw 提现
+	AccountTransactionTypeWithdraw AccountTransactionType = 3
+	// AccountTransactionTypeTransfer 转账（代付）
+	AccountTransactionTypeTransfer AccountTransactionType = 4
+	// AccountTransactionTypeAdjustment 平台调账
+	AccountTransactionTypeAdjustment AccountTransactionType = 5
+)
+
+// accountTransactionTypeNames 账户流水类型名称
+var accountTransactionTypeNames = map[AccountTransactionType]string{
+	AccountTransactionTypeIncome:     "交易收入",
+	AccountTransactionTypeRefund:     "退款",
+	AccountTransactionTypeFee:        "手续费",
+	AccountTransactionTypeWithdraw:   "提现",
+	AccountTransactionTypeTransfer:   "转账",
+	AccountTransactionTypeAdjustment: "调账",
+}
+
+// String 返回账户流水类型名称，未知类型返回 AccountTransactionType(n)
+func (t AccountTransactionType) String() string {
+	if name, ok := accountTransactionTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("AccountTransactionType(%d)", int(t))
+}
+
+// IsValid 判断是否为 SDK 已知的账户流水类型
+func (t AccountTransactionType) IsValid() bool {
+	_, ok := accountTransactionTypeNames[t]
+	return ok
+}
+
+// BillType 对账单类型
+type BillType int
+
+const (
+	// BillTypeTrade 交易账单，包含支付和退款交易明细
+	BillTypeTrade BillType = 0
+	// BillTypeRefund 退款账单
+	BillTypeRefund BillType = 1
+	// BillTypeSettlement 结算账单
+	BillTypeSettlement BillType = 2
+	// BillTypeFund 资金账单，包含提现、转账等资金变动明细
+	BillTypeFund BillType = 3
+)
+
+// billTypeNames 对账单类型名称
+var billTypeNames = map[BillType]string{
+	BillTypeTrade:      "交易账单",
+	BillTypeRefund:     "退款账单",
+	BillTypeSettlement: "结算账单",
+	BillTypeFund:       "资金账单",
+}
+
+// String 返回对账单类型名称，未知类型返回 BillType(n)
+func (b BillType) String() string {
+	if name, ok := billTypeNames[b]; ok {
+		return name
+	}
+	return fmt.Sprintf("BillType(%d)", int(b))
+}
+
+// IsValid 判断是否为 SDK 已知的对账单类型
+func (b BillType) IsValid() bool {
+	_, ok := billTypeNames[b]
+	return ok
+}
+
+// SubMerchantType 子商户主体类型
+type SubMerchantType int
+
+const (
+	// SubMerchantTypeIndividual 个体工商户
+	SubMerchantTypeIndividual SubMerchantType = 0
+	// SubMerchantTypeEnterprise 企业
+	SubMerchantTypeEnterprise SubMerchantType = 1
+	// SubMerchantTypePersonal 小微商户（无营业执照的个人）
+	SubMerchantTypePersonal SubMerchantType = 2
+)
+
+// subMerchantTypeNames 子商户主体类型名称
+var subMerchantTypeNames = map[SubMerchantType]string{
+	SubMerchantTypeIndividual: "个体工商户",
+	SubMerchantTypeEnterprise: "企业",
+	SubMerchantTypePersonal:   "小微商户",
+}
+
+// String 返回子商户主体类型名称，未知类型返回 SubMerchantType(n)
+func (t SubMerchantType) String() string {
+	if name, ok := subMerchantTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("SubMerchantType(%d)", int(t))
+}
+
+// IsValid 判断是否为 SDK 已知的子商户主体类型
+func (t SubMerchantType) IsValid() bool {
+	_, ok := subMerchantTypeNames[t]
+	return ok
+}
+
+// QualificationType 子商户资质材料类型
+type QualificationType int
+
+const (
+	// QualificationTypeBusinessLicense 营业执照
+	QualificationTypeBusinessLicense QualificationType = 0
+	// QualificationTypeIdCardFront 法人身份证人像面
+	QualificationTypeIdCardFront QualificationType = 1
+	// QualificationTypeIdCardBack 法人身份证国徽面
+	QualificationTypeIdCardBack QualificationType = 2
+	// QualificationTypeBankAccount 结算账户证明（开户许可证或银行卡正面）
+	QualificationTypeBankAccount QualificationType = 3
+	// QualificationTypeStorefront 经营场所门头照
+	QualificationTypeStorefront QualificationType = 4
+	// QualificationTypeStoreInterior 经营场所内景照
+	QualificationTypeStoreInterior QualificationType = 5
+	// QualificationTypeOther 其他补充材料
+	QualificationTypeOther QualificationType = 9
+)
+
+// qualificationTypeNames 资质材料类型名称
+var qualificationTypeNames = map[QualificationType]string{
+	QualificationTypeBusinessLicense: "营业执照",
+	QualificationTypeIdCardFront:     "身份证人像面",
+	QualificationTypeIdCardBack:      "身份证国徽面",
+	QualificationTypeBankAccount:     "结算账户证明",
+	QualificationTypeStorefront:      "门头照",
+	QualificationTypeStoreInterior:   "内景照",
+	QualificationTypeOther:           "其他材料",
+}
+
+// String 返回资质材料类型名称，未知类型返回 QualificationType(n)
+func (t QualificationType) String() string {
+	if name, ok := qualificationTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("QualificationType(%d)", int(t))
+}
+
+// IsValid 判断是否为 SDK 已知的资质材料类型
+func (t QualificationType) IsValid() bool {
+	_, ok := qualificationTypeNames[t]
+	return ok
+}
+
+// SettlementAccountType 结算账户类型
+type SettlementAccountType int
+
+const (
+	// SettlementAccountTypeCorporate 对公账户
+	SettlementAccountTypeCorporate SettlementAccountType = 0
+	// SettlementAccountTypePersonal 对私账户（法人或经营者本人银行卡）
+	SettlementAccountTypePersonal SettlementAccountType = 1
+)
+
+// settlementAccountTypeNames 结算账户类型名称
+var settlementAccountTypeNames = map[SettlementAccountType]string{
+	SettlementAccountTypeCorporate: "对公账户",
+	SettlementAccountTypePersonal:  "对私账户",
+}
+
+// String 返回结算账户类型名称，未知类型返回 SettlementAccountType(n)
+func (t SettlementAccountType) String() string {
+	if name, ok := settlementAccountTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("SettlementAccountType(%d)", int(t))
+}
+
+// IsValid 判断是否为 SDK 已知的结算账户类型
+func (t SettlementAccountType) IsValid() bool {
+	_, ok := settlementAccountTypeNames[t]
+	return ok
+}
+
+// InvoiceType 电子发票类型
+type InvoiceType int
+
+const (
+	// InvoiceTypeNormal 增值税电子普通发票
+	InvoiceTypeNormal InvoiceType = 0
+	// InvoiceTypeSpecial 增值税电子专用发票，仅企业抬头可开具
+	InvoiceTypeSpecial InvoiceType = 1
+)
+
+// invoiceTypeNames 电子发票类型名称
+var invoiceTypeNames = map[InvoiceType]string{
+	InvoiceTypeNormal:  "电子普通发票",
+	InvoiceTypeSpecial: "电子专用发票",
+}
+
+// String 返回电子发票类型名称，未知类型返回 InvoiceType(n)
+func (t InvoiceType) String() string {
+	if name, ok := invoiceTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("InvoiceType(%d)", int(t))
+}
+
+// IsValid 判断是否为 SDK 已知的电子发票类型
+func (t InvoiceType) IsValid() bool {
+	_, ok := invoiceTypeNames[t]
+	return ok
+}
+
+// InvoiceTitleType 发票抬头类型
+type InvoiceTitleType int
+
+const (
+	// InvoiceTitleTypePersonal 个人抬头
+	InvoiceTitleTypePersonal InvoiceTitleType = 0
+	// InvoiceTitleTypeEnterprise 企业抬头，需提供纳税人识别号
+	InvoiceTitleTypeEnterprise InvoiceTitleType = 1
+)
+
+// invoiceTitleTypeNames 发票抬头类型名称
+var invoiceTitleTypeNames = map[InvoiceTitleType]string{
+	InvoiceTitleTypePersonal:   "个人",
+	InvoiceTitleTypeEnterprise: "企业",
+}
+
+// String 返回发票抬头类型名称，未知类型返回 InvoiceTitleType(n)
+func (t InvoiceTitleType) String() string {
+	if name, ok := invoiceTitleTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("InvoiceTitleType(%d)", int(t))
+}
+
+// IsValid 判断是否为 SDK 已知的发票抬头类型
+func (t InvoiceTitleType) IsValid() bool {
+	_, ok := invoiceTitleTypeNames[t]
+	return ok
+}
+
+// CouponType 优惠券类型
+type CouponType int
+
+const (
+	// CouponTypeCash 代金券，按固定面额抵扣
+	CouponTypeCash CouponType = 0
+	// CouponTypeDiscount 折扣券，按订单金额的百分比抵扣
+	CouponTypeDiscount CouponType = 1
+)
+
+// couponTypeNames 优惠券类型名称
+var couponTypeNames = map[CouponType]string{
+	CouponTypeCash:     "代金券",
+	CouponTypeDiscount: "折扣券",
+}
+
+// String 返回优惠券类型名称，未知类型返回 CouponType(n)
+func (t CouponType) String() string {
+	if name, ok := couponTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("CouponType(%d)", int(t))
+}
+
+// IsValid 判断是否为 SDK 已知的优惠券类型
+func (t CouponType) IsValid() bool {
+	_, ok := couponTypeNames[t]
+	return ok
+}
+
+// RiskLevel 风控评估的风险等级
+type RiskLevel int
+
+const (
+	// RiskLevelLow 低风险
+	RiskLevelLow RiskLevel = 0
+	// RiskLevelMedium 中风险，可能要求补充验证或人工审核
+	RiskLevelMedium RiskLevel = 1
+	// RiskLevelHigh 高风险，交易可能被挂起或拒绝
+	RiskLevelHigh RiskLevel = 2
+)
+
+// riskLevelNames 风险等级名称
+var riskLevelNames = map[RiskLevel]string{
+	RiskLevelLow:    "低风险",
+	RiskLevelMedium: "中风险",
+	RiskLevelHigh:   "高风险",
+}
+
+// String 返回风险等级名称，未知等级返回 RiskLevel(n)
+func (l RiskLevel) String() string {
+	if name, ok := riskLevelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("RiskLevel(%d)", int(l))
+}
@@ -0,0 +1,61 @@
+package haozpay
+
+import (
+	"context"
+	"time"
+)
+
+// PingResult 网关连通性检查的结果
+type PingResult struct {
+	// Endpoint 本次检查使用的网关地址
+	Endpoint string
+	// Latency 请求耗时，包括签名、网络往返和响应验签
+	Latency time.Duration
+	// ServerTime 网关的服务器时间，网关未返回时为零值
+	ServerTime time.Time
+	// ClockSkew 本地时钟与服务器时间的偏差（本地时间减去服务器时间），按请求耗时的中点估算
+	// 偏差过大会导致请求时间戳校验失败，网关未返回服务器时间时为 0
+	ClockSkew time.Duration
+}
+
+// Ping 调用网关的服务器时间接口，检查网络连通性和签名配置
+// 请求与业务接口一样经过签名，配置了平台公钥时同时验证响应签名，
+// 调用成功说明网关可达、商户私钥与平台登记的公钥匹配，适用于就绪探针和启动自检
+//
+// 默认不重试，Latency 为单次请求的耗时；可传入 WithRequestTimeout 限制探测的等待时间
+//
+// 参数:
+//   - ctx: 上下文
+//   - opts: 单次调用的请求选项
+//
+// 返回:
+//   - *PingResult: 请求耗时、服务器时间和时钟偏差
+//   - error: 网关不可达时返回网络错误，签名不匹配时返回平台的验签失败错误，响应验签失败时错误包装 ErrSignatureInvalid
+//
+// 示例:
+//
+//	http.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+//	    result, err := client.Ping(r.Context(), haozpay.WithRequestTimeout(3*time.Second))
+//	    if err != nil {
+//	        http.Error(w, err.Error(), http.StatusServiceUnavailable)
+//	        return
+//	    }
+//	    fmt.Fprintf(w, "ok latency=%s skew=%s", result.Latency, result.ClockSkew)
+//	})
+func (c *Client) Ping(ctx context.Context, opts ...RequestOption) (*PingResult, error) {
+	opts = append([]RequestOption{WithNoRetry()}, opts...)
+
+	start := time.Now()
+	resp, err := call[ServerTimeResponse](ctx, c.executor, "/pay-core/system/time", &ServerTimeRequest{}, "failed to ping gateway", opts...)
+	if err != nil {
+		return nil, err
+	}
+	latency := time.Since(start)
+
+	result := &PingResult{Endpoint: c.ActiveEndpoint(), Latency: latency}
+	if resp != nil && resp.ServerTime > 0 {
+		result.ServerTime = time.UnixMilli(resp.ServerTime)
+		result.ClockSkew = start.Add(latency / 2).Sub(result.ServerTime)
+	}
+	return result, nil
+}
@@ -0,0 +1,81 @@
+package haozpay
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type PreAuthService struct {
+	executor *apiExecutor
+}
+
+func NewPreAuthService(client *resty.Client, config *Config) *PreAuthService {
+	return &PreAuthService{
+		executor: newAPIExecutor(client, config),
+	}
+}
+
+func (s *PreAuthService) CreatePreAuth(ctx context.Context, req *CreatePreAuthRequest, opts ...RequestOption) (*PreAuthResponse, error) {
+	if !req.PayType.IsValid() {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid payType: %d", req.PayType),
+			StatusCode: 0,
+		}
+	}
+	if req.FreezeAmount <= 0 {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid freezeAmount: %s", req.FreezeAmount),
+			StatusCode: 0,
+		}
+	}
+
+	var resp *PreAuthResponse
+	if err := s.executor.post(ctx, "/pay-core/preauth/create", req, &resp, "failed to create pre-authorization", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *PreAuthService) CapturePreAuth(ctx context.Context, req *CapturePreAuthRequest, opts ...RequestOption) (*CapturePreAuthResponse, error) {
+	if req.CaptureAmount <= 0 {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid captureAmount: %s", req.CaptureAmount),
+			StatusCode: 0,
+		}
+	}
+
+	var resp *CapturePreAuthResponse
+	if err := s.executor.post(ctx, "/pay-core/preauth/capture", req, &resp, "failed to capture pre-authorization", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *PreAuthService) ReleasePreAuth(ctx context.Context, req *ReleasePreAuthRequest, opts ...RequestOption) (*ReleasePreAuthResponse, error) {
+	if req.ReleaseAmount < 0 {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid releaseAmount: %s", req.ReleaseAmount),
+			StatusCode: 0,
+		}
+	}
+
+	var resp *ReleasePreAuthResponse
+	if err := s.executor.post(ctx, "/pay-core/preauth/release", req, &resp, "failed to release pre-authorization", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *PreAuthService) QueryPreAuth(ctx context.Context, req *QueryPreAuthRequest, opts ...RequestOption) (*QueryPreAuthResponse, error) {
+	var resp *QueryPreAuthResponse
+	if err := s.executor.post(ctx, "/pay-core/preauth/query", req, &resp, "failed to query pre-authorization", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,105 @@
+package haozpay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// proxySchemes 支持的代理协议
+// socks5 与 socks5h 相同，均由代理服务器解析目标域名
+var proxySchemes = map[string]bool{
+	"http":    true,
+	"https":   true,
+	"socks5":  true,
+	"socks5h": true,
+}
+
+// ProxyError 代理服务器返回的错误
+// 连接代理失败、代理认证失败或代理拒绝建立隧道时，SDKError.Err 为该类型，可通过 errors.As 取得
+type ProxyError struct {
+	// Proxy 代理服务器地址，不含密码
+	Proxy string
+	// StatusCode 代理服务器对 CONNECT 请求的响应状态码，例如 407 表示代理认证失败；连接代理失败时为 0
+	StatusCode int
+	// Err 原始错误
+	Err error
+}
+
+// Error 实现 error 接口
+func (e *ProxyError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("proxy %s responded %d %s", e.Proxy, e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("proxy %s: %v", e.Proxy, e.Err)
+}
+
+// Unwrap 返回原始错误
+func (e *ProxyError) Unwrap() error {
+	return e.Err
+}
+
+// Temporary 判断错误是否为暂时性错误
+// 连接代理失败和代理返回 5xx 时重试可能恢复，代理认证失败（407）、拒绝访问（403）等重试也无法恢复
+func (e *ProxyError) Temporary() bool {
+	return e.StatusCode == 0 || e.StatusCode >= 500
+}
+
+// parseProxyURL 解析代理地址，设置了 ProxyUsername 时覆盖地址中的认证信息
+func parseProxyURL(proxy, username, password string) (*url.URL, error) {
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return nil, err
+	}
+	if !proxySchemes[u.Scheme] {
+		return nil, fmt.Errorf("unsupported proxy scheme %q, expected http, https, socks5 or socks5h", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, errors.New("proxy host is required")
+	}
+	if username != "" {
+		u.User = url.UserPassword(username, password)
+	}
+	return u, nil
+}
+
+// useProxy 为传输层设置代理
+// HTTP 代理的认证信息通过 Proxy-Authorization 头发送，SOCKS5 代理使用用户名密码认证
+func useProxy(transport *http.Transport, proxyURL *url.URL) {
+	redacted := proxyURL.Redacted()
+	transport.Proxy = http.ProxyURL(proxyURL)
+	transport.OnProxyConnectResponse = func(ctx context.Context, _ *url.URL, _ *http.Request, resp *http.Response) error {
+		if resp.StatusCode == http.StatusOK {
+			return nil
+		}
+		return &ProxyError{Proxy: redacted, StatusCode: resp.StatusCode, Err: errors.New(resp.Status)}
+	}
+}
+
+// proxyTransport 将连接代理失败的错误包装为 ProxyError 的传输层
+type proxyTransport struct {
+	base  http.RoundTripper
+	proxy string
+}
+
+// RoundTrip 实现 http.RoundTripper 接口
+func (t *proxyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err == nil {
+		return resp, nil
+	}
+
+	var proxyErr *ProxyError
+	if errors.As(err, &proxyErr) {
+		return nil, err
+	}
+	// 连接 HTTP 代理失败时为 proxyconnect，SOCKS5 握手或认证失败时为 socks connect
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && (opErr.Op == "proxyconnect" || opErr.Op == "socks connect") {
+		return nil, &ProxyError{Proxy: t.proxy, Err: err}
+	}
+	return nil, err
+}
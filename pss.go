@@ -0,0 +1,98 @@
+package haozpay
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// RSAPSSSigner 基于 RSA 私钥的 RSASSA-PSS 签名器
+// 对签名串的 SHA256 摘要进行 PSS 签名，盐长度与摘要长度相同（32 字节）
+// 需配合 Config.WithSignType(SignTypeRSAPSS) 使用
+type RSAPSSSigner struct {
+	privateKey *rsa.PrivateKey
+}
+
+// NewRSAPSSSigner 使用 PEM 格式的 RSA 私钥创建 PSS 签名器
+//
+// 参数:
+//   - privateKeyPEM: 商户RSA私钥（支持纯私钥字符串或完整PEM格式，PKCS#1 和 PKCS#8 均可）
+//
+// 返回:
+//   - *RSAPSSSigner: 签名器实例
+//   - error: 私钥解析失败时返回错误
+func NewRSAPSSSigner(privateKeyPEM string) (*RSAPSSSigner, error) {
+	privateKey, err := parsePrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	return NewRSAPSSSignerFromKey(privateKey), nil
+}
+
+// NewRSAPSSSignerFromKey 使用已解析的 RSA 私钥创建 PSS 签名器
+func NewRSAPSSSignerFromKey(privateKey *rsa.PrivateKey) *RSAPSSSigner {
+	return &RSAPSSSigner{privateKey: privateKey}
+}
+
+// Sign 实现 Signer 接口
+// digest 为 SHA256 摘要的 HEX 字符串，解码后按 RSASSA-PSS 签名
+func (s *RSAPSSSigner) Sign(digest []byte) ([]byte, error) {
+	hashed, err := decodePSSDigest(digest)
+	if err != nil {
+		return nil, err
+	}
+	return rsa.SignPSS(rand.Reader, s.privateKey, crypto.SHA256, hashed, &rsa.PSSOptions{
+		SaltLength: rsa.PSSSaltLengthEqualsHash,
+	})
+}
+
+// rsaPSSVerifier 基于 RSA 公钥的 RSASSA-PSS 验签器
+type rsaPSSVerifier struct {
+	publicKey *rsa.PublicKey
+}
+
+// Verify 实现 Verifier 接口
+// 自动识别签名的盐长度，兼容未使用摘要长度作为盐长度的签名方
+func (v *rsaPSSVerifier) Verify(digest, signature []byte) error {
+	hashed, err := decodePSSDigest(digest)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSignatureInvalid, err)
+	}
+	if err := rsa.VerifyPSS(v.publicKey, crypto.SHA256, hashed, signature, &rsa.PSSOptions{
+		SaltLength: rsa.PSSSaltLengthAuto,
+	}); err != nil {
+		return fmt.Errorf("%w: rsa-pss verify failed: %v", ErrSignatureInvalid, err)
+	}
+	return nil
+}
+
+// rsaPSSAlgorithm SHA256 摘要 + RSASSA-PSS 签名
+type rsaPSSAlgorithm struct{}
+
+func (rsaPSSAlgorithm) Digest(signString string) string {
+	return sha256Hex(signString)
+}
+
+func (rsaPSSAlgorithm) NewSigner(privateKeyPEM string) (Signer, error) {
+	return NewRSAPSSSigner(privateKeyPEM)
+}
+
+func (rsaPSSAlgorithm) NewVerifier(publicKey crypto.PublicKey) (Verifier, error) {
+	pub, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("RSA public key is required, got %T", publicKey)
+	}
+	return &rsaPSSVerifier{publicKey: pub}, nil
+}
+
+// decodePSSDigest 将 SHA256 摘要的 HEX 字符串解码为原始摘要
+func decodePSSDigest(digest []byte) ([]byte, error) {
+	hashed := make([]byte, hex.DecodedLen(len(digest)))
+	if _, err := hex.Decode(hashed, digest); err != nil || len(hashed) != sha256.Size {
+		return nil, fmt.Errorf("digest must be a hex encoded SHA256 hash")
+	}
+	return hashed, nil
+}
@@ -0,0 +1,37 @@
+package haozpay
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/time/rate"
+)
+
+// newRateLimiter 根据配置创建令牌桶限流器，未开启限流时返回 nil
+func newRateLimiter(qps float64, burst int) *rate.Limiter {
+	if qps <= 0 {
+		return nil
+	}
+	if burst <= 0 {
+		burst = int(math.Ceil(qps))
+	}
+	return rate.NewLimiter(rate.Limit(qps), burst)
+}
+
+// rateLimitMiddleware 请求限流中间件
+// 在请求发送前等待令牌，context 取消或等待时间超过 context 截止时间时返回错误
+//
+// 参数:
+//   - limiter: 令牌桶限流器
+//
+// 返回:
+//   - resty.RequestMiddleware: resty 请求中间件函数
+func rateLimitMiddleware(limiter *rate.Limiter) resty.RequestMiddleware {
+	return func(c *resty.Client, r *resty.Request) error {
+		if err := limiter.Wait(r.Context()); err != nil {
+			return fmt.Errorf("rate limit wait failed: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,160 @@
+package haozpay
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var (
+	// ErrReceiptFailed 平台生成电子回单失败
+	ErrReceiptFailed = errors.New("receipt generation failed")
+	// ErrReceiptHashMismatch 电子回单文件摘要与平台返回的摘要不一致
+	ErrReceiptHashMismatch = errors.New("receipt file hash mismatch")
+)
+
+func (s *PaymentService) ApplyReceipt(ctx context.Context, req *ApplyReceiptRequest, opts ...RequestOption) (*ReceiptResponse, error) {
+	var resp *ReceiptResponse
+	if err := s.executor.post(ctx, "/pay-core/receipt/apply", req, &resp, "failed to apply receipt", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *PaymentService) QueryReceipt(ctx context.Context, req *QueryReceiptRequest, opts ...RequestOption) (*ReceiptResponse, error) {
+	var resp *ReceiptResponse
+	if err := s.executor.post(ctx, "/pay-core/receipt/query", req, &resp, "failed to query receipt", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// DownloadReceipt 下载订单的电子回单（PDF）并写入 w
+//
+// 处理流程:
+//  1. 申请生成电子回单，同一订单重复申请返回同一份回单
+//  2. 回单生成中时按 WaitOptions 的默认退避参数轮询，直到生成成功、失败或 ctx 结束
+//  3. 携带文件令牌下载文件并写入 w，写入时同步计算摘要，写入完毕后与平台返回的文件摘要比对
+//
+// 参数:
+//   - ctx: 上下文，用于控制最长等待时间和文件下载过程
+//   - orderNo: 平台订单号
+//   - w: 回单文件的写入目标，例如 *os.File
+//   - opts: 每次申请、查询和下载使用的请求选项，可通过 WithDownloadProgress 获取下载进度
+//
+// 返回:
+//   - *ReceiptResponse: 回单信息；生成失败时为最后一次查询到的回单
+//   - error: 生成失败时返回 ErrReceiptFailed，摘要不一致时返回 ErrReceiptHashMismatch，
+//     ctx 结束时返回 ctx.Err()，其他情况返回接口调用或写入错误
+//
+// 注意: 文件边下载边写入 w，返回 ErrReceiptHashMismatch 时 w 中已写入的内容不可信，应丢弃
+//
+// 示例:
+//
+//	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+//	defer cancel()
+//
+//	f, err := os.Create(orderNo + ".pdf")
+//	if err != nil {
+//	    return err
+//	}
+//	defer f.Close()
+//
+//	receipt, err := client.Payment.DownloadReceipt(ctx, orderNo, f)
+func (s *PaymentService) DownloadReceipt(ctx context.Context, orderNo string, w io.Writer, opts ...RequestOption) (*ReceiptResponse, error) {
+	if orderNo == "" || w == nil {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "orderNo and writer are required",
+			StatusCode: 0,
+		}
+	}
+
+	receipt, err := s.ApplyReceipt(ctx, &ApplyReceiptRequest{OrderNo: orderNo}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, &SDKError{
+			Code:       ErrInvalidResponse.Code,
+			Message:    "receipt response is missing",
+			StatusCode: 0,
+		}
+	}
+
+	receipt, err = s.waitForReceipt(ctx, receipt, opts)
+	if err != nil {
+		return receipt, err
+	}
+	if receipt.ReceiptStatus == ReceiptStatusFailed {
+		return receipt, fmt.Errorf("%w: %s", ErrReceiptFailed, receipt.FailReason)
+	}
+	if receipt.DownloadUrl == "" {
+		return receipt, &SDKError{
+			Code:       ErrInvalidResponse.Code,
+			Message:    "receipt download url is missing",
+			StatusCode: 0,
+		}
+	}
+
+	if err := s.downloadReceipt(ctx, receipt, w, newRequestOptions(opts)); err != nil {
+		return receipt, err
+	}
+	return receipt, nil
+}
+
+// waitForReceipt 轮询电子回单状态，直到生成完毕或 ctx 结束
+// 查询遇到网络错误、HTTP 429 或 5xx 时继续轮询，其他错误立即返回
+func (s *PaymentService) waitForReceipt(ctx context.Context, receipt *ReceiptResponse, opts []RequestOption) (*ReceiptResponse, error) {
+	options := (*WaitOptions)(nil).withDefaults()
+	interval := options.InitialInterval
+
+	req := &QueryReceiptRequest{OrderNo: receipt.OrderNo, ReceiptNo: receipt.ReceiptNo}
+	for !receipt.ReceiptStatus.IsFinal() {
+		if err := sleepContext(ctx, options.jittered(interval)); err != nil {
+			return receipt, err
+		}
+
+		latest, err := s.QueryReceipt(ctx, req, opts...)
+		switch {
+		case err == nil && latest != nil:
+			receipt = latest
+		case err != nil && ctx.Err() != nil:
+			return receipt, ctx.Err()
+		case err != nil && !isTransientError(err):
+			return receipt, err
+		}
+
+		interval = options.next(interval)
+	}
+	return receipt, nil
+}
+
+// downloadReceipt 下载电子回单文件并写入 w，平台返回文件摘要时校验摘要
+func (s *PaymentService) downloadReceipt(ctx context.Context, receipt *ReceiptResponse, w io.Writer, options *requestOptions) error {
+	body, err := s.executor.download(ctx, receipt.DownloadUrl, receipt.FileToken, receipt.FileSize, options, "failed to download receipt")
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	if receipt.FileHash == "" {
+		_, err = io.Copy(w, body)
+		return err
+	}
+
+	h, err := newFileHash(receipt.HashType)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(io.MultiWriter(w, h), body); err != nil {
+		return err
+	}
+	if !strings.EqualFold(hex.EncodeToString(h.Sum(nil)), receipt.FileHash) {
+		return ErrReceiptHashMismatch
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+module github.com/haoz-cloud/haozpay-sdk/redis
+
+go 1.23.0
+
+require (
+	github.com/haoz-cloud/haozpay-sdk v1.0.0
+	github.com/redis/go-redis/v9 v9.7.3
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.2.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+	github.com/emmansun/gmsm v0.30.1 // indirect
+	github.com/go-resty/resty/v2 v2.16.5 // indirect
+	golang.org/x/crypto v0.37.0 // indirect
+	golang.org/x/net v0.33.0 // indirect
+	golang.org/x/time v0.6.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/haoz-cloud/haozpay-sdk => ../
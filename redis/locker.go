@@ -0,0 +1,149 @@
+package haozpayredis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+const (
+	// DefaultLockKeyPrefix 订单锁的默认键前缀
+	DefaultLockKeyPrefix = "haozpay:lock:"
+	// DefaultLockTTL 订单锁的默认过期时间
+	// 持有锁期间按 ttl/3 的间隔续期，进程崩溃未释放的锁在 ttl 后自动过期
+	DefaultLockTTL = 30 * time.Second
+	// lockRetryInterval 锁被占用时重新尝试获取的间隔
+	lockRetryInterval = 50 * time.Millisecond
+	// unlockTimeout 释放锁的超时时间
+	unlockTimeout = 5 * time.Second
+)
+
+// unlockScript 只删除仍由当前持有者持有的锁，避免锁过期后误删其他进程的锁
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// extendScript 只为仍由当前持有者持有的锁续期
+var extendScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// Locker 基于 Redis 的 haozpay.Locker 实现
+// 使用 SET NX PX 获取锁，锁的值为每次加锁生成的随机令牌，释放和续期时校验令牌
+type Locker struct {
+	client    redis.UniversalClient
+	keyPrefix string
+	ttl       time.Duration
+}
+
+// NewLocker 创建基于 Redis 的订单锁
+//
+// 参数:
+//   - client: Redis 客户端，支持单机、哨兵和集群模式
+//   - keyPrefix: 键前缀，为空时使用 DefaultLockKeyPrefix
+//   - ttl: 锁的过期时间，小于等于 0 时使用 DefaultLockTTL
+//
+// 返回:
+//   - *Locker: 订单锁
+//
+// 示例:
+//
+//	config := haozpay.DefaultConfig().
+//	    WithLocker(haozpayredis.NewLocker(rdb, "", 0))
+func NewLocker(client redis.UniversalClient, keyPrefix string, ttl time.Duration) *Locker {
+	if keyPrefix == "" {
+		keyPrefix = DefaultLockKeyPrefix
+	}
+	if ttl <= 0 {
+		ttl = DefaultLockTTL
+	}
+	return &Locker{client: client, keyPrefix: keyPrefix, ttl: ttl}
+}
+
+// Lock 实现 haozpay.Locker 接口
+// 持有锁期间在后台续期，释放锁后停止续期
+func (l *Locker) Lock(ctx context.Context, key string) (func(), error) {
+	token, err := lockToken()
+	if err != nil {
+		return nil, err
+	}
+	key = l.keyPrefix + key
+
+	ticker := time.NewTicker(lockRetryInterval)
+	defer ticker.Stop()
+	for {
+		ok, err := l.client.SetNX(ctx, key, token, l.ttl).Result()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go l.keepAlive(key, token, stop, done)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(stop)
+			<-done
+
+			ctx, cancel := context.WithTimeout(context.Background(), unlockTimeout)
+			defer cancel()
+			// 释放失败时锁在 ttl 后自动过期
+			_ = unlockScript.Run(ctx, l.client, []string{key}, token).Err()
+		})
+	}, nil
+}
+
+// keepAlive 按 ttl/3 的间隔为锁续期，直到 stop 关闭或锁已不再由当前持有者持有
+func (l *Locker) keepAlive(key, token string, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), l.ttl/3)
+		n, err := extendScript.Run(ctx, l.client, []string{key}, token, l.ttl.Milliseconds()).Int()
+		cancel()
+		if err == nil && n == 0 {
+			return
+		}
+	}
+}
+
+// lockToken 生成锁的持有者令牌
+func lockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+var _ haozpay.Locker = (*Locker)(nil)
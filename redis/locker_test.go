@@ -0,0 +1,181 @@
+package haozpayredis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeRedis 只实现 Locker 使用的命令的内存 Redis，未实现的方法调用时 panic
+type fakeRedis struct {
+	redis.UniversalClient
+
+	mu      sync.Mutex
+	values  map[string]string
+	expires map[string]time.Time
+	// setErr 不为 nil 时 SetNX 返回该错误
+	setErr error
+}
+
+func newFakeRedis() *fakeRedis {
+	return &fakeRedis{values: make(map[string]string), expires: make(map[string]time.Time)}
+}
+
+// get 返回未过期的键值，调用方需持有 mu
+func (r *fakeRedis) get(key string) (string, bool) {
+	if expire, ok := r.expires[key]; ok && !time.Now().Before(expire) {
+		delete(r.values, key)
+		delete(r.expires, key)
+	}
+	value, ok := r.values[key]
+	return value, ok
+}
+
+// value 返回键的当前值
+func (r *fakeRedis) value(key string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, _ := r.get(key)
+	return value
+}
+
+// set 直接写入键值，模拟锁过期后被其他进程获取
+func (r *fakeRedis) set(key, value string, ttl time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values[key] = value
+	r.expires[key] = time.Now().Add(ttl)
+}
+
+func (r *fakeRedis) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.setErr != nil {
+		return redis.NewBoolResult(false, r.setErr)
+	}
+	if _, ok := r.get(key); ok {
+		return redis.NewBoolResult(false, nil)
+	}
+	r.values[key] = fmt.Sprint(value)
+	r.expires[key] = time.Now().Add(expiration)
+	return redis.NewBoolResult(true, nil)
+}
+
+// EvalSha 按脚本的 SHA1 执行 unlockScript 和 extendScript
+func (r *fakeRedis) EvalSha(ctx context.Context, sha1 string, keys []string, args ...interface{}) *redis.Cmd {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.get(keys[0])
+	if !ok || value != fmt.Sprint(args[0]) {
+		return redis.NewCmdResult(int64(0), nil)
+	}
+
+	switch sha1 {
+	case unlockScript.Hash():
+		delete(r.values, keys[0])
+		delete(r.expires, keys[0])
+	case extendScript.Hash():
+		r.expires[keys[0]] = time.Now().Add(time.Duration(args[1].(int64)) * time.Millisecond)
+	default:
+		return redis.NewCmdResult(nil, fmt.Errorf("unknown script %s", sha1))
+	}
+	return redis.NewCmdResult(int64(1), nil)
+}
+
+func TestLocker(t *testing.T) {
+	rdb := newFakeRedis()
+	locker := NewLocker(rdb, "", 0)
+	ctx := context.Background()
+
+	unlock, err := locker.Lock(ctx, "order:P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rdb.value(DefaultLockKeyPrefix+"order:P1") == "" {
+		t.Fatalf("lock key %s is not set", DefaultLockKeyPrefix+"order:P1")
+	}
+
+	// 锁被占用时按 lockRetryInterval 重试，直到 ctx 结束
+	waitCtx, cancel := context.WithTimeout(ctx, 3*lockRetryInterval)
+	defer cancel()
+	if _, err := locker.Lock(waitCtx, "order:P1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Lock() on a held key error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	other, err := locker.Lock(ctx, "order:P2")
+	if err != nil {
+		t.Fatalf("Lock() on another key error = %v", err)
+	}
+	other()
+
+	// 锁释放后等待的调用获取锁
+	acquired := make(chan error, 1)
+	go func() {
+		next, err := locker.Lock(ctx, "order:P1")
+		if err == nil {
+			next()
+		}
+		acquired <- err
+	}()
+	time.Sleep(lockRetryInterval / 2)
+	unlock()
+	if err := <-acquired; err != nil {
+		t.Fatalf("Lock() after unlock error = %v", err)
+	}
+	if value := rdb.value(DefaultLockKeyPrefix + "order:P1"); value != "" {
+		t.Errorf("lock key = %q after unlock, want deleted", value)
+	}
+}
+
+func TestLockerKeepAlive(t *testing.T) {
+	rdb := newFakeRedis()
+	const ttl = 60 * time.Millisecond
+	locker := NewLocker(rdb, "test:", ttl)
+	ctx := context.Background()
+
+	unlock, err := locker.Lock(ctx, "order:P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 持有锁期间按 ttl/3 续期，超过 ttl 后锁仍有效
+	time.Sleep(3 * ttl)
+	if rdb.value("test:order:P1") == "" {
+		t.Fatal("lock expired while held")
+	}
+	unlock()
+	unlock()
+	if rdb.value("test:order:P1") != "" {
+		t.Error("lock key is not deleted after unlock")
+	}
+}
+
+func TestLockerUnlockChecksToken(t *testing.T) {
+	rdb := newFakeRedis()
+	locker := NewLocker(rdb, "test:", time.Minute)
+
+	unlock, err := locker.Lock(context.Background(), "order:P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 锁过期后被其他进程获取，原持有者释放时不删除其他进程的锁
+	rdb.set("test:order:P1", "other", time.Minute)
+	unlock()
+	if value := rdb.value("test:order:P1"); value != "other" {
+		t.Errorf("lock key = %q, want the other holder's token", value)
+	}
+}
+
+func TestLockerError(t *testing.T) {
+	rdb := newFakeRedis()
+	rdb.setErr = errors.New("connection refused")
+
+	if _, err := NewLocker(rdb, "", 0).Lock(context.Background(), "order:P1"); !errors.Is(err, rdb.setErr) {
+		t.Fatalf("Lock() error = %v, want %v", err, rdb.setErr)
+	}
+}
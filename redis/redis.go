@@ -0,0 +1,104 @@
+// Package haozpayredis 提供皓臻支付回调通知防重放、去重记录和订单锁的 Redis 实现，适用于多实例部署
+//
+// 示例:
+//
+//	rdb := redis.NewClient(&redis.Options{Addr: "127.0.0.1:6379"})
+//	config := haozpay.DefaultConfig().
+//	    WithNotifyNonceStore(haozpayredis.NewNonceStore(rdb, "")).
+//	    WithNotifyDedupStore(haozpayredis.NewDedupStore(rdb, "", 24*time.Hour)).
+//	    WithLocker(haozpayredis.NewLocker(rdb, "", 30*time.Second))
+package haozpayredis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+const (
+	// DefaultNonceKeyPrefix 防重放记录的默认键前缀
+	DefaultNonceKeyPrefix = "haozpay:notify:nonce:"
+	// DefaultDedupKeyPrefix 通知处理记录的默认键前缀
+	DefaultDedupKeyPrefix = "haozpay:notify:dedup:"
+)
+
+// NonceStore 基于 Redis 的 haozpay.NonceStore 实现
+// 使用 SET NX 原子地记录通知标识，记录按 ttl 自动过期
+type NonceStore struct {
+	client    redis.UniversalClient
+	keyPrefix string
+}
+
+// NewNonceStore 创建基于 Redis 的防重放记录
+//
+// 参数:
+//   - client: Redis 客户端，支持单机、哨兵和集群模式
+//   - keyPrefix: 键前缀，为空时使用 DefaultNonceKeyPrefix
+//
+// 返回:
+//   - *NonceStore: 防重放记录
+func NewNonceStore(client redis.UniversalClient, keyPrefix string) *NonceStore {
+	if keyPrefix == "" {
+		keyPrefix = DefaultNonceKeyPrefix
+	}
+	return &NonceStore{client: client, keyPrefix: keyPrefix}
+}
+
+// Add 实现 haozpay.NonceStore 接口
+func (s *NonceStore) Add(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, s.keyPrefix+nonce, 1, ttl).Result()
+}
+
+// Remove 实现 haozpay.NonceStore 接口
+func (s *NonceStore) Remove(ctx context.Context, nonce string) error {
+	return s.client.Del(ctx, s.keyPrefix+nonce).Err()
+}
+
+// DedupStore 基于 Redis 的 haozpay.DedupStore 实现
+// 处理成功的通知标识写入 Redis，记录按 ttl 自动过期
+type DedupStore struct {
+	client    redis.UniversalClient
+	keyPrefix string
+	ttl       time.Duration
+}
+
+// NewDedupStore 创建基于 Redis 的通知处理记录
+//
+// 参数:
+//   - client: Redis 客户端，支持单机、哨兵和集群模式
+//   - keyPrefix: 键前缀，为空时使用 DefaultDedupKeyPrefix
+//   - ttl: 记录保留时间，小于等于 0 时使用 haozpay.DefaultDedupTTL
+//
+// 返回:
+//   - *DedupStore: 通知处理记录
+func NewDedupStore(client redis.UniversalClient, keyPrefix string, ttl time.Duration) *DedupStore {
+	if keyPrefix == "" {
+		keyPrefix = DefaultDedupKeyPrefix
+	}
+	if ttl <= 0 {
+		ttl = haozpay.DefaultDedupTTL
+	}
+	return &DedupStore{client: client, keyPrefix: keyPrefix, ttl: ttl}
+}
+
+// Processed 实现 haozpay.DedupStore 接口
+func (s *DedupStore) Processed(ctx context.Context, id string) (bool, error) {
+	n, err := s.client.Exists(ctx, s.keyPrefix+id).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// MarkProcessed 实现 haozpay.DedupStore 接口
+func (s *DedupStore) MarkProcessed(ctx context.Context, id string) error {
+	return s.client.Set(ctx, s.keyPrefix+id, 1, s.ttl).Err()
+}
+
+var (
+	_ haozpay.NonceStore = (*NonceStore)(nil)
+	_ haozpay.DedupStore = (*DedupStore)(nil)
+)
@@ -0,0 +1,122 @@
+package haozpay
+
+import (
+	"context"
+	"fmt"
+)
+
+// RefundableAmount 订单的累计退款情况和剩余可退款金额
+type RefundableAmount struct {
+	// OrderNo 平台订单号
+	OrderNo string
+	// OrderStatus 订单状态
+	OrderStatus OrderStatus
+	// Currency 币种
+	Currency Currency
+	// PaidAmount 订单实付金额，即累计退款金额的上限
+	PaidAmount Money
+	// RefundedAmount 退款成功的累计金额
+	RefundedAmount Money
+	// PendingAmount 退款处理中的金额，处理结果未确定前同样占用可退款金额
+	PendingAmount Money
+	// Refundable 剩余可退款金额，订单未支付成功时为 0
+	Refundable Money
+}
+
+// QueryRefundableAmount 查询订单的剩余可退款金额
+//
+// 查询订单实付金额和订单的全部退款记录：退款成功和处理中的退款占用可退款金额，
+// 失败和关闭的退款不占用
+//
+// 参数:
+//   - ctx: 上下文
+//   - orderNo: 平台订单号
+//   - opts: 订单查询和退款记录查询使用的请求选项
+//
+// 返回:
+//   - *RefundableAmount: 订单的累计退款情况
+//   - error: 查询失败时返回错误
+//
+// 示例:
+//
+//	refundable, err := client.Payment.QueryRefundableAmount(ctx, orderNo)
+//	if err != nil {
+//	    return err
+//	}
+//	log.Printf("已退款 %s，可退款 %s", refundable.RefundedAmount, refundable.Refundable)
+func (s *PaymentService) QueryRefundableAmount(ctx context.Context, orderNo string, opts ...RequestOption) (*RefundableAmount, error) {
+	if orderNo == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "orderNo is required",
+			StatusCode: 0,
+		}
+	}
+
+	order, err := s.QueryOrder(ctx, &QueryOrderRequest{OrderNo: orderNo}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if order == nil {
+		return nil, &SDKError{
+			Code:       ErrInvalidResponse.Code,
+			Message:    "order response is missing",
+			StatusCode: 0,
+		}
+	}
+	refunds, err := s.ListRefunds(ctx, orderNo, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := &RefundableAmount{
+		OrderNo:     orderNo,
+		OrderStatus: order.OrderStatus,
+		Currency:    order.Currency.OrDefault(),
+		PaidAmount:  order.PaidAmount,
+	}
+	for _, refund := range refunds.Refunds {
+		switch refund.RefundStatus {
+		case RefundStatusSuccess:
+			amount.RefundedAmount += refund.RefundAmount
+		case RefundStatusProcessing:
+			amount.PendingAmount += refund.RefundAmount
+		}
+	}
+	// 退款记录为空时使用平台返回的汇总金额
+	if len(refunds.Refunds) == 0 {
+		amount.RefundedAmount = refunds.TotalRefundAmount
+	}
+
+	if order.OrderStatus.IsSuccess() {
+		amount.Refundable = amount.PaidAmount - amount.RefundedAmount - amount.PendingAmount
+		if amount.Refundable < 0 {
+			amount.Refundable = 0
+		}
+	}
+	return amount, nil
+}
+
+// checkRefundable 校验退款金额不超过订单的剩余可退款金额，Config.RefundAmountCheck 开启时由 CreateRefund 调用
+func (s *PaymentService) checkRefundable(ctx context.Context, req *CreateRefundRequest, opts []RequestOption) error {
+	amount, err := s.QueryRefundableAmount(ctx, req.OrderNo, opts...)
+	if err != nil {
+		return err
+	}
+	if !amount.OrderStatus.IsSuccess() {
+		return &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("order %s is not refundable, status: %s", req.OrderNo, amount.OrderStatus),
+			StatusCode: 0,
+		}
+	}
+	if req.RefundAmount > amount.Refundable {
+		return &SDKError{
+			Code: ErrInvalidParameter.Code,
+			Message: fmt.Sprintf("refundAmount %s exceeds refundable amount %s (paid %s, refunded %s, pending %s)",
+				req.RefundAmount, amount.Refundable, amount.PaidAmount, amount.RefundedAmount, amount.PendingAmount),
+			StatusCode: 0,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package haozpay
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultKeyFileWatchInterval WatchPrivateKeyFile 检查密钥文件的默认间隔
+const DefaultKeyFileWatchInterval = 10 * time.Second
+
+// UpdateConfig 在运行时替换客户端配置，用于不停机轮换商户私钥、切换商户号或调整重试策略
+// 新配置通过校验后整体替换客户端由配置派生的状态（签名器、平台公钥、底层 HTTP 客户端及其中间件），
+// 客户端的业务服务、收银台链接生成器和之后发起的调用立即使用新配置；已发出的调用（包括其重试）继续使用原配置
+//
+// 参数:
+//   - cfg: 新的客户端配置，复制后使用，之后修改 cfg 不影响客户端
+//
+// 返回:
+//   - error: 新配置验证失败，或修改了 Proxy、TLSConfig、客户端证书、SandboxInsecureSkipVerify 等传输层配置时返回错误，
+//     此时客户端继续使用原配置
+//
+// 注意:
+//   - 传输层和连接池保持不变，因此传输层配置不能通过 UpdateConfig 修改，需要时使用 NewClient 创建客户端
+//   - 已注册的调用钩子保持不变；汇率、渠道状态、银行信息等缓存保留，缓存时长不随新配置调整
+//   - 限流令牌桶和多网关地址的可用状态按新配置重新创建
+//   - 通过 Clone 派生的客户端不受影响
+//
+// 示例:
+//
+//	cfg := client.GetConfig().WithPrivateKey(newPrivateKeyPEM)
+//	if err := client.UpdateConfig(cfg); err != nil {
+//	    log.Printf("rotate private key: %v", err)
+//	}
+func (c *Client) UpdateConfig(cfg *Config) error {
+	return c.updateConfig(func(*Config) *Config { return cfg.Clone() })
+}
+
+// updateConfig 根据当前配置生成新配置并替换客户端状态，多个更新串行执行
+func (c *Client) updateConfig(next func(current *Config) *Config) error {
+	c.updateMu.Lock()
+	defer c.updateMu.Unlock()
+
+	current := c.state.Load().config
+	cfg := next(current)
+	if transportConfigChanged(current, cfg) {
+		return ErrInvalidConfig("Proxy, proxy credentials, TLSConfig, client certificate and SandboxInsecureSkipVerify cannot be changed by UpdateConfig")
+	}
+	state, _, err := newClientState(cfg, c.transport)
+	if err != nil {
+		return err
+	}
+	c.state.Store(state)
+	return nil
+}
+
+// WatchPrivateKeyFile 定期读取商户私钥文件，内容变化时通过 UpdateConfig 使用新私钥，直到 ctx 结束
+// 用于私钥由密钥管理系统或部署工具写入文件的场景；读取失败或新私钥无效（例如文件尚未写完）时
+// 以 Warn 级别输出日志并继续使用原私钥，文件再次变化时重试
+// 配置了自定义 Signer 时签名不使用 PrivateKey，无需监听私钥文件
+//
+// 参数:
+//   - ctx: 控制监听的生命周期
+//   - path: 私钥文件路径，内容为 PEM 格式的商户私钥
+//   - interval: 检查间隔，小于等于 0 时使用 DefaultKeyFileWatchInterval
+//
+// 返回:
+//   - error: ctx 结束时返回 ctx.Err()
+//
+// 示例:
+//
+//	go client.WatchPrivateKeyFile(ctx, "/etc/haozpay/merchant_private.pem", 0)
+func (c *Client) WatchPrivateKeyFile(ctx context.Context, path string, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultKeyFileWatchInterval
+	}
+
+	// last 最近一次尝试使用的私钥，内容未变化时不重复更新
+	last := strings.TrimSpace(c.state.Load().config.PrivateKey)
+	for {
+		if key, err := readKeyFile(path); err != nil {
+			c.state.Load().logger.Warn("[SDK] failed to read private key file", "path", path, "error", err)
+		} else if key != last {
+			last = key
+			err := c.updateConfig(func(current *Config) *Config {
+				return current.Clone().WithPrivateKey(key)
+			})
+			if err != nil {
+				c.state.Load().logger.Warn("[SDK] failed to reload private key", "path", path, "error", err)
+			} else {
+				c.state.Load().logger.Info("[SDK] private key reloaded", "path", path)
+			}
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// readKeyFile 读取密钥文件并去除首尾空白
+func readKeyFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return "", fmt.Errorf("key file %s is empty", path)
+	}
+	return key, nil
+}
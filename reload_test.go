@@ -0,0 +1,195 @@
+package haozpay_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+	"github.com/haoz-cloud/haozpay-sdk/haozpaytest"
+)
+
+// lockedBuffer 可在多个 goroutine 中并发写入和读取的日志输出
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// newPrivateKeyPEM 生成 PEM 格式的 RSA 商户私钥
+func newPrivateKeyPEM(t *testing.T) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+}
+
+// waitFor 等待 cond 成立，超时后测试失败
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestUpdateConfigRejected(t *testing.T) {
+	const path = "/pay-core/payment/order/query"
+	server, client := newTestGateway(t)
+	server.Respond(path, &haozpay.QueryOrderResponse{OrderNo: "P1"})
+	merchantNo := client.GetConfig().MerchantNo
+
+	tests := []struct {
+		name   string
+		modify func(cfg *haozpay.Config)
+	}{
+		{name: "proxy", modify: func(cfg *haozpay.Config) { cfg.WithProxy("http://127.0.0.1:3128") }},
+		{name: "proxy credentials", modify: func(cfg *haozpay.Config) { cfg.WithProxyAuth("user", "secret") }},
+		{name: "client certificate", modify: func(cfg *haozpay.Config) { cfg.ClientCertPEM = "cert" }},
+		{name: "invalid config", modify: func(cfg *haozpay.Config) { cfg.WithMerchantNo("") }},
+		{name: "invalid private key", modify: func(cfg *haozpay.Config) { cfg.WithPrivateKey("not a key") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := client.GetConfig().WithMerchantNo("M2")
+			tt.modify(cfg)
+			if err := client.UpdateConfig(cfg); err == nil {
+				t.Fatal("UpdateConfig() succeeded, want error")
+			}
+
+			// 更新失败时客户端继续使用原配置
+			if got := client.GetConfig().MerchantNo; got != merchantNo {
+				t.Errorf("MerchantNo = %q, want %q", got, merchantNo)
+			}
+			if _, err := client.Payment.QueryOrder(context.Background(), &haozpay.QueryOrderRequest{OrderNo: "P1"}); err != nil {
+				t.Errorf("QueryOrder() after rejected update error = %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdateConfigInFlight(t *testing.T) {
+	const path = "/pay-core/payment/order/query"
+	server, client := newTestGateway(t, func(cfg *haozpay.Config) {
+		cfg.WithRetry(1, time.Millisecond, time.Millisecond)
+	})
+	oldMerchantNo := client.GetConfig().MerchantNo
+
+	// 第一次请求阻塞到配置更新之后，再返回 503 触发重试
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	server.Handle(path, func(req *haozpaytest.Request) (interface{}, error) {
+		first := false
+		once.Do(func() { first = true })
+		if first {
+			close(started)
+			<-release
+			return nil, &haozpaytest.Error{StatusCode: http.StatusServiceUnavailable, Code: haozpaytest.CodeInternalError, Message: "unavailable"}
+		}
+		return &haozpay.QueryOrderResponse{OrderNo: "P1"}, nil
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Payment.QueryOrder(context.Background(), &haozpay.QueryOrderRequest{OrderNo: "P1"})
+		done <- err
+	}()
+	<-started
+	if err := client.UpdateConfig(client.GetConfig().WithMerchantNo("M2")); err != nil {
+		t.Fatalf("UpdateConfig() error = %v", err)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("in-flight QueryOrder() error = %v", err)
+	}
+
+	// 已发出的调用及其重试使用原配置，之后的调用使用新配置
+	if _, err := client.Payment.QueryOrder(context.Background(), &haozpay.QueryOrderRequest{OrderNo: "P1"}); err != nil {
+		t.Fatalf("QueryOrder() after update error = %v", err)
+	}
+	var merchantNos []string
+	for _, req := range server.Requests() {
+		merchantNos = append(merchantNos, req.MerchantNo)
+	}
+	if want := []string{oldMerchantNo, oldMerchantNo, "M2"}; strings.Join(merchantNos, ",") != strings.Join(want, ",") {
+		t.Errorf("request merchantNo = %q, want %q", merchantNos, want)
+	}
+}
+
+func TestWatchPrivateKeyFile(t *testing.T) {
+	logs := &lockedBuffer{}
+	_, client := newTestGateway(t, func(cfg *haozpay.Config) {
+		cfg.WithLogger(haozpay.NewStdLogger(logs, haozpay.LogLevelInfo))
+	})
+	keyFile := filepath.Join(t.TempDir(), "merchant_private.pem")
+	privateKey := func() string { return strings.TrimSpace(client.GetConfig().PrivateKey) }
+
+	first := newPrivateKeyPEM(t)
+	if err := os.WriteFile(keyFile, []byte(first), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- client.WatchPrivateKeyFile(ctx, keyFile, 10*time.Millisecond) }()
+	defer func() {
+		cancel()
+		if err := <-done; err != context.Canceled {
+			t.Errorf("WatchPrivateKeyFile() = %v, want %v", err, context.Canceled)
+		}
+	}()
+
+	waitFor(t, "the first key", func() bool { return privateKey() == strings.TrimSpace(first) })
+
+	// 写了一半的私钥无法解析，输出日志并继续使用原私钥
+	if err := os.WriteFile(keyFile, []byte(first[:len(first)/2]), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the reload warning", func() bool { return strings.Contains(logs.String(), "failed to reload private key") })
+	if privateKey() != strings.TrimSpace(first) {
+		t.Fatal("private key changed after an invalid key file")
+	}
+
+	// 空文件同样保留原私钥
+	if err := os.WriteFile(keyFile, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the read warning", func() bool { return strings.Contains(logs.String(), "failed to read private key file") })
+	if privateKey() != strings.TrimSpace(first) {
+		t.Fatal("private key changed after an empty key file")
+	}
+
+	// 文件再次变化为有效私钥时使用新私钥
+	second := newPrivateKeyPEM(t)
+	if err := os.WriteFile(keyFile, []byte(second), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the second key", func() bool { return privateKey() == strings.TrimSpace(second) })
+}
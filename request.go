@@ -0,0 +1,237 @@
+package haozpay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// apiExecutor 业务接口的公共请求流程
+// 负责序列化业务参数（加密敏感字段）、封装请求报文、发送请求并检查业务响应码
+// 签名、验签、日志和 HTTP 错误处理由客户端注册的中间件完成
+type apiExecutor struct {
+	// state 客户端的当前状态，同一客户端的业务服务共享，每次调用开始时读取一次
+	state *atomic.Pointer[clientState]
+	// hooks 客户端注册的调用钩子，单独创建的服务为 nil
+	hooks *callHooks
+}
+
+// newAPIExecutor 创建业务接口请求执行器
+func newAPIExecutor(client *resty.Client, config *Config) *apiExecutor {
+	logger := config.Logger
+	if logger == nil {
+		logger = defaultLogger(config.Debug)
+	}
+	state := &atomic.Pointer[clientState]{}
+	state.Store(&clientState{
+		config:      config,
+		restyClient: client,
+		encryptor:   newFieldEncryptor(config.PublicKey),
+		logger:      logger,
+		codec:       codecOrDefault(config.Codec),
+	})
+	return &apiExecutor{state: state}
+}
+
+// current 返回客户端的当前状态
+func (e *apiExecutor) current() *clientState {
+	return e.state.Load()
+}
+
+// post 发送业务请求
+// 每次调用携带客户端请求ID（X-Request-Id），context 中未指定时自动生成，
+// 平台未返回请求ID时返回的 SDKError 使用客户端请求ID；
+// context 中通过 WithTraceContext 指定的链路追踪信息作为 traceparent、tracestate 请求头发送
+// 发送前后分别执行客户端注册的 OnBeforeCall 和 OnAfterCall 钩子
+//
+// 参数:
+//   - ctx: 上下文
+//   - path: 接口路径
+//   - req: 业务参数，序列化为 bizBody
+//   - data: 响应 data 的解析目标，为 nil 时忽略响应数据
+//     传入指向结构体指针的指针（例如 **QueryOrderResponse）时，响应 data 为空会得到 nil
+//   - errMessage: 请求执行失败时的错误描述，例如 "failed to query order"
+//   - opts: 单次调用的请求选项
+//
+// 返回:
+//   - error: 序列化失败、请求失败或业务响应码非 0 时返回 SDKError
+func (e *apiExecutor) post(ctx context.Context, path string, req interface{}, data interface{}, errMessage string, opts ...RequestOption) error {
+	return e.execute(ctx, http.MethodPost, path, req, data, errMessage, opts)
+}
+
+// get 发送 GET 业务请求，用于只读的查询接口
+// 业务参数的各字段与 merchantNo、timestamp、signType、sign 作为查询参数发送，
+// 签名串规则与 post 一致；其余参数和返回值与 post 相同
+func (e *apiExecutor) get(ctx context.Context, path string, req interface{}, data interface{}, errMessage string, opts ...RequestOption) error {
+	return e.execute(ctx, http.MethodGet, path, req, data, errMessage, opts)
+}
+
+// execute 执行业务请求，处理请求ID、耗时统计、调用指标、慢请求日志和调用钩子
+func (e *apiExecutor) execute(ctx context.Context, method, path string, req interface{}, data interface{}, errMessage string, opts []RequestOption) error {
+	state := e.current()
+	options := newRequestOptions(opts)
+	ctx, requestID := ensureRequestID(ctx)
+	ctx = withCallInfo(ctx, path, state.config.MerchantNo)
+	ctx, stats := withCallStats(ctx, state.config.Trace, options)
+
+	metrics := state.config.Metrics
+	if metrics != nil {
+		metrics.CallStarted(path)
+	}
+	e.hooks.beforeCall(ctx, path, req)
+	start := time.Now()
+	err := e.send(ctx, state, method, path, req, data, errMessage, requestID, options)
+	elapsed := time.Since(start)
+	if metrics != nil {
+		metrics.CallFinished(path, newCallResult(err, options.attempts, elapsed))
+	}
+	if stats != nil {
+		stats.Total = elapsed
+	}
+	if threshold := state.config.SlowRequestThreshold; threshold > 0 && elapsed >= threshold {
+		keyvals := []interface{}{
+			"duration", elapsed,
+			"retries", max(options.attempts-1, 0),
+		}
+		if err != nil {
+			keyvals = append(keyvals, "error", err)
+		}
+		callLogger(ctx, state.logger).Warn("[SDK] slow request", keyvals...)
+	}
+	if err != nil {
+		e.hooks.afterCall(ctx, path, nil, err)
+	} else {
+		e.hooks.afterCall(ctx, path, data, nil)
+	}
+	return err
+}
+
+// send 使用 state 序列化业务参数并发送请求，其余参数与 post 一致
+func (e *apiExecutor) send(ctx context.Context, state *clientState, method, path string, req interface{}, data interface{}, errMessage string, requestID string, options *requestOptions) error {
+	bizBodyBytes, err := marshalBizBody(req, state.encryptor, state.codec)
+	if err != nil {
+		return &SDKError{
+			Code:       ErrInvalidResponse.Code,
+			Message:    fmt.Sprintf("failed to marshal request: %v", err),
+			RequestID:  requestID,
+			StatusCode: 0,
+			Err:        err,
+		}
+	}
+
+	timestamp := currentTimestampMillis()
+	var body interface{} = &HaozPayRequest{
+		MerchantNo: state.config.MerchantNo,
+		Timestamp:  timestamp,
+		BizBody:    string(bizBodyBytes),
+	}
+	// 旧版接口只接受表单，GET 接口的参数放在查询串中，上传接口使用 multipart，
+	// 业务参数均平铺为顶层字段后由签名中间件签名并编码
+	if options.encoding == RequestEncodingForm || method == http.MethodGet || options.file != nil {
+		formReq, err := newFormRequest(state.config.MerchantNo, timestamp, bizBodyBytes)
+		if err != nil {
+			return &SDKError{
+				Code:       ErrInvalidParameter.Code,
+				Message:    fmt.Sprintf("failed to encode request parameters: %v", err),
+				RequestID:  requestID,
+				StatusCode: 0,
+				Err:        err,
+			}
+		}
+		body = formReq
+		if options.file != nil {
+			body = newMultipartRequest(formReq, options.file)
+		}
+	}
+
+	var result struct {
+		Response
+		Data interface{} `json:"data"`
+	}
+	result.Data = data
+
+	r := state.restyClient.R().
+		SetContext(options.context(ctx)).
+		SetHeader(RequestIDHeader, requestID)
+	injectTraceContext(ctx, r.Header)
+	e.hooks.requestHeader(ctx, path, r.Header)
+	r.SetHeaders(options.headers).
+		SetBody(body).
+		SetResult(&result)
+	stats := CallStatsFromContext(ctx)
+	if stats != nil {
+		r.EnableTrace()
+	}
+	resp, err := r.Execute(method, path)
+	if resp != nil && resp.Request != nil {
+		options.attempts = resp.Request.Attempt
+	}
+	if stats != nil {
+		stats.record(resp)
+	}
+
+	if err != nil {
+		err = requestError(err, errMessage)
+		var sdkErr *SDKError
+		if errors.As(err, &sdkErr) {
+			setGatewayRequestID(ctx, sdkErr.RequestID)
+		}
+		return attachResponse(attachRequestID(err, requestID), resp)
+	}
+
+	setGatewayRequestID(ctx, result.RequestID)
+	if result.Code != 0 {
+		return attachResponse(attachRequestID(responseError(&result.Response, 0), requestID), resp)
+	}
+
+	if options.envelope != nil {
+		*options.envelope = result.Response
+	}
+	return nil
+}
+
+// requestError 包装请求执行错误
+// 中间件返回的 SDKError（如错误响应、验签失败）保持原样返回，
+// 其他错误按 transportErrorCode 转换为预定义错误码的 SDKError，并保留原始错误
+func requestError(err error, message string) error {
+	var sdkErr *SDKError
+	if errors.As(err, &sdkErr) {
+		return sdkErr
+	}
+
+	return &SDKError{
+		Code:       transportErrorCode(err),
+		Message:    fmt.Sprintf("%s: %v", message, err),
+		StatusCode: 0,
+		Err:        err,
+	}
+}
+
+// attachResponse 将平台响应的 HTTP 状态码、响应头和原始报文附加到 SDKError
+// 未设置状态码的错误（业务错误、响应格式错误）使用响应的状态码，原始报文超过 MaxErrorBodySize 时截断
+func attachResponse(err error, resp *resty.Response) error {
+	var sdkErr *SDKError
+	if resp == nil || resp.RawResponse == nil || !errors.As(err, &sdkErr) {
+		return err
+	}
+	if sdkErr.StatusCode == 0 {
+		sdkErr.StatusCode = resp.StatusCode()
+	}
+	sdkErr.Header = resp.Header().Clone()
+	body := resp.Body()
+	if len(body) > MaxErrorBodySize {
+		body = body[:MaxErrorBodySize]
+		sdkErr.RawBodyTruncated = true
+	}
+	sdkErr.RawBody = append([]byte(nil), body...)
+	return err
+}
+
+func currentTimestampMillis() int64 {
+	return time.Now().UnixMilli()
+}
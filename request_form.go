@@ -0,0 +1,179 @@
+package haozpay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RequestEncoding 请求报文的编码方式
+type RequestEncoding int
+
+const (
+	// RequestEncodingJSON JSON 格式的 HaozPayRequest 报文，业务参数序列化为 bizBody（默认）
+	RequestEncodingJSON RequestEncoding = iota
+	// RequestEncodingForm application/x-www-form-urlencoded 表单，用于只接受表单的旧版接口
+	// 业务参数与 merchantNo、timestamp、signType、sign 平铺为顶层字段，对象和数组类型的参数编码为
+	// 键按字典序排列的紧凑 JSON 字符串，与 JSON 报文中对应参数在签名串中的文本一致
+	RequestEncodingForm
+)
+
+// WithRequestEncoding 设置本次调用的请求报文编码方式
+// 签名串的构建规则不变：业务参数的各字段加上 merchantNo 和 timestamp，按参数名排序，空值跳过
+//
+// 参数:
+//   - encoding: 请求报文编码方式
+//
+// 示例:
+//
+//	resp, err := haozpay.Do[BankListResponse](ctx, client, "/pay-core/legacy/bank/list", req,
+//	    haozpay.WithRequestEncoding(haozpay.RequestEncodingForm))
+func WithRequestEncoding(encoding RequestEncoding) RequestOption {
+	return func(o *requestOptions) {
+		o.encoding = encoding
+	}
+}
+
+// formRequest 表单格式的请求报文
+// 由 signatureMiddleware 签名后编码为表单，GET 请求编码为查询串
+type formRequest struct {
+	// MerchantNo 商户编号
+	MerchantNo string
+	// Timestamp 请求时间戳（毫秒）
+	Timestamp int64
+	// Fields 业务参数，值为参与签名和发送的文本
+	Fields map[string]string
+}
+
+// newFormRequest 将序列化后的业务参数展开为表单请求
+// 字符串和数字保留原始文本，布尔值为 true/false，对象和数组为键按字典序排列的紧凑 JSON 字符串（canonicalJSON），
+// null 和空字符串不发送
+func newFormRequest(merchantNo string, timestamp int64, bizBody []byte) (*formRequest, error) {
+	decoder := json.NewDecoder(bytes.NewReader(bizBody))
+	decoder.UseNumber()
+
+	var raw map[string]json.RawMessage
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("form encoded request must be a JSON object: %w", err)
+	}
+
+	fields := make(map[string]string, len(raw))
+	for key, value := range raw {
+		text, err := formFieldValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field %s: %w", key, err)
+		}
+		if strings.TrimSpace(text) != "" {
+			fields[key] = text
+		}
+	}
+	return &formRequest{MerchantNo: merchantNo, Timestamp: timestamp, Fields: fields}, nil
+}
+
+// formFieldValue 返回 JSON 值在表单中的文本
+func formFieldValue(value json.RawMessage) (string, error) {
+	trimmed := bytes.TrimSpace(value)
+	if len(trimmed) == 0 {
+		return "", nil
+	}
+	switch trimmed[0] {
+	case '"':
+		var s string
+		if err := json.Unmarshal(trimmed, &s); err != nil {
+			return "", err
+		}
+		return s, nil
+	case '{', '[':
+		decoder := json.NewDecoder(bytes.NewReader(trimmed))
+		decoder.UseNumber()
+		var v interface{}
+		if err := decoder.Decode(&v); err != nil {
+			return "", err
+		}
+		return canonicalJSON(v)
+	case 'n':
+		return "", nil
+	default:
+		// 数字和布尔值
+		return string(trimmed), nil
+	}
+}
+
+// formSignParams 返回表单请求的签名参数：业务参数的各字段加上 merchantNo 和 timestamp
+func formSignParams(formReq *formRequest) map[string]interface{} {
+	params := make(map[string]interface{}, len(formReq.Fields)+2)
+	for k, v := range formReq.Fields {
+		params[k] = v
+	}
+	params["merchantNo"] = formReq.MerchantNo
+	params["timestamp"] = formReq.Timestamp
+	return params
+}
+
+// signFormRequest 对表单请求签名并设置请求体，GET 请求设置查询串
+func signFormRequest(r *resty.Request, formReq *formRequest, signer Signer, signType SignType) error {
+	sign, err := GenerateSignWithSignType(formSignParams(formReq), signer, signType)
+	if err != nil {
+		return fmt.Errorf("failed to generate signature: %w", err)
+	}
+
+	form := make(url.Values, len(formReq.Fields)+4)
+	for k, v := range formReq.Fields {
+		form.Set(k, v)
+	}
+	form.Set("merchantNo", formReq.MerchantNo)
+	form.Set("timestamp", strconv.FormatInt(formReq.Timestamp, 10))
+	form.Set("signType", string(signType))
+	form.Set("sign", sign)
+
+	// GET 请求不发送请求体（resty 不会发送 GET 请求的 Body），参数按规范编码后追加到请求地址
+	// 重试时 resty 会还原请求地址并重新执行中间件，因此保留 Body 以便重新签名
+	if r.Method == http.MethodGet {
+		separator := "?"
+		if strings.Contains(r.URL, "?") {
+			separator = "&"
+		}
+		r.URL += separator + encodeQuery(form)
+		return nil
+	}
+
+	r.SetHeader("Content-Type", "application/x-www-form-urlencoded")
+	r.SetBody(form.Encode())
+	return nil
+}
+
+// encodeQuery 按参数名升序编码查询串
+// 按 RFC 3986 编码：除字母、数字和 -._~ 外的字节均编码为 %XX，空格编码为 %20 而不是 +，
+// 避免网关按路径规则解码时把 + 当作字面量，导致验签时的参数值与签名串不一致
+func encodeQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, key := range keys {
+		for _, value := range values[key] {
+			if sb.Len() > 0 {
+				sb.WriteByte('&')
+			}
+			sb.WriteString(escapeQueryComponent(key))
+			sb.WriteByte('=')
+			sb.WriteString(escapeQueryComponent(value))
+		}
+	}
+	return sb.String()
+}
+
+// escapeQueryComponent 按 RFC 3986 编码查询参数的名称或值
+func escapeQueryComponent(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
@@ -0,0 +1,179 @@
+package haozpay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// uploadFile 随请求上传的文件
+type uploadFile struct {
+	// field 文件在表单中的字段名
+	field string
+	// name 文件名
+	name string
+	// content 文件内容，每次发送（包括重试）前回到开头
+	content io.ReadSeeker
+}
+
+// withUploadFile 以 multipart/form-data 格式发送请求并附带文件，供上传类接口使用
+func withUploadFile(file *uploadFile) RequestOption {
+	return func(o *requestOptions) {
+		o.file = file
+	}
+}
+
+// multipartRequest multipart/form-data 格式的请求报文
+// 签名参数与表单请求一致，文件内容不参与签名（由业务参数中的文件摘要覆盖）
+//
+// 请求体在发送时边读取文件边编码，不会将整个文件读入内存；
+// 实现 io.ReadCloser，resty 直接将其作为请求体，重试时由 signatureMiddleware 重新签名并从文件开头重新编码
+type multipartRequest struct {
+	form *formRequest
+	file *uploadFile
+
+	mu          sync.Mutex
+	sign        string
+	signType    SignType
+	boundary    string
+	body        *io.PipeReader
+	writerDone  chan struct{}
+	contentType string
+}
+
+// newMultipartRequest 创建 multipart 请求
+func newMultipartRequest(form *formRequest, file *uploadFile) *multipartRequest {
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+	return &multipartRequest{
+		form:        form,
+		file:        file,
+		boundary:    boundary,
+		contentType: "multipart/form-data; boundary=" + boundary,
+	}
+}
+
+// Read 实现 io.Reader 接口，首次读取时开始编码请求体
+func (m *multipartRequest) Read(p []byte) (int, error) {
+	m.mu.Lock()
+	if m.body == nil {
+		m.start()
+	}
+	body := m.body
+	m.mu.Unlock()
+	return body.Read(p)
+}
+
+// Close 实现 io.Closer 接口，结束本次请求体的编码
+func (m *multipartRequest) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stop()
+	return nil
+}
+
+// MarshalJSON 调试日志输出签名参数和文件名，不输出文件内容
+func (m *multipartRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"fields": m.form.Fields,
+		"file":   m.file.name,
+	})
+}
+
+// reset 设置本次发送的签名，并结束上一次发送未完成的编码
+func (m *multipartRequest) reset(sign string, signType SignType) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stop()
+	m.sign = sign
+	m.signType = signType
+}
+
+// start 从文件开头开始编码请求体，调用方需持有 mu
+func (m *multipartRequest) start() {
+	reader, writer := io.Pipe()
+	done := make(chan struct{})
+	m.body = reader
+	m.writerDone = done
+
+	go func() {
+		defer close(done)
+		writer.CloseWithError(m.write(writer))
+	}()
+}
+
+// stop 结束正在进行的编码并等待其退出，调用方需持有 mu
+func (m *multipartRequest) stop() {
+	if m.body == nil {
+		return
+	}
+	_ = m.body.CloseWithError(io.ErrClosedPipe)
+	<-m.writerDone
+	m.body = nil
+	m.writerDone = nil
+}
+
+// write 编码 multipart 请求体：签名参数字段在前，文件在后
+func (m *multipartRequest) write(w io.Writer) error {
+	if _, err := m.file.content.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind upload file: %w", err)
+	}
+
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary(m.boundary); err != nil {
+		return err
+	}
+
+	fields := make(map[string]string, len(m.form.Fields)+4)
+	for k, v := range m.form.Fields {
+		fields[k] = v
+	}
+	fields["merchantNo"] = m.form.MerchantNo
+	fields["timestamp"] = strconv.FormatInt(m.form.Timestamp, 10)
+	fields["signType"] = string(m.signType)
+	fields["sign"] = m.sign
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := mw.WriteField(key, fields[key]); err != nil {
+			return err
+		}
+	}
+
+	part, err := mw.CreateFormFile(m.file.field, m.file.name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, m.file.content); err != nil {
+		return fmt.Errorf("failed to read upload file: %w", err)
+	}
+	return mw.Close()
+}
+
+// signMultipartRequest 对 multipart 请求签名并设置请求头
+func signMultipartRequest(r *resty.Request, req *multipartRequest, signer Signer, signType SignType) error {
+	params := make(map[string]interface{}, len(req.form.Fields)+2)
+	for k, v := range req.form.Fields {
+		params[k] = v
+	}
+	params["merchantNo"] = req.form.MerchantNo
+	params["timestamp"] = req.form.Timestamp
+
+	sign, err := GenerateSignWithSignType(params, signer, signType)
+	if err != nil {
+		return fmt.Errorf("failed to generate signature: %w", err)
+	}
+
+	req.reset(sign, signType)
+	r.SetHeader("Content-Type", req.contentType)
+	return nil
+}
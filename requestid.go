@@ -0,0 +1,110 @@
+package haozpay
+
+import (
+	"context"
+	"errors"
+)
+
+// RequestIDHeader 客户端请求ID的请求头
+// SDK 为每次接口调用生成请求ID并通过该请求头发送，便于与平台日志关联排查问题
+const RequestIDHeader = "X-Request-Id"
+
+// requestIDKey context 中存储客户端请求ID的键
+type requestIDKey struct{}
+
+// WithRequestID 为接口调用指定客户端请求ID
+// 未指定时 SDK 为每次调用自动生成请求ID；同一次调用的重试使用相同的请求ID
+//
+// 参数:
+//   - ctx: 调用使用的 context
+//   - requestID: 客户端请求ID，例如业务系统的链路追踪ID
+//
+// 返回:
+//   - context.Context: 携带请求ID的 context
+//
+// 示例:
+//
+//	ctx = haozpay.WithRequestID(ctx, traceID)
+//	order, err := client.Payment.QueryOrder(ctx, req)
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext 获取 context 中的客户端请求ID，未设置时返回空字符串
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// ensureRequestID 返回 context 中的客户端请求ID，未设置时生成新的请求ID并写入 context
+func ensureRequestID(ctx context.Context) (context.Context, string) {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		return ctx, requestID
+	}
+	requestID, err := randomNonce()
+	if err != nil {
+		return ctx, ""
+	}
+	return WithRequestID(ctx, requestID), requestID
+}
+
+// callInfoKey context 中存储接口调用信息的键
+type callInfoKey struct{}
+
+// callInfo 接口调用信息，附加到调用期间输出的日志，并记录平台返回的请求ID
+type callInfo struct {
+	op         string
+	merchantNo string
+	// gatewayRequestID 平台响应中的请求ID，收到响应后写入
+	gatewayRequestID string
+}
+
+// withCallInfo 在 context 中记录接口路径和商户号
+func withCallInfo(ctx context.Context, op, merchantNo string) context.Context {
+	return context.WithValue(ctx, callInfoKey{}, &callInfo{op: op, merchantNo: merchantNo})
+}
+
+// callInfoFromContext 获取 context 中的接口调用信息，不在接口调用中时返回 nil
+func callInfoFromContext(ctx context.Context) *callInfo {
+	if ctx == nil {
+		return nil
+	}
+	info, _ := ctx.Value(callInfoKey{}).(*callInfo)
+	return info
+}
+
+// setGatewayRequestID 记录平台响应中的请求ID
+func setGatewayRequestID(ctx context.Context, requestID string) {
+	if info := callInfoFromContext(ctx); info != nil {
+		info.gatewayRequestID = requestID
+	}
+}
+
+// GatewayRequestIDFromContext 获取平台响应中的请求ID（响应报文的 request_id），用于 AfterCallHook 关联平台日志
+// 调用成功和失败时均可获取；未收到平台响应、平台未返回请求ID或不在接口调用中时返回空字符串
+//
+// 示例:
+//
+//	client.OnAfterCall(func(ctx context.Context, op string, resp interface{}, err error) {
+//	    span := trace.SpanFromContext(ctx)
+//	    span.SetAttributes(attribute.String("haozpay.gateway_request_id", haozpay.GatewayRequestIDFromContext(ctx)))
+//	})
+func GatewayRequestIDFromContext(ctx context.Context) string {
+	if info := callInfoFromContext(ctx); info != nil {
+		return info.gatewayRequestID
+	}
+	return ""
+}
+
+// attachRequestID 为 SDKError 补充客户端请求ID
+// 平台返回了请求ID时保持不变
+func attachRequestID(err error, requestID string) error {
+	var sdkErr *SDKError
+	if requestID != "" && errors.As(err, &sdkErr) && sdkErr.RequestID == "" {
+		sdkErr.RequestID = requestID
+	}
+	return err
+}
@@ -0,0 +1,151 @@
+package haozpay
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RetryPolicy 单次调用的重试策略
+type RetryPolicy int
+
+const (
+	// RetryDefault 按接口类型决定是否重试（默认）
+	// 查询类等幂等接口在网络错误、超时、HTTP 429 和 5xx 时重试；
+	// 下单、退款、提现等变更类接口不重试，避免重复扣款
+	RetryDefault RetryPolicy = iota
+	// RetryAlways 在网络错误、超时、HTTP 429 和 5xx 时总是重试
+	// 仅在调用方确认该次调用幂等时使用（例如使用相同商户订单号重复下单会被平台拒绝）
+	RetryAlways
+	// RetryNever 不重试
+	RetryNever
+)
+
+// retryPolicyKey context 中存储重试策略的键
+type retryPolicyKey struct{}
+
+// WithRetryPolicy 为单次调用设置重试策略
+// 重试次数和等待时间仍使用 Config.WithRetry 的配置
+//
+// 参数:
+//   - ctx: 调用使用的 context
+//   - policy: 重试策略
+//
+// 返回:
+//   - context.Context: 携带重试策略的 context
+//
+// 示例:
+//
+//	// 查询接口本次调用不重试
+//	ctx = haozpay.WithRetryPolicy(ctx, haozpay.RetryNever)
+//	order, err := client.Payment.QueryOrder(ctx, req)
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+// retryPolicyFromContext 获取 context 中的重试策略，未设置时返回 RetryDefault
+func retryPolicyFromContext(ctx context.Context) RetryPolicy {
+	if ctx == nil {
+		return RetryDefault
+	}
+	if policy, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy); ok {
+		return policy
+	}
+	return RetryDefault
+}
+
+// idempotentPaths 幂等接口路径，RetryDefault 策略下只有这些接口会重试
+var idempotentPaths = map[string]bool{
+	"/pay-core/payment/order/query":      true,
+	"/pay-core/payment/order/list":       true,
+	"/pay-core/payment/refund/query":     true,
+	"/pay-core/payment/refund/list":      true,
+	"/pay-core/withdraw/query":           true,
+	"/pay-core/transfer/query":           true,
+	"/pay-core/transfer/batch/query":     true,
+	"/pay-core/bill/statement/apply":     true,
+	"/pay-core/contract/query":           true,
+	"/pay-core/preauth/query":            true,
+	"/pay-core/merchant/apply/query":     true,
+	"/pay-core/exchange/rate/query":      true,
+	"/pay-core/merchant/key/check":       true,
+	"/pay-core/receipt/apply":            true,
+	"/pay-core/receipt/query":            true,
+	"/pay-core/invoice/query":            true,
+	"/pay-core/coupon/query":             true,
+	"/pay-core/coupon/order/query":       true,
+	"/pay-core/risk/order/query":         true,
+	"/pay-core/system/time":              true,
+	"/pay-core/merchant/config/query":    true,
+	"/pay-core/channel/status/query":     true,
+	"/pay-core/bank/list/query":          true,
+	"/pay-core/bank/card-bin/query":      true,
+	"/pay-core/account/card/list":        true,
+	"/pay-core/account/transaction/list": true,
+}
+
+// isIdempotentPath 判断请求路径是否为幂等接口
+// BaseURL 可能带有路径前缀，因此按后缀匹配
+func isIdempotentPath(path string) bool {
+	for idempotentPath := range idempotentPaths {
+		if strings.HasSuffix(path, idempotentPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryCondition 重试条件
+// 替换 resty 的默认重试判断（默认对所有网络错误重试，包括下单等变更类接口）
+//
+// 判断逻辑:
+//  1. 根据 context 中的重试策略和接口类型判断是否允许重试
+//  2. 允许重试时，仅在网络错误、超时、HTTP 429 和 5xx 时重试
+func retryCondition(resp *resty.Response, err error) bool {
+	// 请求未发送（例如签名失败、限流等待被取消）时不重试
+	if resp == nil || resp.Request == nil {
+		return false
+	}
+
+	switch retryPolicyFromContext(resp.Request.Context()) {
+	case RetryNever:
+		return false
+	case RetryDefault:
+		// GET 接口均为只读查询
+		if resp.Request.RawRequest == nil ||
+			(resp.Request.Method != http.MethodGet && !isIdempotentPath(resp.Request.RawRequest.URL.Path)) {
+			return false
+		}
+	}
+
+	return isRetryableFailure(resp, err)
+}
+
+// isRetryableFailure 判断失败是否可以通过重试恢复
+func isRetryableFailure(resp *resty.Response, err error) bool {
+	statusCode := resp.StatusCode()
+	if statusCode == http.StatusTooManyRequests || statusCode >= 500 {
+		return true
+	}
+
+	if err == nil {
+		return false
+	}
+
+	// 中间件返回的 SDKError 按错误码判断，业务错误、验签失败等重试也无法恢复
+	var sdkErr *SDKError
+	if errors.As(err, &sdkErr) {
+		return sdkErr.Retryable()
+	}
+
+	var proxyErr *ProxyError
+	if errors.As(err, &proxyErr) {
+		return proxyErr.Temporary()
+	}
+
+	// 其余为网络错误或超时
+	return !errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,94 @@
+package haozpay_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+	"github.com/haoz-cloud/haozpay-sdk/haozpaytest"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	const (
+		queryPath  = "/pay-core/payment/order/query"
+		refundPath = "/pay-core/payment/refund"
+	)
+	unavailable := &haozpaytest.Error{StatusCode: http.StatusServiceUnavailable, Code: haozpaytest.CodeInternalError, Message: "unavailable"}
+
+	tests := []struct {
+		name   string
+		policy *haozpay.RetryPolicy
+		path   string
+		call   func(ctx context.Context, client *haozpay.Client) error
+		// want 网关收到的请求数
+		want int
+	}{
+		{
+			name: "query is retried by default",
+			path: queryPath,
+			call: func(ctx context.Context, client *haozpay.Client) error {
+				_, err := client.Payment.QueryOrder(ctx, &haozpay.QueryOrderRequest{OrderNo: "P1"})
+				return err
+			},
+			want: 2,
+		},
+		{
+			name: "refund is not retried by default",
+			path: refundPath,
+			call: func(ctx context.Context, client *haozpay.Client) error {
+				_, err := client.Payment.CreateRefund(ctx, &haozpay.CreateRefundRequest{OrderNo: "P1", RefundAmount: haozpay.Fen(100)})
+				return err
+			},
+			want: 1,
+		},
+		{
+			name:   "RetryAlways retries refund",
+			policy: policyPtr(haozpay.RetryAlways),
+			path:   refundPath,
+			call: func(ctx context.Context, client *haozpay.Client) error {
+				_, err := client.Payment.CreateRefund(ctx, &haozpay.CreateRefundRequest{OrderNo: "P1", RefundAmount: haozpay.Fen(100)})
+				return err
+			},
+			want: 2,
+		},
+		{
+			name:   "RetryNever does not retry query",
+			policy: policyPtr(haozpay.RetryNever),
+			path:   queryPath,
+			call: func(ctx context.Context, client *haozpay.Client) error {
+				_, err := client.Payment.QueryOrder(ctx, &haozpay.QueryOrderRequest{OrderNo: "P1"})
+				return err
+			},
+			want: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, client := newTestGateway(t, func(cfg *haozpay.Config) {
+				cfg.WithRetry(1, time.Millisecond, time.Millisecond)
+			})
+			// 第一次请求平台不可用，第二次请求成功
+			server.Respond(queryPath, unavailable, &haozpay.QueryOrderResponse{OrderNo: "P1"})
+			server.Respond(refundPath, unavailable, &haozpay.RefundResponse{OrderNo: "P1"})
+
+			ctx := context.Background()
+			if tt.policy != nil {
+				ctx = haozpay.WithRetryPolicy(ctx, *tt.policy)
+			}
+			err := tt.call(ctx, client)
+			if n := countRequests(server, tt.path); n != tt.want {
+				t.Fatalf("gateway received %d requests, want %d (err = %v)", n, tt.want, err)
+			}
+			if (err == nil) != (tt.want == 2) {
+				t.Errorf("call error = %v", err)
+			}
+		})
+	}
+}
+
+func policyPtr(policy haozpay.RetryPolicy) *haozpay.RetryPolicy {
+	return &policy
+}
@@ -0,0 +1,336 @@
+package haozpay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultRetryQueueMaxAttempts RetryQueue 默认的最大尝试次数（含首次请求）
+	DefaultRetryQueueMaxAttempts = 20
+	// DefaultRetryQueuePollInterval RetryQueue.Run 默认的队列扫描间隔
+	DefaultRetryQueuePollInterval = 5 * time.Second
+	// DefaultRetryQueueBatchSize RetryQueue 每次扫描默认处理的最大任务数
+	DefaultRetryQueueBatchSize = 100
+)
+
+// RetryTaskKind 重试任务类型
+type RetryTaskKind string
+
+const (
+	// RetryTaskRefund 退款
+	RetryTaskRefund RetryTaskKind = "refund"
+	// RetryTaskWithdraw 提现
+	RetryTaskWithdraw RetryTaskKind = "withdraw"
+)
+
+// RetryTask 重试队列中等待重新提交的请求
+type RetryTask struct {
+	// ID 任务标识，提现为 withdraw:{reqSeqId}，退款为 refund:{orderNo}:{随机串}
+	ID string `json:"id"`
+	// Kind 任务类型
+	Kind RetryTaskKind `json:"kind"`
+	// Refund 退款请求，Kind 为 RetryTaskRefund 时有效
+	Refund *CreateRefundRequest `json:"refund,omitempty"`
+	// Withdraw 提现请求，Kind 为 RetryTaskWithdraw 时有效
+	Withdraw *CreateWithdrawRequest `json:"withdraw,omitempty"`
+	// Attempts 已尝试的次数（含首次请求）
+	Attempts int `json:"attempts"`
+	// NextAttemptAt 下一次尝试的时间
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+	// LastError 最近一次失败的错误信息
+	LastError string `json:"lastError,omitempty"`
+	// Uncertain 此前任一次失败时请求是否可能已被平台处理（例如请求超时），重新提交前需先查询结果
+	// 一旦为 true 不再恢复为 false：之后的失败即使确定未被处理，也无法说明更早的请求未被处理
+	Uncertain bool `json:"uncertain,omitempty"`
+	// CreatedAt 任务的创建时间
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// RetryScheduledError 请求失败后已加入重试队列
+// 由 RetryQueue.CreateRefund 和 RetryQueue.CreateWithdraw 返回，Err 为本次请求的错误，
+// 可通过 errors.As 与未加入队列的错误区分，最终结果通过 RetryQueueOptions 的回调通知
+type RetryScheduledError struct {
+	// TaskID 重试任务标识
+	TaskID string
+	// Err 本次请求的错误
+	Err error
+}
+
+// Error 实现 error 接口
+func (e *RetryScheduledError) Error() string {
+	return fmt.Sprintf("scheduled for retry (task %s): %v", e.TaskID, e.Err)
+}
+
+// Unwrap 返回本次请求的错误，支持通过 errors.As 取得 SDKError
+func (e *RetryScheduledError) Unwrap() error {
+	return e.Err
+}
+
+// RetryQueueOptions 重试队列参数，各字段为零值时使用默认值
+type RetryQueueOptions struct {
+	// MaxAttempts 最大尝试次数（含首次请求），默认 DefaultRetryQueueMaxAttempts
+	MaxAttempts int
+	// Backoff 两次尝试之间的退避参数，为 nil 时使用 WaitOptions 的默认值
+	Backoff *WaitOptions
+	// PollInterval Run 扫描队列的间隔，默认 DefaultRetryQueuePollInterval
+	PollInterval time.Duration
+	// BatchSize 每次扫描处理的最大任务数，默认 DefaultRetryQueueBatchSize
+	BatchSize int
+	// OnSuccess 任务重试成功后调用
+	// result 为 *RefundResponse 或 *WithdrawResponse；提现已被平台受理、通过查询确认时为 *QueryWithdrawResponse
+	OnSuccess func(ctx context.Context, task *RetryTask, result interface{})
+	// OnFinalFailure 任务达到最大尝试次数、遇到无法通过重试恢复的错误，
+	// 或退款请求可能已被平台处理而无法安全重试时调用，任务随后从队列删除
+	OnFinalFailure func(ctx context.Context, task *RetryTask, err error)
+}
+
+// RetryQueue 失败的退款、提现请求的持久化重试队列
+//
+// 平台不可用时（网络错误、HTTP 429、5xx）请求加入队列，由 Run 按指数退避重新提交，
+// 任务保存在 RetryQueueStore 中，进程重启后继续重试
+//
+// 变更类请求重试前需确认上一次请求未被平台处理：
+//   - 提现按 ReqSeqId 查询，上一次请求可能已被处理（例如超时）时先调用 QueryWithdraw，已受理则视为成功
+//   - 退款请求没有商户侧的唯一标识，只在确定平台未处理时（连接失败、HTTP 429、503）加入队列，
+//     请求超时等结果未知的失败直接返回错误，由调用方通过 ListRefunds 核对后决定是否重新提交
+//
+// 多个进程不能同时对同一个 RetryQueueStore 调用 Run
+type RetryQueue struct {
+	payment *PaymentService
+	store   RetryQueueStore
+	options RetryQueueOptions
+	backoff WaitOptions
+}
+
+// NewRetryQueue 创建重试队列
+//
+// 参数:
+//   - client: 用于提交和查询请求的客户端
+//   - store: 任务存储，例如 NewFileRetryQueueStore 创建的文件存储
+//   - opts: 重试队列参数，为 nil 时使用默认值
+//
+// 返回:
+//   - *RetryQueue: 重试队列
+//
+// 示例:
+//
+//	store, err := haozpay.NewFileRetryQueueStore("/var/lib/app/haozpay-retry.json")
+//	if err != nil {
+//	    return err
+//	}
+//	queue := haozpay.NewRetryQueue(client, store, &haozpay.RetryQueueOptions{
+//	    OnFinalFailure: func(ctx context.Context, task *haozpay.RetryTask, err error) {
+//	        alert("haozpay retry failed", task.ID, err)
+//	    },
+//	})
+//	go queue.Run(ctx)
+//
+//	resp, err := queue.CreateWithdraw(ctx, req)
+//	var scheduled *haozpay.RetryScheduledError
+//	if errors.As(err, &scheduled) {
+//	    // 已加入重试队列，结果通过回调通知
+//	}
+func NewRetryQueue(client *Client, store RetryQueueStore, opts *RetryQueueOptions) *RetryQueue {
+	var options RetryQueueOptions
+	if opts != nil {
+		options = *opts
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = DefaultRetryQueueMaxAttempts
+	}
+	if options.PollInterval <= 0 {
+		options.PollInterval = DefaultRetryQueuePollInterval
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultRetryQueueBatchSize
+	}
+	return &RetryQueue{
+		payment: client.Payment,
+		store:   store,
+		options: options,
+		backoff: options.Backoff.withDefaults(),
+	}
+}
+
+// CreateRefund 申请退款，平台确定未处理的暂时性失败加入重试队列
+// 返回 RetryScheduledError 时请求已加入队列；其他错误与 PaymentService.CreateRefund 一致
+func (q *RetryQueue) CreateRefund(ctx context.Context, req *CreateRefundRequest, opts ...RequestOption) (*RefundResponse, error) {
+	resp, err := q.payment.CreateRefund(ctx, req, opts...)
+	if err == nil || !isTransientError(err) || requestMayHaveBeenProcessed(err) {
+		return resp, err
+	}
+
+	nonce, nonceErr := randomNonce()
+	if nonceErr != nil {
+		return nil, err
+	}
+	refund := *req
+	task := q.newTask("refund:"+req.OrderNo+":"+nonce, RetryTaskRefund, err)
+	task.Refund = &refund
+	return nil, q.schedule(ctx, task, err)
+}
+
+// CreateWithdraw 申请提现，暂时性失败加入重试队列
+// 返回 RetryScheduledError 时请求已加入队列；其他错误与 PaymentService.CreateWithdraw 一致
+func (q *RetryQueue) CreateWithdraw(ctx context.Context, req *CreateWithdrawRequest, opts ...RequestOption) (*WithdrawResponse, error) {
+	if req.ReqSeqId == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "reqSeqId is required",
+			StatusCode: 0,
+		}
+	}
+
+	resp, err := q.payment.CreateWithdraw(ctx, req, opts...)
+	if err == nil || !isTransientError(err) {
+		return resp, err
+	}
+
+	withdraw := *req
+	task := q.newTask("withdraw:"+req.ReqSeqId, RetryTaskWithdraw, err)
+	task.Withdraw = &withdraw
+	return nil, q.schedule(ctx, task, err)
+}
+
+// Run 按 PollInterval 扫描队列并重新提交到期的任务，直到 ctx 结束
+//
+// 返回:
+//   - error: ctx 结束时返回 ctx.Err()
+func (q *RetryQueue) Run(ctx context.Context) error {
+	for {
+		// 读取任务存储失败时等待下一次扫描
+		_, _ = q.ProcessDue(ctx)
+		if err := sleepContext(ctx, q.options.PollInterval); err != nil {
+			return err
+		}
+	}
+}
+
+// ProcessDue 重新提交所有到期的任务，最多处理 BatchSize 个
+//
+// 返回:
+//   - int: 处理的任务数
+//   - error: 读取或保存任务失败时返回错误
+func (q *RetryQueue) ProcessDue(ctx context.Context) (int, error) {
+	tasks, err := q.store.DueTasks(ctx, time.Now(), q.options.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load retry tasks: %w", err)
+	}
+
+	for i, task := range tasks {
+		if ctx.Err() != nil {
+			return i, ctx.Err()
+		}
+		if err := q.process(ctx, task); err != nil {
+			return i, err
+		}
+	}
+	return len(tasks), nil
+}
+
+// process 重新提交单个任务，按结果删除任务或安排下一次尝试
+func (q *RetryQueue) process(ctx context.Context, task *RetryTask) error {
+	task.Attempts++
+	result, err := q.attempt(ctx, task)
+
+	switch {
+	case err == nil:
+		if err := q.store.RemoveTask(ctx, task.ID); err != nil {
+			return fmt.Errorf("failed to remove retry task %s: %w", task.ID, err)
+		}
+		if q.options.OnSuccess != nil {
+			q.options.OnSuccess(ctx, task, result)
+		}
+		return nil
+	case isTransientError(err) && task.Attempts < q.options.MaxAttempts &&
+		(task.Kind != RetryTaskRefund || !requestMayHaveBeenProcessed(err)):
+		q.reschedule(task, err)
+		if err := q.store.SaveTask(ctx, task); err != nil {
+			return fmt.Errorf("failed to save retry task %s: %w", task.ID, err)
+		}
+		return nil
+	default:
+		task.LastError = err.Error()
+		if err := q.store.RemoveTask(ctx, task.ID); err != nil {
+			return fmt.Errorf("failed to remove retry task %s: %w", task.ID, err)
+		}
+		if q.options.OnFinalFailure != nil {
+			q.options.OnFinalFailure(ctx, task, err)
+		}
+		return nil
+	}
+}
+
+// attempt 重新提交任务中的请求
+// 提现的上一次请求可能已被处理时先按 ReqSeqId 查询，查询到提现记录时不再提交
+func (q *RetryQueue) attempt(ctx context.Context, task *RetryTask) (interface{}, error) {
+	switch {
+	case task.Kind == RetryTaskRefund && task.Refund != nil:
+		return q.payment.CreateRefund(ctx, task.Refund)
+	case task.Kind == RetryTaskWithdraw && task.Withdraw != nil:
+		if task.Uncertain {
+			withdraw, err := q.payment.QueryWithdraw(ctx, &QueryWithdrawRequest{ReqSeqId: task.Withdraw.ReqSeqId})
+			switch {
+			case err == nil && withdraw != nil && withdraw.ReqSeqId != "":
+				return withdraw, nil
+			case err != nil && isTransientError(err):
+				return nil, err
+			}
+		}
+		return q.payment.CreateWithdraw(ctx, task.Withdraw)
+	default:
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid retry task %s: kind %q", task.ID, task.Kind),
+			StatusCode: 0,
+		}
+	}
+}
+
+// newTask 创建首次请求失败后的重试任务
+func (q *RetryQueue) newTask(id string, kind RetryTaskKind, err error) *RetryTask {
+	task := &RetryTask{
+		ID:        id,
+		Kind:      kind,
+		Attempts:  1,
+		CreatedAt: time.Now(),
+	}
+	q.reschedule(task, err)
+	return task
+}
+
+// schedule 保存重试任务，保存失败时返回首次请求的错误
+func (q *RetryQueue) schedule(ctx context.Context, task *RetryTask, err error) error {
+	if saveErr := q.store.SaveTask(ctx, task); saveErr != nil {
+		return err
+	}
+	return &RetryScheduledError{TaskID: task.ID, Err: err}
+}
+
+// reschedule 记录失败原因，按已尝试次数计算下一次尝试的时间
+// 任一次请求可能已被处理后任务始终保持 Uncertain
+func (q *RetryQueue) reschedule(task *RetryTask, err error) {
+	interval := q.backoff.InitialInterval
+	for i := 1; i < task.Attempts && interval < q.backoff.MaxInterval; i++ {
+		interval = q.backoff.next(interval)
+	}
+	task.LastError = err.Error()
+	task.Uncertain = task.Uncertain || requestMayHaveBeenProcessed(err)
+	task.NextAttemptAt = time.Now().Add(q.backoff.jittered(interval))
+}
+
+// requestMayHaveBeenProcessed 判断失败的请求是否可能已被平台处理
+// 连接失败（见 isConnectError）和 HTTP 429、503 响应表示请求未被处理，
+// 超时、连接中断和其他 5xx 响应无法确定请求是否已被处理
+func requestMayHaveBeenProcessed(err error) bool {
+	var sdkErr *SDKError
+	if errors.As(err, &sdkErr) &&
+		(sdkErr.StatusCode == http.StatusTooManyRequests || sdkErr.StatusCode == http.StatusServiceUnavailable) {
+		return false
+	}
+	return !isConnectError(err)
+}
@@ -0,0 +1,202 @@
+package haozpay
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RetryQueueStore 重试队列的任务存储
+// 供 RetryQueue 持久化失败的退款、提现请求，进程重启后继续重试；
+// 调用方可基于自己的数据库实现，单实例部署可使用 FileRetryQueueStore，测试场景可使用 MemoryRetryQueueStore
+type RetryQueueStore interface {
+	// SaveTask 保存任务，ID 相同的任务已存在时覆盖
+	SaveTask(ctx context.Context, task *RetryTask) error
+	// DueTasks 返回 NextAttemptAt 不晚于 now 的任务，按 NextAttemptAt 升序，最多返回 limit 个
+	DueTasks(ctx context.Context, now time.Time, limit int) ([]*RetryTask, error)
+	// RemoveTask 删除任务，任务不存在时不返回错误
+	RemoveTask(ctx context.Context, id string) error
+}
+
+// MemoryRetryQueueStore 基于内存的 RetryQueueStore 实现，进程重启后任务丢失
+// 通过 NewMemoryRetryQueueStore 函数创建实例，可在多个 goroutine 中并发使用
+type MemoryRetryQueueStore struct {
+	mu    sync.Mutex
+	tasks map[string]RetryTask
+}
+
+// NewMemoryRetryQueueStore 创建基于内存的 RetryQueueStore
+func NewMemoryRetryQueueStore() *MemoryRetryQueueStore {
+	return &MemoryRetryQueueStore{tasks: make(map[string]RetryTask)}
+}
+
+// SaveTask 实现 RetryQueueStore 接口，保存任务的副本
+func (s *MemoryRetryQueueStore) SaveTask(ctx context.Context, task *RetryTask) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks[task.ID] = *task
+	return nil
+}
+
+// DueTasks 实现 RetryQueueStore 接口，返回任务的副本
+func (s *MemoryRetryQueueStore) DueTasks(ctx context.Context, now time.Time, limit int) ([]*RetryTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return dueTasks(s.tasks, now, limit), nil
+}
+
+// RemoveTask 实现 RetryQueueStore 接口
+func (s *MemoryRetryQueueStore) RemoveTask(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tasks, id)
+	return nil
+}
+
+// Len 返回队列中的任务数
+func (s *MemoryRetryQueueStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.tasks)
+}
+
+// FileRetryQueueStore 基于本地 JSON 文件的 RetryQueueStore 实现，适用于单实例部署
+// 每次变更后将全部任务写入临时文件再替换原文件，进程崩溃不会留下写了一半的文件；
+// 多个进程不能共用同一个文件，多实例部署请基于共享数据库实现 RetryQueueStore
+// 通过 NewFileRetryQueueStore 函数创建实例，可在多个 goroutine 中并发使用
+type FileRetryQueueStore struct {
+	path  string
+	mu    sync.Mutex
+	tasks map[string]RetryTask
+}
+
+// NewFileRetryQueueStore 创建基于本地文件的 RetryQueueStore，文件已存在时加载其中的任务
+//
+// 参数:
+//   - path: 任务文件路径，文件不存在时在首次保存任务时创建，所在目录需已存在
+//
+// 返回:
+//   - *FileRetryQueueStore: 文件任务存储
+//   - error: 读取或解析已有的任务文件失败时返回错误
+func NewFileRetryQueueStore(path string) (*FileRetryQueueStore, error) {
+	s := &FileRetryQueueStore{path: path, tasks: make(map[string]RetryTask)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read retry queue file: %w", err)
+	}
+	if len(data) == 0 {
+		return s, nil
+	}
+
+	var tasks []RetryTask
+	if err := json.Unmarshal(data, &tasks); err != nil {
+		return nil, fmt.Errorf("failed to parse retry queue file %s: %w", path, err)
+	}
+	for _, task := range tasks {
+		s.tasks[task.ID] = task
+	}
+	return s, nil
+}
+
+// SaveTask 实现 RetryQueueStore 接口
+func (s *FileRetryQueueStore) SaveTask(ctx context.Context, task *RetryTask) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.tasks[task.ID]
+	s.tasks[task.ID] = *task
+	if err := s.flush(); err != nil {
+		if existed {
+			s.tasks[task.ID] = previous
+		} else {
+			delete(s.tasks, task.ID)
+		}
+		return err
+	}
+	return nil
+}
+
+// DueTasks 实现 RetryQueueStore 接口，返回任务的副本
+func (s *FileRetryQueueStore) DueTasks(ctx context.Context, now time.Time, limit int) ([]*RetryTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return dueTasks(s.tasks, now, limit), nil
+}
+
+// RemoveTask 实现 RetryQueueStore 接口
+func (s *FileRetryQueueStore) RemoveTask(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, ok := s.tasks[id]
+	if !ok {
+		return nil
+	}
+	delete(s.tasks, id)
+	if err := s.flush(); err != nil {
+		s.tasks[id] = previous
+		return err
+	}
+	return nil
+}
+
+// flush 将全部任务写入临时文件后替换任务文件，调用方需持有锁
+func (s *FileRetryQueueStore) flush() error {
+	tasks := make([]RetryTask, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		tasks = append(tasks, task)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+
+	data, err := json.MarshalIndent(tasks, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode retry queue: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write retry queue file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write retry queue file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write retry queue file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write retry queue file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write retry queue file: %w", err)
+	}
+	return nil
+}
+
+// dueTasks 从任务表中筛选到期的任务，按 NextAttemptAt 升序返回副本
+func dueTasks(tasks map[string]RetryTask, now time.Time, limit int) []*RetryTask {
+	var due []*RetryTask
+	for _, task := range tasks {
+		if !task.NextAttemptAt.After(now) {
+			task := task
+			due = append(due, &task)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due
+}
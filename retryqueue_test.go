@@ -0,0 +1,107 @@
+package haozpay_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+	"github.com/haoz-cloud/haozpay-sdk/haozpaytest"
+)
+
+// processUntil 反复处理到期任务，直到 done 返回 true
+func processUntil(t *testing.T, queue *haozpay.RetryQueue, done func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !done() {
+		if time.Now().After(deadline) {
+			t.Fatal("retry task was not processed in time")
+		}
+		if _, err := queue.ProcessDue(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRetryQueueWithdrawStaysUncertain(t *testing.T) {
+	const (
+		applyPath = "/pay-core/withdraw/apply"
+		queryPath = "/pay-core/withdraw/query"
+	)
+	server, client := newTestGateway(t)
+	ctx := context.Background()
+
+	// 首次提交结果未知（HTTP 500），第一次查询时平台暂时不可用（HTTP 503），第二次查询到提现已受理
+	server.Respond(applyPath, &haozpaytest.Error{StatusCode: http.StatusInternalServerError, Code: haozpaytest.CodeInternalError, Message: "internal error"})
+	server.Respond(queryPath,
+		&haozpaytest.Error{StatusCode: http.StatusServiceUnavailable, Code: haozpaytest.CodeInternalError, Message: "unavailable"},
+		&haozpay.QueryWithdrawResponse{ReqSeqId: "W1", SeqId: "S1"},
+	)
+
+	store := haozpay.NewMemoryRetryQueueStore()
+	var succeeded *haozpay.RetryTask
+	var result interface{}
+	queue := haozpay.NewRetryQueue(client, store, &haozpay.RetryQueueOptions{
+		Backoff: &haozpay.WaitOptions{InitialInterval: time.Millisecond, MaxInterval: time.Millisecond},
+		OnSuccess: func(ctx context.Context, task *haozpay.RetryTask, r interface{}) {
+			succeeded, result = task, r
+		},
+		OnFinalFailure: func(ctx context.Context, task *haozpay.RetryTask, err error) {
+			t.Errorf("task %s failed: %v", task.ID, err)
+		},
+	})
+
+	_, err := queue.CreateWithdraw(ctx, &haozpay.CreateWithdrawRequest{PayChannel: "ALIPAY", WithdrawAmount: haozpay.Fen(100), ReqSeqId: "W1"})
+	var scheduled *haozpay.RetryScheduledError
+	if !errors.As(err, &scheduled) {
+		t.Fatalf("CreateWithdraw() error = %v, want RetryScheduledError", err)
+	}
+
+	processUntil(t, queue, func() bool { return succeeded != nil })
+
+	// 查询失败的 503 不能说明首次提交未被处理，不能重新提交提现
+	if n := countRequests(server, applyPath); n != 1 {
+		t.Errorf("withdraw submitted %d times, want 1", n)
+	}
+	if n := countRequests(server, queryPath); n != 2 {
+		t.Errorf("withdraw queried %d times, want 2", n)
+	}
+	if !succeeded.Uncertain {
+		t.Error("task is no longer uncertain after an unprocessed failure")
+	}
+	if withdraw, ok := result.(*haozpay.QueryWithdrawResponse); !ok || withdraw.SeqId != "S1" {
+		t.Errorf("OnSuccess result = %#v", result)
+	}
+}
+
+func TestRetryQueueWithdrawResubmitsUnprocessedRequest(t *testing.T) {
+	const applyPath = "/pay-core/withdraw/apply"
+	server, client := newTestGateway(t)
+
+	// 平台限流（HTTP 429）时请求未被处理，重新提交前无需查询
+	server.Respond(applyPath,
+		&haozpaytest.Error{StatusCode: http.StatusTooManyRequests, Code: haozpaytest.CodeInternalError, Message: "too many requests"},
+		&haozpay.WithdrawResponse{ReqSeqId: "W2", SeqId: "S2"},
+	)
+
+	done := false
+	queue := haozpay.NewRetryQueue(client, haozpay.NewMemoryRetryQueueStore(), &haozpay.RetryQueueOptions{
+		Backoff:   &haozpay.WaitOptions{InitialInterval: time.Millisecond, MaxInterval: time.Millisecond},
+		OnSuccess: func(ctx context.Context, task *haozpay.RetryTask, result interface{}) { done = true },
+	})
+
+	if _, err := queue.CreateWithdraw(context.Background(), &haozpay.CreateWithdrawRequest{PayChannel: "ALIPAY", WithdrawAmount: haozpay.Fen(100), ReqSeqId: "W2"}); err == nil {
+		t.Fatal("CreateWithdraw() succeeded, want RetryScheduledError")
+	}
+	processUntil(t, queue, func() bool { return done })
+
+	if n := countRequests(server, applyPath); n != 2 {
+		t.Errorf("withdraw submitted %d times, want 2", n)
+	}
+	if n := countRequests(server, "/pay-core/withdraw/query"); n != 0 {
+		t.Errorf("withdraw queried %d times, want 0", n)
+	}
+}
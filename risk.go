@@ -0,0 +1,33 @@
+package haozpay
+
+import (
+	"context"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type RiskService struct {
+	executor *apiExecutor
+}
+
+func NewRiskService(client *resty.Client, config *Config) *RiskService {
+	return &RiskService{
+		executor: newAPIExecutor(client, config),
+	}
+}
+
+func (s *RiskService) QueryOrderRisk(ctx context.Context, orderNo string, opts ...RequestOption) (*OrderRiskResponse, error) {
+	if orderNo == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "orderNo is required",
+			StatusCode: 0,
+		}
+	}
+
+	var resp *OrderRiskResponse
+	if err := s.executor.post(ctx, "/pay-core/risk/order/query", &QueryOrderRiskRequest{OrderNo: orderNo}, &resp, "failed to query order risk", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
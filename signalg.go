@@ -0,0 +1,161 @@
+package haozpay
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/emmansun/gmsm/sm2"
+	"github.com/emmansun/gmsm/sm3"
+)
+
+// SignAlgorithm 签名算法实现
+// 决定签名串的摘要算法、默认签名器和平台公钥的验签方式
+// 内置 SignTypeRSA2、SignTypeRSA、SignTypeRSAPSS 和 SignTypeSM2，其他算法可通过 RegisterSignAlgorithm 注册
+type SignAlgorithm interface {
+	// Digest 计算签名串的摘要，返回小写 HEX 字符串，签名器和验签器均对该字符串进行运算
+	Digest(signString string) string
+	// NewSigner 使用商户私钥创建默认签名器，未设置 Config.Signer 时使用
+	NewSigner(privateKeyPEM string) (Signer, error)
+	// NewVerifier 使用平台公钥创建验签器，公钥类型不适用于该算法时返回错误
+	NewVerifier(publicKey crypto.PublicKey) (Verifier, error)
+}
+
+// Verifier 签名验签器
+type Verifier interface {
+	// Verify 验证摘要的签名，签名不匹配时返回包装 ErrSignatureInvalid 的错误
+	Verify(digest, signature []byte) error
+}
+
+var (
+	signAlgorithmsMu sync.RWMutex
+	signAlgorithms   = map[SignType]SignAlgorithm{
+		SignTypeRSA2:   rsaAlgorithm{digest: sha256Hex},
+		SignTypeRSA:    rsaAlgorithm{digest: sha1Hex},
+		SignTypeRSAPSS: rsaPSSAlgorithm{},
+		SignTypeSM2:    sm2Algorithm{},
+	}
+)
+
+// RegisterSignAlgorithm 注册签名算法
+// 注册后 Config.SignType 设置为 signType 即可使用，请求签名和回调、响应验签均按该算法处理
+// 使用已有的 signType 注册时替换原有实现，可用于替换内置算法
+//
+// 参数:
+//   - signType: 签名算法类型，即请求报文 signType 字段的取值
+//   - algorithm: 签名算法实现
+//
+// 注意: 应在创建客户端之前（例如 init 函数中）注册，已解析的验签器不会随注册更新
+//
+// 示例:
+//
+//	haozpay.RegisterSignAlgorithm("RSA512", myRSA512Algorithm{})
+//	config.WithSignType("RSA512")
+func RegisterSignAlgorithm(signType SignType, algorithm SignAlgorithm) {
+	if signType == "" || algorithm == nil {
+		panic("haozpay: RegisterSignAlgorithm requires a sign type and algorithm")
+	}
+	signAlgorithmsMu.Lock()
+	defer signAlgorithmsMu.Unlock()
+	signAlgorithms[signType] = algorithm
+}
+
+// LookupSignAlgorithm 返回已注册的签名算法
+//
+// 返回:
+//   - SignAlgorithm: 签名算法实现
+//   - bool: signType 未注册时为 false
+func LookupSignAlgorithm(signType SignType) (SignAlgorithm, bool) {
+	signAlgorithmsMu.RLock()
+	defer signAlgorithmsMu.RUnlock()
+	algorithm, ok := signAlgorithms[signType]
+	return algorithm, ok
+}
+
+// signAlgorithmFor 返回签名算法，未注册时返回错误
+func signAlgorithmFor(signType SignType) (SignAlgorithm, error) {
+	algorithm, ok := LookupSignAlgorithm(signType)
+	if !ok {
+		return nil, fmt.Errorf("unsupported sign type: %s", signType)
+	}
+	return algorithm, nil
+}
+
+// isRegisteredSignType 判断签名算法是否已注册
+func isRegisteredSignType(signType SignType) bool {
+	_, ok := LookupSignAlgorithm(signType)
+	return ok
+}
+
+// registeredSignTypes 返回已注册的签名算法类型，按名称排序
+func registeredSignTypes() []SignType {
+	signAlgorithmsMu.RLock()
+	defer signAlgorithmsMu.RUnlock()
+	signTypes := make([]SignType, 0, len(signAlgorithms))
+	for signType := range signAlgorithms {
+		signTypes = append(signTypes, signType)
+	}
+	sort.Slice(signTypes, func(i, j int) bool { return signTypes[i] < signTypes[j] })
+	return signTypes
+}
+
+// sha256Hex 返回签名串 SHA256 摘要的小写 HEX 字符串
+func sha256Hex(signString string) string {
+	sum := sha256.Sum256([]byte(signString))
+	return hex.EncodeToString(sum[:])
+}
+
+// sha1Hex 返回签名串 SHA1 摘要的小写 HEX 字符串
+func sha1Hex(signString string) string {
+	sum := sha1.Sum([]byte(signString))
+	return hex.EncodeToString(sum[:])
+}
+
+// rsaAlgorithm RSA 签名算法，RSA2 与 RSA 仅摘要算法不同
+// 签名为 PKCS1v15 block type 1 填充且不含 DigestInfo 前缀，与 Java Hutool 私钥"加密"一致
+type rsaAlgorithm struct {
+	digest func(signString string) string
+}
+
+func (a rsaAlgorithm) Digest(signString string) string {
+	return a.digest(signString)
+}
+
+func (a rsaAlgorithm) NewSigner(privateKeyPEM string) (Signer, error) {
+	return NewRSASigner(privateKeyPEM)
+}
+
+func (a rsaAlgorithm) NewVerifier(publicKey crypto.PublicKey) (Verifier, error) {
+	pub, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("RSA public key is required, got %T", publicKey)
+	}
+	return &rsaVerifier{publicKey: pub}, nil
+}
+
+// sm2Algorithm SM3 摘要 + SM2 签名
+type sm2Algorithm struct{}
+
+func (sm2Algorithm) Digest(signString string) string {
+	sum := sm3.Sum([]byte(signString))
+	return hex.EncodeToString(sum[:])
+}
+
+func (sm2Algorithm) NewSigner(privateKeyPEM string) (Signer, error) {
+	return NewSM2Signer(privateKeyPEM)
+}
+
+func (sm2Algorithm) NewVerifier(publicKey crypto.PublicKey) (Verifier, error) {
+	pub, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok || !sm2.IsSM2PublicKey(pub) {
+		return nil, errors.New("SM2 public key is required")
+	}
+	return &sm2Verifier{publicKey: pub}, nil
+}
@@ -0,0 +1,245 @@
+package haozpay
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/emmansun/gmsm/sm2"
+	"github.com/emmansun/gmsm/smx509"
+)
+
+// SignType 签名算法类型
+type SignType string
+
+const (
+	// SignTypeRSA2 SHA256 摘要 + RSA 签名（默认）
+	SignTypeRSA2 SignType = "RSA2"
+	// SignTypeRSA SHA1 摘要 + RSA 签名，用于仍使用旧版签名算法的商户
+	SignTypeRSA SignType = "RSA"
+	// SignTypeRSAPSS SHA256 摘要 + RSASSA-PSS 签名
+	SignTypeRSAPSS SignType = "RSA-PSS"
+	// SignTypeSM2 SM3 摘要 + SM2 签名（国密 GM/T 算法）
+	SignTypeSM2 SignType = "SM2"
+)
+
+// Signer 请求签名器
+// 对签名串的摘要进行签名，默认实现为 RSASigner
+// 可通过 Config.WithSigner 替换为 HSM、KMS 等不在进程内存中持有私钥的实现
+type Signer interface {
+	// Sign 对摘要进行签名
+	//
+	// 参数:
+	//   - digest: 签名串摘要的小写 HEX 字符串（RSA2 为 SHA256，RSA 为 SHA1，SM2 为 SM3）
+	//
+	// 返回:
+	//   - []byte: 签名结果（RSA 为 PKCS1v15 block type 1 填充且不含 DigestInfo 前缀，RSA-PSS 为对摘要原始字节的 PSS 签名，SM2 为 ASN.1 编码）
+	//   - error: 签名失败时返回错误
+	Sign(digest []byte) ([]byte, error)
+}
+
+// RSASigner 基于 RSA 私钥的默认签名器
+// 私钥在创建时解析一次，之后可并发复用
+type RSASigner struct {
+	privateKey *rsa.PrivateKey
+}
+
+// NewRSASigner 使用 PEM 格式的 RSA 私钥创建签名器
+//
+// 参数:
+//   - privateKeyPEM: 商户RSA私钥（支持纯私钥字符串或完整PEM格式，PKCS#1 和 PKCS#8 均可）
+//
+// 返回:
+//   - *RSASigner: 签名器实例
+//   - error: 私钥解析失败时返回错误
+func NewRSASigner(privateKeyPEM string) (*RSASigner, error) {
+	privateKey, err := parsePrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	return NewRSASignerFromKey(privateKey), nil
+}
+
+// NewRSASignerFromKey 使用已解析的 RSA 私钥创建签名器
+func NewRSASignerFromKey(privateKey *rsa.PrivateKey) *RSASigner {
+	return &RSASigner{privateKey: privateKey}
+}
+
+// Sign 实现 Signer 接口
+func (s *RSASigner) Sign(digest []byte) ([]byte, error) {
+	return privateKeyEncryptRaw(s.privateKey, digest)
+}
+
+// CryptoSigner 基于 crypto.Signer 的签名器
+// 适用于 HSM、KMS、PKCS#11 等实现了 crypto.Signer 的 RSA 密钥
+type CryptoSigner struct {
+	signer crypto.Signer
+}
+
+// NewCryptoSigner 使用 crypto.Signer 创建签名器
+//
+// 参数:
+//   - signer: 底层签名实现，公钥必须是 RSA 公钥
+//
+// 返回:
+//   - *CryptoSigner: 签名器实例
+//   - error: 公钥不是 RSA 公钥时返回错误
+//
+// 注意:
+//   - 底层实现需要支持 crypto.Hash(0)，即对传入数据直接进行 PKCS1v15 签名
+func NewCryptoSigner(signer crypto.Signer) (*CryptoSigner, error) {
+	if signer == nil {
+		return nil, errors.New("crypto.Signer is nil")
+	}
+	if _, ok := signer.Public().(*rsa.PublicKey); !ok {
+		return nil, fmt.Errorf("unsupported public key type: %T", signer.Public())
+	}
+	return &CryptoSigner{signer: signer}, nil
+}
+
+// Sign 实现 Signer 接口
+// 使用 crypto.Hash(0) 调用底层签名器，与 Java Hutool 私钥"加密"结果一致
+func (s *CryptoSigner) Sign(digest []byte) ([]byte, error) {
+	return s.signer.Sign(rand.Reader, digest, crypto.Hash(0))
+}
+
+// newPrivateKeySigner 根据签名算法使用商户私钥创建默认签名器
+func newPrivateKeySigner(privateKeyPEM string, signType SignType) (Signer, error) {
+	algorithm, err := signAlgorithmFor(signType)
+	if err != nil {
+		return nil, err
+	}
+	return algorithm.NewSigner(privateKeyPEM)
+}
+
+// signatureVerifier 平台签名验签器
+// 由签名算法和平台公钥创建，签名算法决定摘要算法和验签方式
+type signatureVerifier interface {
+	// signType 验签器对应的签名算法
+	signType() SignType
+	// digest 按签名算法计算签名串的摘要
+	digest(signString string) string
+	// verify 验证摘要的签名
+	verify(digest, signature []byte) error
+}
+
+// algorithmVerifier 基于已注册签名算法的验签器
+type algorithmVerifier struct {
+	typ       SignType
+	algorithm SignAlgorithm
+	verifier  Verifier
+}
+
+func (v *algorithmVerifier) signType() SignType {
+	return v.typ
+}
+
+func (v *algorithmVerifier) digest(signString string) string {
+	return v.algorithm.Digest(signString)
+}
+
+func (v *algorithmVerifier) verify(digest, signature []byte) error {
+	return v.verifier.Verify(digest, signature)
+}
+
+// rsaVerifier 基于 RSA 公钥的验签器
+type rsaVerifier struct {
+	publicKey *rsa.PublicKey
+}
+
+// Verify 实现 Verifier 接口
+func (v *rsaVerifier) Verify(digest, signature []byte) error {
+	decrypted, err := decryptWithPublicKey(v.publicKey, signature)
+	if err != nil {
+		return fmt.Errorf("%w: failed to decrypt with public key: %v", ErrSignatureInvalid, err)
+	}
+
+	if string(decrypted) != string(digest) {
+		return fmt.Errorf("%w: hash mismatch", ErrSignatureInvalid)
+	}
+
+	return nil
+}
+
+// verifierCacheKey 验签器缓存的键
+type verifierCacheKey struct {
+	publicKey string
+	signType  SignType
+}
+
+// verifierCache 已解析的平台验签器，按公钥内容和签名算法缓存
+// 回调验签等按公钥字符串调用的场景复用解析结果，避免每次验签都重新解析公钥
+var verifierCache sync.Map
+
+// cachedVerifier 返回公钥对应的验签器，首次使用时解析并缓存
+// signType 为空时按公钥类型选择默认签名算法；解析失败的结果不缓存
+func cachedVerifier(publicKeyPEM string, signType SignType) (signatureVerifier, error) {
+	key := verifierCacheKey{publicKey: publicKeyPEM, signType: signType}
+	if verifier, ok := verifierCache.Load(key); ok {
+		return verifier.(signatureVerifier), nil
+	}
+	verifier, err := newVerifier(publicKeyPEM, signType)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := verifierCache.LoadOrStore(key, verifier)
+	return actual.(signatureVerifier), nil
+}
+
+// newVerifier 解析平台公钥并按签名算法创建验签器
+// signType 为空时按公钥类型选择：RSA 公钥使用 SignTypeRSA2，SM2 公钥使用 SignTypeSM2
+func newVerifier(publicKeyPEM string, signType SignType) (signatureVerifier, error) {
+	pub, err := parsePlatformPublicKey(publicKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	if signType == "" {
+		if signType, err = defaultSignType(pub); err != nil {
+			return nil, err
+		}
+	}
+	algorithm, err := signAlgorithmFor(signType)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := algorithm.NewVerifier(pub)
+	if err != nil {
+		return nil, fmt.Errorf("public key does not match SignType %s: %w", signType, err)
+	}
+	return &algorithmVerifier{typ: signType, algorithm: algorithm, verifier: verifier}, nil
+}
+
+// parsePlatformPublicKey 解析 RSA 或 SM2 公钥（支持纯公钥字符串、PEM 公钥和 X.509 证书）
+func parsePlatformPublicKey(publicKeyPEM string) (crypto.PublicKey, error) {
+	keyBytes, err := decodePublicKeyBytes(publicKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// 国密 x509 实现同时支持 RSA 和 SM2 公钥
+	pub, err := smx509.ParsePKIXPublicKey(keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+	return pub, nil
+}
+
+// defaultSignType 返回公钥类型对应的默认签名算法
+func defaultSignType(pub crypto.PublicKey) (SignType, error) {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		return SignTypeRSA2, nil
+	case *ecdsa.PublicKey:
+		if !sm2.IsSM2PublicKey(pub) {
+			return "", errors.New("not an SM2 public key")
+		}
+		return SignTypeSM2, nil
+	default:
+		return "", fmt.Errorf("unsupported public key type: %T", pub)
+	}
+}
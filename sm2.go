@@ -0,0 +1,92 @@
+package haozpay
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/emmansun/gmsm/sm2"
+	"github.com/emmansun/gmsm/smx509"
+)
+
+// SM2Signer 基于 SM2 私钥的国密签名器
+// 使用默认用户标识(1234567812345678)按 GB/T 32918.2 进行签名，签名结果为 ASN.1 编码
+// 需配合 Config.WithSignType(SignTypeSM2) 使用，使签名摘要采用 SM3
+type SM2Signer struct {
+	privateKey *sm2.PrivateKey
+}
+
+// NewSM2Signer 使用 PEM 格式的 SM2 私钥创建签名器
+//
+// 参数:
+//   - privateKeyPEM: 商户SM2私钥（支持 SEC1 "EC PRIVATE KEY"、PKCS#8 "PRIVATE KEY" 或纯 Base64 字符串）
+//
+// 返回:
+//   - *SM2Signer: 签名器实例
+//   - error: 私钥解析失败时返回错误
+func NewSM2Signer(privateKeyPEM string) (*SM2Signer, error) {
+	privateKey, err := parseSM2PrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	return NewSM2SignerFromKey(privateKey), nil
+}
+
+// NewSM2SignerFromKey 使用已解析的 SM2 私钥创建签名器
+func NewSM2SignerFromKey(privateKey *sm2.PrivateKey) *SM2Signer {
+	return &SM2Signer{privateKey: privateKey}
+}
+
+// Sign 实现 Signer 接口
+func (s *SM2Signer) Sign(digest []byte) ([]byte, error) {
+	return s.privateKey.Sign(rand.Reader, digest, sm2.DefaultSM2SignerOpts)
+}
+
+// sm2Verifier 基于 SM2 公钥的验签器
+type sm2Verifier struct {
+	publicKey *ecdsa.PublicKey
+}
+
+// Verify 实现 Verifier 接口
+func (v *sm2Verifier) Verify(digest, signature []byte) error {
+	if !sm2.VerifyASN1WithSM2(v.publicKey, nil, digest, signature) {
+		return fmt.Errorf("%w: sm2 verify failed", ErrSignatureInvalid)
+	}
+	return nil
+}
+
+// parseSM2PrivateKey 解析SM2私钥（支持SEC1和PKCS8格式，自动兼容纯私钥字符串和PEM格式）
+func parseSM2PrivateKey(keyStr string) (*sm2.PrivateKey, error) {
+	keyStr = strings.TrimSpace(keyStr)
+
+	var der []byte
+	if block, _ := pem.Decode([]byte(keyStr)); block != nil {
+		der = block.Bytes
+	} else {
+		decoded, err := base64.StdEncoding.DecodeString(keyStr)
+		if err != nil {
+			return nil, errors.New("私钥PEM格式解析失败")
+		}
+		der = decoded
+	}
+
+	// 尝试SEC1格式
+	if privateKey, err := smx509.ParseSM2PrivateKey(der); err == nil {
+		return privateKey, nil
+	}
+
+	// 尝试PKCS8格式
+	keyInterface, err := smx509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("不支持的私钥格式: %w", err)
+	}
+	privateKey, ok := keyInterface.(*sm2.PrivateKey)
+	if !ok {
+		return nil, errors.New("不是SM2私钥")
+	}
+	return privateKey, nil
+}
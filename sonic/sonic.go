@@ -0,0 +1,69 @@
+// Package haozpaysonic 提供基于 bytedance/sonic 的 haozpay.Codec 实现
+//
+// sonic 在 amd64 和 arm64 上使用 JIT 加速序列化，其他平台或不支持的 Go 版本自动回退到 encoding/json
+//
+// 示例:
+//
+//	config := haozpay.DefaultConfig().
+//	    WithMerchantNo("HZ1971294971928846336").
+//	    WithPrivateKey(privateKeyPEM).
+//	    WithCodec(haozpaysonic.New())
+package haozpaysonic
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/bytedance/sonic"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+// errTrailingData 严格解析时 JSON 之后存在多余数据的错误
+var errTrailingData = errors.New("invalid character after top-level value")
+
+// Codec 基于 bytedance/sonic 的 haozpay.Codec 实现
+type Codec struct {
+	api sonic.API
+}
+
+// New 创建与 encoding/json 行为一致的编解码器（sonic.ConfigStd）
+func New() *Codec {
+	return NewWithAPI(sonic.ConfigStd)
+}
+
+// NewWithAPI 使用指定的 sonic 配置创建编解码器
+//
+// 参数:
+//   - api: sonic 配置，例如 sonic.ConfigDefault 或通过 sonic.Config{...}.Froze() 创建
+//
+// 注意:
+//   - sonic.ConfigDefault 不转义 HTML 字符、不校验字符串中的非法 UTF-8，性能更好，签名不受影响
+func NewWithAPI(api sonic.API) *Codec {
+	return &Codec{api: api}
+}
+
+// Marshal 实现 haozpay.Codec 接口
+func (c *Codec) Marshal(v interface{}) ([]byte, error) {
+	return c.api.Marshal(v)
+}
+
+// Unmarshal 实现 haozpay.Codec 接口
+func (c *Codec) Unmarshal(data []byte, v interface{}) error {
+	return c.api.Unmarshal(data, v)
+}
+
+// UnmarshalStrict 实现 haozpay.StrictCodec 接口，JSON 中包含 v 未定义的字段时返回错误
+func (c *Codec) UnmarshalStrict(data []byte, v interface{}) error {
+	dec := c.api.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errTrailingData
+	}
+	return nil
+}
+
+var _ haozpay.StrictCodec = (*Codec)(nil)
@@ -0,0 +1,115 @@
+package haozpay
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// CallStats 单次接口调用的耗时分解，用于区分网络耗时和网关处理耗时
+// 各阶段耗时来自最后一次请求（包括重试），复用连接时 DNSLookup、Connect、TLSHandshake 为 0
+type CallStats struct {
+	// DNSLookup DNS 解析耗时
+	DNSLookup time.Duration
+	// Connect 建立 TCP 连接的耗时
+	Connect time.Duration
+	// TLSHandshake TLS 握手耗时
+	TLSHandshake time.Duration
+	// ServerTime 从取得连接到收到响应首字节的耗时，包括发送请求、网络往返和网关处理
+	ServerTime time.Duration
+	// ResponseTime 从收到响应首字节到读取完响应的耗时
+	ResponseTime time.Duration
+	// Total 整个调用的耗时，包括序列化、签名、所有重试和响应验签
+	Total time.Duration
+	// ConnReused 是否复用了已有连接
+	ConnReused bool
+	// RemoteAddr 网关的网络地址，未建立连接时为空
+	RemoteAddr string
+	// Attempts 发送请求的次数，包括重试；参数校验失败未发送请求时为 0
+	Attempts int
+}
+
+// callStatsKey context 中存储调用耗时统计的键
+type callStatsKey struct{}
+
+// WithCallStats 统计本次调用的耗时，调用返回后（无论成功与否）写入 stats
+//
+// 参数:
+//   - stats: 耗时统计的写入目标，为 nil 时忽略
+//
+// 示例:
+//
+//	var stats haozpay.CallStats
+//	order, err := client.Payment.QueryOrder(ctx, req, haozpay.WithCallStats(&stats))
+//	log.Printf("dns=%s connect=%s tls=%s server=%s total=%s",
+//	    stats.DNSLookup, stats.Connect, stats.TLSHandshake, stats.ServerTime, stats.Total)
+func WithCallStats(stats *CallStats) RequestOption {
+	return func(o *requestOptions) {
+		if stats != nil {
+			o.stats = stats
+		}
+	}
+}
+
+// CallStatsFromContext 获取本次调用的耗时统计，用于 AfterCallHook 上报指标
+// 仅在开启 Config.Trace 或传入 WithCallStats 时可用，否则返回 nil；
+// BeforeCallHook 中取得的统计尚未写入
+//
+// 示例:
+//
+//	client.OnAfterCall(func(ctx context.Context, op string, resp interface{}, err error) {
+//	    if stats := haozpay.CallStatsFromContext(ctx); stats != nil {
+//	        metrics.Observe(op, "server", stats.ServerTime)
+//	        metrics.Observe(op, "total", stats.Total)
+//	    }
+//	})
+func CallStatsFromContext(ctx context.Context) *CallStats {
+	if ctx == nil {
+		return nil
+	}
+	stats, _ := ctx.Value(callStatsKey{}).(*CallStats)
+	return stats
+}
+
+// withCallStats 按配置和请求选项将耗时统计写入 context，未开启统计时返回原 context 和 nil
+func withCallStats(ctx context.Context, trace bool, options *requestOptions) (context.Context, *CallStats) {
+	stats := options.stats
+	if stats == nil && trace {
+		stats = &CallStats{}
+	}
+	if stats == nil {
+		return ctx, nil
+	}
+	*stats = CallStats{}
+	return context.WithValue(ctx, callStatsKey{}, stats), stats
+}
+
+// record 写入最后一次请求的各阶段耗时，覆盖之前的统计结果
+// 请求在某一阶段失败时，该阶段及之后的耗时为 0
+func (s *CallStats) record(resp *resty.Response) {
+	if resp == nil || resp.Request == nil {
+		return
+	}
+	info := resp.Request.TraceInfo()
+	*s = CallStats{
+		DNSLookup:    nonNegative(info.DNSLookup),
+		Connect:      nonNegative(info.TCPConnTime),
+		TLSHandshake: nonNegative(info.TLSHandshake),
+		ServerTime:   nonNegative(info.ServerTime),
+		ResponseTime: nonNegative(info.ResponseTime),
+		ConnReused:   info.IsConnReused,
+		Attempts:     info.RequestAttempt,
+	}
+	if info.RemoteAddr != nil {
+		s.RemoteAddr = info.RemoteAddr.String()
+	}
+}
+
+// nonNegative 阶段未完成时 resty 以零值时间计算得到负数，按 0 处理
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
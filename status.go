@@ -0,0 +1,644 @@
+package haozpay
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// OrderStatus 支付订单状态
+type OrderStatus int
+
+const (
+	// OrderStatusCreated 已创建，待支付
+	OrderStatusCreated OrderStatus = 0
+	// OrderStatusPaying 支付中
+	OrderStatusPaying OrderStatus = 1
+	// OrderStatusPaid 支付成功
+	OrderStatusPaid OrderStatus = 2
+	// OrderStatusFailed 支付失败
+	OrderStatusFailed OrderStatus = 3
+	// OrderStatusClosed 已关闭（取消或超时未支付）
+	OrderStatusClosed OrderStatus = 4
+	// OrderStatusRefunding 退款中
+	OrderStatusRefunding OrderStatus = 5
+	// OrderStatusRefunded 已退款
+	OrderStatusRefunded OrderStatus = 6
+)
+
+// orderStatusNames 订单状态名称
+var orderStatusNames = map[OrderStatus]string{
+	OrderStatusCreated:   "待支付",
+	OrderStatusPaying:    "支付中",
+	OrderStatusPaid:      "支付成功",
+	OrderStatusFailed:    "支付失败",
+	OrderStatusClosed:    "已关闭",
+	OrderStatusRefunding: "退款中",
+	OrderStatusRefunded:  "已退款",
+}
+
+// String 返回订单状态名称，未知状态返回 OrderStatus(n)
+func (s OrderStatus) String() string {
+	if name, ok := orderStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("OrderStatus(%d)", int(s))
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口，支持数字和字符串两种形式
+func (s *OrderStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalIntEnum(data, s)
+}
+
+// IsFinal 判断支付结果是否已确定（不会再变为支付成功或支付失败）
+// 待支付、支付中以及未知状态返回 false
+func (s OrderStatus) IsFinal() bool {
+	switch s {
+	case OrderStatusPaid, OrderStatusFailed, OrderStatusClosed, OrderStatusRefunding, OrderStatusRefunded:
+		return true
+	}
+	return false
+}
+
+// IsSuccess 判断订单是否已支付成功（包括支付成功后发起了退款的订单）
+func (s OrderStatus) IsSuccess() bool {
+	switch s {
+	case OrderStatusPaid, OrderStatusRefunding, OrderStatusRefunded:
+		return true
+	}
+	return false
+}
+
+// RefundStatus 退款状态
+type RefundStatus int
+
+const (
+	// RefundStatusProcessing 退款处理中
+	RefundStatusProcessing RefundStatus = 0
+	// RefundStatusSuccess 退款成功
+	RefundStatusSuccess RefundStatus = 1
+	// RefundStatusFailed 退款失败
+	RefundStatusFailed RefundStatus = 2
+	// RefundStatusClosed 退款关闭
+	RefundStatusClosed RefundStatus = 3
+)
+
+// refundStatusNames 退款状态名称
+var refundStatusNames = map[RefundStatus]string{
+	RefundStatusProcessing: "退款处理中",
+	RefundStatusSuccess:    "退款成功",
+	RefundStatusFailed:     "退款失败",
+	RefundStatusClosed:     "退款关闭",
+}
+
+// String 返回退款状态名称，未知状态返回 RefundStatus(n)
+func (s RefundStatus) String() string {
+	if name, ok := refundStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("RefundStatus(%d)", int(s))
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口，支持数字和字符串两种形式
+func (s *RefundStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalIntEnum(data, s)
+}
+
+// IsFinal 判断退款是否已处理完成（成功、失败或关闭）
+func (s RefundStatus) IsFinal() bool {
+	switch s {
+	case RefundStatusSuccess, RefundStatusFailed, RefundStatusClosed:
+		return true
+	}
+	return false
+}
+
+// IsSuccess 判断退款是否成功
+func (s RefundStatus) IsSuccess() bool {
+	return s == RefundStatusSuccess
+}
+
+// WithdrawStatus 提现状态
+type WithdrawStatus int
+
+const (
+	// WithdrawStatusProcessing 提现处理中
+	WithdrawStatusProcessing WithdrawStatus = 0
+	// WithdrawStatusSuccess 提现成功（已到账）
+	WithdrawStatusSuccess WithdrawStatus = 1
+	// WithdrawStatusFailed 提现失败
+	WithdrawStatusFailed WithdrawStatus = 2
+)
+
+// withdrawStatusNames 提现状态名称
+var withdrawStatusNames = map[WithdrawStatus]string{
+	WithdrawStatusProcessing: "提现处理中",
+	WithdrawStatusSuccess:    "提现成功",
+	WithdrawStatusFailed:     "提现失败",
+}
+
+// String 返回提现状态名称，未知状态返回 WithdrawStatus(n)
+func (s WithdrawStatus) String() string {
+	if name, ok := withdrawStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("WithdrawStatus(%d)", int(s))
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口，支持数字和字符串两种形式
+func (s *WithdrawStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalIntEnum(data, s)
+}
+
+// IsFinal 判断提现是否已处理完成（成功或失败）
+func (s WithdrawStatus) IsFinal() bool {
+	return s == WithdrawStatusSuccess || s == WithdrawStatusFailed
+}
+
+// IsSuccess 判断提现是否成功
+func (s WithdrawStatus) IsSuccess() bool {
+	return s == WithdrawStatusSuccess
+}
+
+// TransferStatus 转账（代付）状态
+type TransferStatus int
+
+const (
+	// TransferStatusProcessing 转账处理中
+	TransferStatusProcessing TransferStatus = 0
+	// TransferStatusSuccess 转账成功（已到账）
+	TransferStatusSuccess TransferStatus = 1
+	// TransferStatusFailed 转账失败
+	TransferStatusFailed TransferStatus = 2
+	// TransferStatusReturned 转账退票（到账后被收款银行退回）
+	TransferStatusReturned TransferStatus = 3
+)
+
+// transferStatusNames 转账状态名称
+var transferStatusNames = map[TransferStatus]string{
+	TransferStatusProcessing: "转账处理中",
+	TransferStatusSuccess:    "转账成功",
+	TransferStatusFailed:     "转账失败",
+	TransferStatusReturned:   "转账退票",
+}
+
+// String 返回转账状态名称，未知状态返回 TransferStatus(n)
+func (s TransferStatus) String() string {
+	if name, ok := transferStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("TransferStatus(%d)", int(s))
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口，支持数字和字符串两种形式
+func (s *TransferStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalIntEnum(data, s)
+}
+
+// IsFinal 判断转账是否已处理完成（成功、失败或退票）
+// 注意: 转账成功后仍可能发生退票，对账时需关注状态变化
+func (s TransferStatus) IsFinal() bool {
+	return s == TransferStatusSuccess || s == TransferStatusFailed || s == TransferStatusReturned
+}
+
+// IsSuccess 判断转账是否成功
+func (s TransferStatus) IsSuccess() bool {
+	return s == TransferStatusSuccess
+}
+
+// BatchTransferStatus 批量转账批次状态
+type BatchTransferStatus int
+
+const (
+	// BatchTransferStatusProcessing 批次处理中
+	BatchTransferStatusProcessing BatchTransferStatus = 0
+	// BatchTransferStatusFinished 批次处理完成，各明细的结果以明细状态为准
+	BatchTransferStatusFinished BatchTransferStatus = 1
+	// BatchTransferStatusRejected 批次被拒绝（例如余额不足、校验失败），所有明细均未转账
+	BatchTransferStatusRejected BatchTransferStatus = 2
+)
+
+// batchTransferStatusNames 批次状态名称
+var batchTransferStatusNames = map[BatchTransferStatus]string{
+	BatchTransferStatusProcessing: "批次处理中",
+	BatchTransferStatusFinished:   "批次处理完成",
+	BatchTransferStatusRejected:   "批次被拒绝",
+}
+
+// String 返回批次状态名称，未知状态返回 BatchTransferStatus(n)
+func (s BatchTransferStatus) String() string {
+	if name, ok := batchTransferStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("BatchTransferStatus(%d)", int(s))
+}
+
+// IsFinal 判断批次是否已处理完成（完成或被拒绝）
+func (s BatchTransferStatus) IsFinal() bool {
+	return s == BatchTransferStatusFinished || s == BatchTransferStatusRejected
+}
+
+// IsSuccess 判断批次是否处理完成
+// 注意: 批次处理完成不代表所有明细均转账成功，需逐条检查明细状态
+func (s BatchTransferStatus) IsSuccess() bool {
+	return s == BatchTransferStatusFinished
+}
+
+// ContractStatus 代扣协议状态
+type ContractStatus int
+
+const (
+	// ContractStatusSigning 签约中，等待用户确认
+	ContractStatusSigning ContractStatus = 0
+	// ContractStatusSigned 已签约，可发起扣款
+	ContractStatusSigned ContractStatus = 1
+	// ContractStatusTerminated 已解约（商户或用户解约）
+	ContractStatusTerminated ContractStatus = 2
+	// ContractStatusFailed 签约失败（用户取消或签约超时）
+	ContractStatusFailed ContractStatus = 3
+)
+
+// contractStatusNames 代扣协议状态名称
+var contractStatusNames = map[ContractStatus]string{
+	ContractStatusSigning:    "签约中",
+	ContractStatusSigned:     "已签约",
+	ContractStatusTerminated: "已解约",
+	ContractStatusFailed:     "签约失败",
+}
+
+// String 返回代扣协议状态名称，未知状态返回 ContractStatus(n)
+func (s ContractStatus) String() string {
+	if name, ok := contractStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("ContractStatus(%d)", int(s))
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口，支持数字和字符串两种形式
+func (s *ContractStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalIntEnum(data, s)
+}
+
+// IsFinal 判断签约流程是否已结束（已签约、已解约或签约失败）
+func (s ContractStatus) IsFinal() bool {
+	return s == ContractStatusSigned || s == ContractStatusTerminated || s == ContractStatusFailed
+}
+
+// IsSuccess 判断协议是否处于已签约状态（可发起扣款）
+func (s ContractStatus) IsSuccess() bool {
+	return s == ContractStatusSigned
+}
+
+// PreAuthStatus 预授权状态
+type PreAuthStatus int
+
+const (
+	// PreAuthStatusPending 待授权，等待用户确认冻结
+	PreAuthStatusPending PreAuthStatus = 0
+	// PreAuthStatusFrozen 已冻结，可发起扣款（转支付）或解冻
+	PreAuthStatusFrozen PreAuthStatus = 1
+	// PreAuthStatusCaptured 已完成，冻结金额已全部扣款或解冻
+	PreAuthStatusCaptured PreAuthStatus = 2
+	// PreAuthStatusReleased 已解冻，冻结金额已全部解冻，未发生扣款
+	PreAuthStatusReleased PreAuthStatus = 3
+	// PreAuthStatusClosed 已关闭（用户未授权或授权超时）
+	PreAuthStatusClosed PreAuthStatus = 4
+)
+
+// preAuthStatusNames 预授权状态名称
+var preAuthStatusNames = map[PreAuthStatus]string{
+	PreAuthStatusPending:  "待授权",
+	PreAuthStatusFrozen:   "已冻结",
+	PreAuthStatusCaptured: "已完成",
+	PreAuthStatusReleased: "已解冻",
+	PreAuthStatusClosed:   "已关闭",
+}
+
+// String 返回预授权状态名称，未知状态返回 PreAuthStatus(n)
+func (s PreAuthStatus) String() string {
+	if name, ok := preAuthStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("PreAuthStatus(%d)", int(s))
+}
+
+// IsFinal 判断预授权是否已结束（不再有冻结中的金额）
+func (s PreAuthStatus) IsFinal() bool {
+	return s == PreAuthStatusCaptured || s == PreAuthStatusReleased || s == PreAuthStatusClosed
+}
+
+// IsSuccess 判断预授权是否冻结成功（包括冻结后已完成扣款或解冻的预授权）
+func (s PreAuthStatus) IsSuccess() bool {
+	return s == PreAuthStatusFrozen || s == PreAuthStatusCaptured || s == PreAuthStatusReleased
+}
+
+// SubMerchantStatus 子商户进件状态
+//
+// 进件流程: 提交申请（待补充资料）→ 上传资质 → 审核中 → 待签约 → 开户成功
+// 审核驳回后可补充资料重新提交，进入审核中状态
+type SubMerchantStatus int
+
+const (
+	// SubMerchantStatusPending 已提交申请，待上传资质材料
+	SubMerchantStatusPending SubMerchantStatus = 0
+	// SubMerchantStatusAuditing 资质审核中
+	SubMerchantStatusAuditing SubMerchantStatus = 1
+	// SubMerchantStatusRejected 审核驳回，可根据驳回原因补充资料后重新提交
+	SubMerchantStatusRejected SubMerchantStatus = 2
+	// SubMerchantStatusToBeSigned 审核通过，待商户确认签约（通过 SignUrl 完成签约和账户验证）
+	SubMerchantStatusToBeSigned SubMerchantStatus = 3
+	// SubMerchantStatusCompleted 开户成功，可正常交易
+	SubMerchantStatusCompleted SubMerchantStatus = 4
+	// SubMerchantStatusFrozen 已冻结，暂停交易
+	SubMerchantStatusFrozen SubMerchantStatus = 5
+)
+
+// subMerchantStatusNames 子商户进件状态名称
+var subMerchantStatusNames = map[SubMerchantStatus]string{
+	SubMerchantStatusPending:    "待上传资质",
+	SubMerchantStatusAuditing:   "审核中",
+	SubMerchantStatusRejected:   "审核驳回",
+	SubMerchantStatusToBeSigned: "待签约",
+	SubMerchantStatusCompleted:  "开户成功",
+	SubMerchantStatusFrozen:     "已冻结",
+}
+
+// String 返回子商户进件状态名称，未知状态返回 SubMerchantStatus(n)
+func (s SubMerchantStatus) String() string {
+	if name, ok := subMerchantStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("SubMerchantStatus(%d)", int(s))
+}
+
+// IsFinal 判断进件流程是否已结束（开户成功或已冻结）
+// 审核驳回后仍可补充资料重新提交，因此不视为终态
+func (s SubMerchantStatus) IsFinal() bool {
+	return s == SubMerchantStatusCompleted || s == SubMerchantStatusFrozen
+}
+
+// IsSuccess 判断子商户是否开户成功
+func (s SubMerchantStatus) IsSuccess() bool {
+	return s == SubMerchantStatusCompleted
+}
+
+// NeedsAction 判断当前状态是否需要商户侧操作
+// 待上传资质、审核驳回时需要上传或补充资料，待签约时需要引导商户完成签约
+func (s SubMerchantStatus) NeedsAction() bool {
+	switch s {
+	case SubMerchantStatusPending, SubMerchantStatusRejected, SubMerchantStatusToBeSigned:
+		return true
+	}
+	return false
+}
+
+// ReceiptStatus 电子回单生成状态
+type ReceiptStatus int
+
+const (
+	// ReceiptStatusProcessing 生成中
+	ReceiptStatusProcessing ReceiptStatus = 0
+	// ReceiptStatusSuccess 已生成，可下载
+	ReceiptStatusSuccess ReceiptStatus = 1
+	// ReceiptStatusFailed 生成失败，失败原因见 FailReason
+	ReceiptStatusFailed ReceiptStatus = 2
+)
+
+// receiptStatusNames 电子回单生成状态名称
+var receiptStatusNames = map[ReceiptStatus]string{
+	ReceiptStatusProcessing: "生成中",
+	ReceiptStatusSuccess:    "已生成",
+	ReceiptStatusFailed:     "生成失败",
+}
+
+// String 返回电子回单生成状态名称，未知状态返回 ReceiptStatus(n)
+func (s ReceiptStatus) String() string {
+	if name, ok := receiptStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("ReceiptStatus(%d)", int(s))
+}
+
+// IsFinal 判断电子回单是否已生成完毕（成功或失败）
+func (s ReceiptStatus) IsFinal() bool {
+	return s == ReceiptStatusSuccess || s == ReceiptStatusFailed
+}
+
+// IsSuccess 判断电子回单是否生成成功
+func (s ReceiptStatus) IsSuccess() bool {
+	return s == ReceiptStatusSuccess
+}
+
+// InvoiceStatus 电子发票状态
+//
+// 开票流程: 开具中 → 已开具（或开具失败）→ 红冲中 → 已红冲
+// 红冲失败时发票仍为已开具状态，失败原因见 FailReason
+type InvoiceStatus int
+
+const (
+	// InvoiceStatusIssuing 开具中
+	InvoiceStatusIssuing InvoiceStatus = 0
+	// InvoiceStatusIssued 已开具
+	InvoiceStatusIssued InvoiceStatus = 1
+	// InvoiceStatusFailed 开具失败，可修正抬头等信息后重新申请
+	InvoiceStatusFailed InvoiceStatus = 2
+	// InvoiceStatusRedFlushing 红冲中
+	InvoiceStatusRedFlushing InvoiceStatus = 3
+	// InvoiceStatusRedFlushed 已红冲（已开具红字发票冲销）
+	InvoiceStatusRedFlushed InvoiceStatus = 4
+)
+
+// invoiceStatusNames 电子发票状态名称
+var invoiceStatusNames = map[InvoiceStatus]string{
+	InvoiceStatusIssuing:     "开具中",
+	InvoiceStatusIssued:      "已开具",
+	InvoiceStatusFailed:      "开具失败",
+	InvoiceStatusRedFlushing: "红冲中",
+	InvoiceStatusRedFlushed:  "已红冲",
+}
+
+// String 返回电子发票状态名称，未知状态返回 InvoiceStatus(n)
+func (s InvoiceStatus) String() string {
+	if name, ok := invoiceStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("InvoiceStatus(%d)", int(s))
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler 接口，支持数字和字符串两种形式
+func (s *InvoiceStatus) UnmarshalJSON(data []byte) error {
+	return unmarshalIntEnum(data, s)
+}
+
+// IsFinal 判断开票或红冲是否已处理完毕
+// 已开具的发票仍可红冲，但开票本身已结束，因此视为终态
+func (s InvoiceStatus) IsFinal() bool {
+	return s == InvoiceStatusIssued || s == InvoiceStatusFailed || s == InvoiceStatusRedFlushed
+}
+
+// IsSuccess 判断发票是否已开具且未被红冲
+func (s InvoiceStatus) IsSuccess() bool {
+	return s == InvoiceStatusIssued
+}
+
+// CouponActivityStatus 优惠活动状态
+type CouponActivityStatus int
+
+const (
+	// CouponActivityStatusPending 未开始
+	CouponActivityStatusPending CouponActivityStatus = 0
+	// CouponActivityStatusActive 进行中，可发放和核销优惠券
+	CouponActivityStatusActive CouponActivityStatus = 1
+	// CouponActivityStatusEnded 已结束（到达结束时间或优惠券已发放完毕）
+	CouponActivityStatusEnded CouponActivityStatus = 2
+	// CouponActivityStatusStopped 已停用
+	CouponActivityStatusStopped CouponActivityStatus = 3
+)
+
+// couponActivityStatusNames 优惠活动状态名称
+var couponActivityStatusNames = map[CouponActivityStatus]string{
+	CouponActivityStatusPending: "未开始",
+	CouponActivityStatusActive:  "进行中",
+	CouponActivityStatusEnded:   "已结束",
+	CouponActivityStatusStopped: "已停用",
+}
+
+// String 返回优惠活动状态名称，未知状态返回 CouponActivityStatus(n)
+func (s CouponActivityStatus) String() string {
+	if name, ok := couponActivityStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("CouponActivityStatus(%d)", int(s))
+}
+
+// IsFinal 判断优惠活动是否已结束或停用
+func (s CouponActivityStatus) IsFinal() bool {
+	return s == CouponActivityStatusEnded || s == CouponActivityStatusStopped
+}
+
+// RiskHoldStatus 风控挂起状态
+//
+// 命中风控规则的订单资金被挂起，人工审核后放行或拒绝；拒绝的订单由平台原路退款
+type RiskHoldStatus int
+
+const (
+	// RiskHoldStatusNone 未挂起
+	RiskHoldStatusNone RiskHoldStatus = 0
+	// RiskHoldStatusHeld 已挂起，等待风控审核
+	RiskHoldStatusHeld RiskHoldStatus = 1
+	// RiskHoldStatusReleased 审核通过，已放行
+	RiskHoldStatusReleased RiskHoldStatus = 2
+	// RiskHoldStatusRejected 审核拒绝，订单将原路退款
+	RiskHoldStatusRejected RiskHoldStatus = 3
+)
+
+// riskHoldStatusNames 风控挂起状态名称
+var riskHoldStatusNames = map[RiskHoldStatus]string{
+	RiskHoldStatusNone:     "未挂起",
+	RiskHoldStatusHeld:     "已挂起",
+	RiskHoldStatusReleased: "已放行",
+	RiskHoldStatusRejected: "已拒绝",
+}
+
+// String 返回风控挂起状态名称，未知状态返回 RiskHoldStatus(n)
+func (s RiskHoldStatus) String() string {
+	if name, ok := riskHoldStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("RiskHoldStatus(%d)", int(s))
+}
+
+// IsHeld 判断订单资金是否仍处于挂起状态
+func (s RiskHoldStatus) IsHeld() bool {
+	return s == RiskHoldStatusHeld
+}
+
+// ChannelState 支付渠道状态
+//
+// 银联、网联等上游渠道会定期停机维护，维护期间该渠道的支付方式无法下单
+type ChannelState int
+
+const (
+	// ChannelStateNormal 正常
+	ChannelStateNormal ChannelState = 0
+	// ChannelStateMaintenance 维护中，维护结束后自动恢复
+	ChannelStateMaintenance ChannelState = 1
+	// ChannelStateUnavailable 不可用，通常为渠道故障或平台暂停了该渠道
+	ChannelStateUnavailable ChannelState = 2
+)
+
+// channelStateNames 支付渠道状态名称
+var channelStateNames = map[ChannelState]string{
+	ChannelStateNormal:      "正常",
+	ChannelStateMaintenance: "维护中",
+	ChannelStateUnavailable: "不可用",
+}
+
+// String 返回支付渠道状态名称，未知状态返回 ChannelState(n)
+func (s ChannelState) String() string {
+	if name, ok := channelStateNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("ChannelState(%d)", int(s))
+}
+
+// IsAvailable 判断渠道当前是否可以下单，未知状态视为不可用
+func (s ChannelState) IsAvailable() bool {
+	return s == ChannelStateNormal
+}
+
+// BindCardStatus 提现银行卡绑定状态
+//
+// 绑卡流程: 提交绑卡 → 验证中（银行四要素验证）→ 已绑定；验证失败或解绑后不能再用于提现
+type BindCardStatus int
+
+const (
+	// BindCardStatusVerifying 验证中
+	BindCardStatusVerifying BindCardStatus = 0
+	// BindCardStatusBound 已绑定，可用于提现
+	BindCardStatusBound BindCardStatus = 1
+	// BindCardStatusFailed 验证失败
+	BindCardStatusFailed BindCardStatus = 2
+	// BindCardStatusUnbound 已解绑
+	BindCardStatusUnbound BindCardStatus = 3
+)
+
+// bindCardStatusNames 银行卡绑定状态名称
+var bindCardStatusNames = map[BindCardStatus]string{
+	BindCardStatusVerifying: "验证中",
+	BindCardStatusBound:     "已绑定",
+	BindCardStatusFailed:    "验证失败",
+	BindCardStatusUnbound:   "已解绑",
+}
+
+// String 返回银行卡绑定状态名称，未知状态返回 BindCardStatus(n)
+func (s BindCardStatus) String() string {
+	if name, ok := bindCardStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("BindCardStatus(%d)", int(s))
+}
+
+// IsBound 判断银行卡是否已绑定，可用于提现
+func (s BindCardStatus) IsBound() bool {
+	return s == BindCardStatusBound
+}
+
+// unmarshalIntEnum 解析整数枚举，支持数字和字符串两种形式（表单格式的回调通知中数字为字符串）
+func unmarshalIntEnum[T ~int](data []byte, v *T) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	text := string(data)
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		text = strings.TrimSpace(text[1 : len(text)-1])
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil {
+		return fmt.Errorf("invalid %T value %s", *v, data)
+	}
+	*v = T(n)
+	return nil
+}
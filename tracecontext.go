@@ -0,0 +1,113 @@
+package haozpay
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+const (
+	// TraceParentHeader W3C Trace Context 的 traceparent 请求头
+	TraceParentHeader = "traceparent"
+	// TraceStateHeader W3C Trace Context 的 tracestate 请求头
+	TraceStateHeader = "tracestate"
+)
+
+// TraceContext W3C Trace Context 链路追踪信息
+// 接口调用的 context 中携带 TraceContext 时，SDK 将其作为 traceparent、tracestate 请求头发送到平台，
+// 使平台侧的链路与业务系统的链路关联
+type TraceContext struct {
+	// TraceParent traceparent 请求头的值，格式为 version-traceId-parentId-flags，
+	// 例如 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+	TraceParent string
+	// TraceState tracestate 请求头的值，可为空
+	TraceState string
+}
+
+// Valid 判断 TraceParent 是否符合 W3C Trace Context 格式
+// traceId 和 parentId 不能全为 0，版本号不能为 ff
+func (tc TraceContext) Valid() bool {
+	parts := strings.Split(tc.TraceParent, "-")
+	if len(parts) < 4 {
+		return false
+	}
+	version, traceID, parentID, flags := parts[0], parts[1], parts[2], parts[3]
+	if !isLowerHex(version, 2) || version == "ff" || (version == "00" && len(parts) != 4) {
+		return false
+	}
+	return isLowerHex(traceID, 32) && strings.Trim(traceID, "0") != "" &&
+		isLowerHex(parentID, 16) && strings.Trim(parentID, "0") != "" &&
+		isLowerHex(flags, 2)
+}
+
+// isLowerHex 判断 s 是否为长度为 n 的小写十六进制字符串
+func isLowerHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// TraceContextFromHeader 从 HTTP 请求头中读取 W3C Trace Context
+// 用于在处理业务系统收到的请求时取得上游的链路追踪信息
+//
+// 示例:
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//	    ctx := haozpay.WithTraceContext(r.Context(), haozpay.TraceContextFromHeader(r.Header))
+//	    resp, err := client.Payment.CreateRefund(ctx, req)
+//	    ...
+//	}
+func TraceContextFromHeader(header http.Header) TraceContext {
+	return TraceContext{
+		TraceParent: strings.TrimSpace(header.Get(TraceParentHeader)),
+		TraceState:  strings.TrimSpace(strings.Join(header.Values(TraceStateHeader), ",")),
+	}
+}
+
+// traceContextKey context 中存储链路追踪信息的键
+type traceContextKey struct{}
+
+// WithTraceContext 为接口调用指定 W3C Trace Context
+// TraceParent 格式不正确时不发送链路追踪请求头
+//
+// 参数:
+//   - ctx: 调用使用的 context
+//   - tc: 链路追踪信息
+//
+// 返回:
+//   - context.Context: 携带链路追踪信息的 context
+func WithTraceContext(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceContextKey{}, tc)
+}
+
+// TraceContextFromContext 获取 context 中通过 WithTraceContext 指定的链路追踪信息
+// 未设置或 TraceParent 格式不正确时返回 false
+func TraceContextFromContext(ctx context.Context) (TraceContext, bool) {
+	if ctx == nil {
+		return TraceContext{}, false
+	}
+	tc, ok := ctx.Value(traceContextKey{}).(TraceContext)
+	if !ok || !tc.Valid() {
+		return TraceContext{}, false
+	}
+	return tc, true
+}
+
+// injectTraceContext 将 context 中的链路追踪信息写入请求头
+func injectTraceContext(ctx context.Context, header http.Header) {
+	tc, ok := TraceContextFromContext(ctx)
+	if !ok {
+		return
+	}
+	header.Set(TraceParentHeader, tc.TraceParent)
+	if tc.TraceState != "" {
+		header.Set(TraceStateHeader, tc.TraceState)
+	}
+}
@@ -0,0 +1,156 @@
+package haozpay
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// MaxBatchTransferItems 单个批量转账批次允许的最大明细数
+const MaxBatchTransferItems = 1000
+
+type TransferService struct {
+	executor *apiExecutor
+}
+
+func NewTransferService(client *resty.Client, config *Config) *TransferService {
+	return &TransferService{
+		executor: newAPIExecutor(client, config),
+	}
+}
+
+func (s *TransferService) CreateTransfer(ctx context.Context, req *CreateTransferRequest, opts ...RequestOption) (*TransferResponse, error) {
+	if !req.PayeeType.IsValid() {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid payeeType: %d", req.PayeeType),
+			StatusCode: 0,
+		}
+	}
+
+	var resp *TransferResponse
+	if err := s.executor.post(ctx, "/pay-core/transfer/apply", req, &resp, "failed to create transfer", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *TransferService) QueryTransfer(ctx context.Context, req *QueryTransferRequest, opts ...RequestOption) (*QueryTransferResponse, error) {
+	var resp *QueryTransferResponse
+	if err := s.executor.post(ctx, "/pay-core/transfer/query", req, &resp, "failed to query transfer", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *TransferService) CreateBatchTransfer(ctx context.Context, req *CreateBatchTransferRequest, opts ...RequestOption) (*BatchTransferResponse, error) {
+	batch, err := normalizeBatchTransfer(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *BatchTransferResponse
+	if err := s.executor.post(ctx, "/pay-core/transfer/batch/apply", batch, &resp, "failed to create batch transfer", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateBatchTransfers 按 MaxBatchTransferItems 将明细拆分为多个批次依次提交
+// 拆分为多个批次时批次号为 req.BatchNo 加序号后缀（例如 B001_1、B001_2），汇总金额和笔数按批次重新计算
+// 某个批次提交失败时停止提交，返回已成功提交的批次结果和错误
+func (s *TransferService) CreateBatchTransfers(ctx context.Context, req *CreateBatchTransferRequest, opts ...RequestOption) ([]*BatchTransferResponse, error) {
+	chunks := ChunkBatchTransferItems(req.Items, MaxBatchTransferItems)
+	if len(chunks) <= 1 {
+		resp, err := s.CreateBatchTransfer(ctx, req, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return []*BatchTransferResponse{resp}, nil
+	}
+
+	responses := make([]*BatchTransferResponse, 0, len(chunks))
+	for i, items := range chunks {
+		batch := *req
+		batch.BatchNo = fmt.Sprintf("%s_%d", req.BatchNo, i+1)
+		batch.Items = items
+		batch.TotalAmount = 0
+		batch.TotalCount = 0
+
+		resp, err := s.CreateBatchTransfer(ctx, &batch, opts...)
+		if err != nil {
+			return responses, err
+		}
+		responses = append(responses, resp)
+	}
+	return responses, nil
+}
+
+func (s *TransferService) QueryBatchTransfer(ctx context.Context, req *QueryBatchTransferRequest, opts ...RequestOption) (*QueryBatchTransferResponse, error) {
+	var resp *QueryBatchTransferResponse
+	if err := s.executor.post(ctx, "/pay-core/transfer/batch/query", req, &resp, "failed to query batch transfer", opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ChunkBatchTransferItems 将转账明细按 size 拆分为多个批次
+// size 小于等于 0 或超过 MaxBatchTransferItems 时使用 MaxBatchTransferItems
+// 返回的批次共享 items 的底层数组
+func ChunkBatchTransferItems(items []BatchTransferItem, size int) [][]BatchTransferItem {
+	if size <= 0 || size > MaxBatchTransferItems {
+		size = MaxBatchTransferItems
+	}
+
+	chunks := make([][]BatchTransferItem, 0, (len(items)+size-1)/size)
+	for start := 0; start < len(items); start += size {
+		end := start + size
+		if end > len(items) {
+			end = len(items)
+		}
+		chunks = append(chunks, items[start:end:end])
+	}
+	return chunks
+}
+
+// normalizeBatchTransfer 校验批量转账明细，未设置汇总金额和笔数时按明细计算
+// 返回请求副本，不修改调用方传入的请求对象
+func normalizeBatchTransfer(req *CreateBatchTransferRequest) (*CreateBatchTransferRequest, error) {
+	if len(req.Items) == 0 || len(req.Items) > MaxBatchTransferItems {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("batch transfer items must be between 1 and %d, got %d", MaxBatchTransferItems, len(req.Items)),
+			StatusCode: 0,
+		}
+	}
+
+	var total Money
+	for i, item := range req.Items {
+		if !item.PayeeType.IsValid() {
+			return nil, &SDKError{
+				Code:       ErrInvalidParameter.Code,
+				Message:    fmt.Sprintf("invalid payeeType of item %d: %d", i, item.PayeeType),
+				StatusCode: 0,
+			}
+		}
+		total += item.TransferAmount
+	}
+
+	batch := *req
+	if batch.TotalCount == 0 {
+		batch.TotalCount = len(batch.Items)
+	}
+	if batch.TotalAmount == 0 {
+		batch.TotalAmount = total
+	}
+	if batch.TotalCount != len(batch.Items) || batch.TotalAmount != total {
+		return nil, &SDKError{
+			Code: ErrInvalidParameter.Code,
+			Message: fmt.Sprintf("batch transfer totals mismatch: totalCount=%d, totalAmount=%s, items=%d, itemsAmount=%s",
+				batch.TotalCount, batch.TotalAmount, len(batch.Items), total),
+			StatusCode: 0,
+		}
+	}
+	return &batch, nil
+}
@@ -0,0 +1,56 @@
+package haozpay_test
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+func TestCreateTransferEncryptsPayee(t *testing.T) {
+	const path = "/pay-core/transfer/apply"
+	server, client := newTestGateway(t)
+	server.Respond(path, &haozpay.TransferResponse{ReqSeqId: "T1"})
+
+	req := &haozpay.CreateTransferRequest{
+		ReqSeqId:       "T1",
+		TransferAmount: haozpay.Fen(100),
+		PayeeType:      haozpay.PayeeTypeBankCard,
+		PayeeAccount:   "6222020000000001",
+		PayeeName:      "张三",
+		BankCode:       "ICBC",
+	}
+	if _, err := client.Transfer.CreateTransfer(context.Background(), req); err != nil {
+		t.Fatalf("CreateTransfer() error = %v", err)
+	}
+
+	// 网关收到的请求只包含密文，签名基于密文计算
+	requests := server.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("requests = %d, want 1", len(requests))
+	}
+	var sent haozpay.CreateTransferRequest
+	if err := requests[0].Decode(&sent); err != nil {
+		t.Fatal(err)
+	}
+	for _, plaintext := range []string{"6222020000000001", "张三"} {
+		if strings.Contains(requests[0].BizBody, plaintext) {
+			t.Errorf("bizBody contains plaintext %q: %s", plaintext, requests[0].BizBody)
+		}
+	}
+	for name, ciphertext := range map[string]string{"payeeAccount": sent.PayeeAccount, "payeeName": sent.PayeeName} {
+		if _, err := base64.StdEncoding.DecodeString(ciphertext); ciphertext == "" || err != nil {
+			t.Errorf("%s = %q, want Base64 ciphertext", name, ciphertext)
+		}
+	}
+	if sent.BankCode != "ICBC" || sent.PayeeIdCardNo != "" {
+		t.Errorf("untagged or empty fields changed: %s", requests[0].BizBody)
+	}
+
+	// 调用方的请求对象保持明文
+	if req.PayeeAccount != "6222020000000001" || req.PayeeName != "张三" {
+		t.Errorf("request was modified: %+v", req)
+	}
+}
@@ -1,39 +1,91 @@
 package haozpay
 
-import "time"
+import (
+	"encoding/json"
+	"io"
+)
 
 type Response struct {
-	Code      int         `json:"code"`
-	Message   string      `json:"message"`
-	Data      interface{} `json:"data,omitempty"`
-	RequestID string      `json:"request_id,omitempty"`
-	Timestamp int64       `json:"timestamp,omitempty"`
+	Code      int             `json:"code"`
+	Message   string          `json:"message"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	RequestID string          `json:"request_id,omitempty"`
+	Timestamp int64           `json:"timestamp,omitempty"`
+	Sign      string          `json:"sign,omitempty"`
+
+	SubCode     string       `json:"subCode,omitempty"`
+	SubMessage  string       `json:"subMsg,omitempty"`
+	FieldErrors []FieldError `json:"fieldErrors,omitempty"`
 }
 
 type HaozPayRequest struct {
-	MerchantNo string `json:"merchantNo"`
-	Timestamp  int64  `json:"timestamp"`
-	BizBody    string `json:"bizBody"`
-	Sign       string `json:"sign"`
+	MerchantNo string   `json:"merchantNo"`
+	Timestamp  int64    `json:"timestamp"`
+	BizBody    string   `json:"bizBody"`
+	SignType   SignType `json:"signType,omitempty"`
+	Sign       string   `json:"sign"`
 }
 
 type CreatePaymentOrderRequest struct {
-	OrderTitle        string  `json:"orderTitle"`
-	OrderAmount       float64 `json:"orderAmount"`
-	PayType           int     `json:"payType"`
-	UseHaozPayCashier bool    `json:"useHaozPayCashier"`
-	NotifyUrl         string  `json:"notifyUrl"`
+	OrderTitle        string   `json:"orderTitle"`
+	OrderAmount       Money    `json:"orderAmount"`
+	Currency          Currency `json:"currency,omitempty"`
+	PayType           PayType  `json:"payType"`
+	UseHaozPayCashier bool     `json:"useHaozPayCashier"`
+	NotifyUrl         string   `json:"notifyUrl"`
+	ReturnUrl         string   `json:"returnUrl,omitempty"`
+	ClientIp          string   `json:"clientIp,omitempty"`
+	SceneType         string   `json:"sceneType,omitempty"`
+	SceneName         string   `json:"sceneName,omitempty"`
+	SceneUrl          string   `json:"sceneUrl,omitempty"`
 }
 
 type PaymentOrderResponse struct {
-	MerchantNo      string  `json:"merchantNo"`
-	ChannelType     string  `json:"channelType"`
-	SeqId           string  `json:"seqId"`
-	PayType         int     `json:"payType"`
-	OrderTitle      string  `json:"orderTitle"`
-	OrderAmount     float64 `json:"orderAmount"`
-	PayInfo         string  `json:"payInfo"`
-	MerchantOrderNo string  `json:"merchantOrderNo"`
+	MerchantNo      string   `json:"merchantNo"`
+	ChannelType     string   `json:"channelType"`
+	SeqId           string   `json:"seqId"`
+	PayType         PayType  `json:"payType"`
+	OrderTitle      string   `json:"orderTitle"`
+	OrderAmount     Money    `json:"orderAmount"`
+	Currency        Currency `json:"currency"`
+	PayInfo         string   `json:"payInfo"`
+	MerchantOrderNo string   `json:"merchantOrderNo"`
+}
+
+type QueryOrderRequest struct {
+	OrderNo string `json:"orderNo"`
+}
+
+type QueryOrderResponse struct {
+	MerchantNo      string      `json:"merchantNo"`
+	OrderNo         string      `json:"orderNo"`
+	MerchantOrderNo string      `json:"merchantOrderNo"`
+	SeqId           string      `json:"seqId"`
+	ChannelType     string      `json:"channelType"`
+	ChannelTransId  string      `json:"channelTransId"`
+	PayType         PayType     `json:"payType"`
+	OrderTitle      string      `json:"orderTitle"`
+	OrderAmount     Money       `json:"orderAmount"`
+	Currency        Currency    `json:"currency"`
+	PaidAmount      Money       `json:"paidAmount"`
+	OrderStatus     OrderStatus `json:"orderStatus"`
+	OrderStatusDesc string      `json:"orderStatusDesc"`
+	CreateTime      HaozTime    `json:"createTime"`
+	FinishTime      HaozTime    `json:"finishTime"`
+
+	DiscountAmount   Money             `json:"discountAmount"`
+	PromotionDetails []PromotionDetail `json:"promotionDetails"`
+}
+
+type PromotionDetail struct {
+	ActivityNo         string     `json:"activityNo"`
+	ActivityName       string     `json:"activityName"`
+	CouponNo           string     `json:"couponNo"`
+	CouponType         CouponType `json:"couponType"`
+	DiscountAmount     Money      `json:"discountAmount"`
+	MerchantContribute Money      `json:"merchantContribute"`
+	PlatformContribute Money      `json:"platformContribute"`
+	OtherContribute    Money      `json:"otherContribute"`
 }
 
 type CancelPaymentOrderRequest struct {
@@ -42,30 +94,32 @@ type CancelPaymentOrderRequest struct {
 }
 
 type CreateRefundRequest struct {
-	OrderNo      string  `json:"orderNo"`
-	RefundAmount float64 `json:"refundAmount"`
-	RefundReason string  `json:"refundReason,omitempty"`
-	Remark       string  `json:"remark,omitempty"`
-	NotifyUrl    string  `json:"notifyUrl,omitempty"`
+	OrderNo      string   `json:"orderNo"`
+	RefundAmount Money    `json:"refundAmount"`
+	Currency     Currency `json:"currency,omitempty"`
+	RefundReason string   `json:"refundReason,omitempty"`
+	Remark       string   `json:"remark,omitempty"`
+	NotifyUrl    string   `json:"notifyUrl,omitempty"`
 }
 
 type RefundResponse struct {
-	MerchantNo        string    `json:"merchantNo"`
-	OrderNo           string    `json:"orderNo"`
-	SeqId             string    `json:"seqId"`
-	ReqDate           string    `json:"reqDate"`
-	PaySeqId          string    `json:"paySeqId"`
-	PayReqDate        string    `json:"payReqDate"`
-	PayUniqueId       string    `json:"payUniqueId"`
-	RefundStartDate   string    `json:"refundStartDate"`
-	RefundStartTime   time.Time `json:"refundStartTime"`
-	RefundFinishTime  time.Time `json:"refundFinishTime"`
-	RefundStatus      int       `json:"refundStatus"`
-	RefundAmount      float64   `json:"refundAmount"`
-	RealRefundAmount  float64   `json:"realRefundAmount"`
-	TotalRefAmount    string    `json:"totalRefAmount"`
-	TotalRefFeeAmount string    `json:"totalRefFeeAmount"`
-	RefCount          string    `json:"refCount"`
+	MerchantNo        string       `json:"merchantNo"`
+	OrderNo           string       `json:"orderNo"`
+	SeqId             string       `json:"seqId"`
+	ReqDate           string       `json:"reqDate"`
+	PaySeqId          string       `json:"paySeqId"`
+	PayReqDate        string       `json:"payReqDate"`
+	PayUniqueId       string       `json:"payUniqueId"`
+	RefundStartDate   string       `json:"refundStartDate"`
+	RefundStartTime   HaozTime     `json:"refundStartTime"`
+	RefundFinishTime  HaozTime     `json:"refundFinishTime"`
+	RefundStatus      RefundStatus `json:"refundStatus"`
+	RefundAmount      Money        `json:"refundAmount"`
+	Currency          Currency     `json:"currency"`
+	RealRefundAmount  Money        `json:"realRefundAmount"`
+	TotalRefAmount    Money        `json:"totalRefAmount"`
+	TotalRefFeeAmount Money        `json:"totalRefFeeAmount"`
+	RefCount          string       `json:"refCount"`
 }
 
 type QueryRefundRequest struct {
@@ -73,28 +127,778 @@ type QueryRefundRequest struct {
 }
 
 type QueryRefundResponse struct {
-	MerchantNo         string  `json:"merchantNo"`
-	OrderNo            string  `json:"orderNo"`
-	RefundSeqId        string  `json:"refundSeqId"`
-	PaySeqId           string  `json:"paySeqId"`
-	PayReqDate         string  `json:"payReqDate"`
-	RefundAmount       float64 `json:"refundAmount"`
-	ActualRefundAmount float64 `json:"actualRefundAmount"`
-	RefundStatus       int     `json:"refundStatus"`
-	RefundStatusDesc   string  `json:"refundStatusDesc"`
-	TransFinishTime    string  `json:"transFinishTime"`
-	FeeAmount          float64 `json:"feeAmount"`
-	AcctSplitBunch     string  `json:"acctSplitBunch"`
-	UnconfirmAmount    float64 `json:"unconfirmAmount"`
-	ConfirmedAmount    float64 `json:"confirmedAmount"`
-	PayChannel         string  `json:"payChannel"`
-	Remark             string  `json:"remark"`
+	MerchantNo         string       `json:"merchantNo"`
+	OrderNo            string       `json:"orderNo"`
+	RefundSeqId        string       `json:"refundSeqId"`
+	PaySeqId           string       `json:"paySeqId"`
+	PayReqDate         string       `json:"payReqDate"`
+	RefundAmount       Money        `json:"refundAmount"`
+	Currency           Currency     `json:"currency"`
+	ActualRefundAmount Money        `json:"actualRefundAmount"`
+	RefundStatus       RefundStatus `json:"refundStatus"`
+	RefundStatusDesc   string       `json:"refundStatusDesc"`
+	TransFinishTime    HaozTime     `json:"transFinishTime"`
+	FeeAmount          Money        `json:"feeAmount"`
+	AcctSplitBunch     string       `json:"acctSplitBunch"`
+	UnconfirmAmount    Money        `json:"unconfirmAmount"`
+	ConfirmedAmount    Money        `json:"confirmedAmount"`
+	PayChannel         string       `json:"payChannel"`
+	Remark             string       `json:"remark"`
 }
 
 type CreateWithdrawRequest struct {
-	PayChannel     string  `json:"payChannel"`
-	WithdrawAmount float64 `json:"withdrawAmount"`
-	ReqSeqId       string  `json:"reqSeqId"`
-	Remark         string  `json:"remark,omitempty"`
-	NotifyUrl      string  `json:"notifyUrl,omitempty"`
+	PayChannel     string `json:"payChannel"`
+	WithdrawAmount Money  `json:"withdrawAmount"`
+	ReqSeqId       string `json:"reqSeqId"`
+	BindId         string `json:"bindId,omitempty"`
+	Remark         string `json:"remark,omitempty"`
+	NotifyUrl      string `json:"notifyUrl,omitempty"`
+}
+
+type WithdrawResponse struct {
+	MerchantNo     string         `json:"merchantNo"`
+	ReqSeqId       string         `json:"reqSeqId"`
+	SeqId          string         `json:"seqId"`
+	PayChannel     string         `json:"payChannel"`
+	WithdrawAmount Money          `json:"withdrawAmount"`
+	FeeAmount      Money          `json:"feeAmount"`
+	WithdrawStatus WithdrawStatus `json:"withdrawStatus"`
+	CreateTime     HaozTime       `json:"createTime"`
+}
+
+type QueryWithdrawRequest struct {
+	ReqSeqId string `json:"reqSeqId"`
+}
+
+type QueryWithdrawResponse struct {
+	MerchantNo         string         `json:"merchantNo"`
+	ReqSeqId           string         `json:"reqSeqId"`
+	SeqId              string         `json:"seqId"`
+	PayChannel         string         `json:"payChannel"`
+	WithdrawAmount     Money          `json:"withdrawAmount"`
+	FeeAmount          Money          `json:"feeAmount"`
+	ArrivalAmount      Money          `json:"arrivalAmount"`
+	WithdrawStatus     WithdrawStatus `json:"withdrawStatus"`
+	WithdrawStatusDesc string         `json:"withdrawStatusDesc"`
+	CreateTime         HaozTime       `json:"createTime"`
+	ArrivalTime        HaozTime       `json:"arrivalTime"`
+	FailReason         string         `json:"failReason"`
+	Remark             string         `json:"remark"`
+}
+
+type CreateTransferRequest struct {
+	ReqSeqId       string    `json:"reqSeqId"`
+	TransferAmount Money     `json:"transferAmount"`
+	PayeeType      PayeeType `json:"payeeType"`
+	PayeeAccount   string    `json:"payeeAccount" haozpay:"encrypt"`
+	PayeeName      string    `json:"payeeName" haozpay:"encrypt"`
+	PayeeIdCardNo  string    `json:"payeeIdCardNo,omitempty" haozpay:"encrypt"`
+	PayeeMobile    string    `json:"payeeMobile,omitempty" haozpay:"encrypt"`
+	BankCode       string    `json:"bankCode,omitempty"`
+	BankName       string    `json:"bankName,omitempty"`
+	Remark         string    `json:"remark,omitempty"`
+	NotifyUrl      string    `json:"notifyUrl,omitempty"`
+}
+
+type TransferResponse struct {
+	MerchantNo     string         `json:"merchantNo"`
+	ReqSeqId       string         `json:"reqSeqId"`
+	TransferNo     string         `json:"transferNo"`
+	TransferAmount Money          `json:"transferAmount"`
+	FeeAmount      Money          `json:"feeAmount"`
+	TransferStatus TransferStatus `json:"transferStatus"`
+	CreateTime     HaozTime       `json:"createTime"`
+}
+
+type QueryTransferRequest struct {
+	ReqSeqId   string `json:"reqSeqId,omitempty"`
+	TransferNo string `json:"transferNo,omitempty"`
+}
+
+type QueryTransferResponse struct {
+	MerchantNo         string         `json:"merchantNo"`
+	ReqSeqId           string         `json:"reqSeqId"`
+	TransferNo         string         `json:"transferNo"`
+	PayeeType          PayeeType      `json:"payeeType"`
+	PayeeAccount       string         `json:"payeeAccount"`
+	PayeeName          string         `json:"payeeName"`
+	TransferAmount     Money          `json:"transferAmount"`
+	FeeAmount          Money          `json:"feeAmount"`
+	TransferStatus     TransferStatus `json:"transferStatus"`
+	TransferStatusDesc string         `json:"transferStatusDesc"`
+	ChannelTransId     string         `json:"channelTransId"`
+	CreateTime         HaozTime       `json:"createTime"`
+	FinishTime         HaozTime       `json:"finishTime"`
+	FailReason         string         `json:"failReason"`
+	Remark             string         `json:"remark"`
+}
+
+type BatchTransferItem struct {
+	ItemSeqId      string    `json:"itemSeqId"`
+	TransferAmount Money     `json:"transferAmount"`
+	PayeeType      PayeeType `json:"payeeType"`
+	PayeeAccount   string    `json:"payeeAccount" haozpay:"encrypt"`
+	PayeeName      string    `json:"payeeName" haozpay:"encrypt"`
+	PayeeIdCardNo  string    `json:"payeeIdCardNo,omitempty" haozpay:"encrypt"`
+	PayeeMobile    string    `json:"payeeMobile,omitempty" haozpay:"encrypt"`
+	BankCode       string    `json:"bankCode,omitempty"`
+	BankName       string    `json:"bankName,omitempty"`
+	Remark         string    `json:"remark,omitempty"`
+}
+
+type CreateBatchTransferRequest struct {
+	BatchNo     string              `json:"batchNo"`
+	TotalAmount Money               `json:"totalAmount"`
+	TotalCount  int                 `json:"totalCount"`
+	Items       []BatchTransferItem `json:"items"`
+	Remark      string              `json:"remark,omitempty"`
+	NotifyUrl   string              `json:"notifyUrl,omitempty"`
+}
+
+type BatchTransferResponse struct {
+	MerchantNo  string              `json:"merchantNo"`
+	BatchNo     string              `json:"batchNo"`
+	BatchId     string              `json:"batchId"`
+	TotalAmount Money               `json:"totalAmount"`
+	TotalCount  int                 `json:"totalCount"`
+	BatchStatus BatchTransferStatus `json:"batchStatus"`
+	CreateTime  HaozTime            `json:"createTime"`
+}
+
+type QueryBatchTransferRequest struct {
+	BatchNo string `json:"batchNo,omitempty"`
+	BatchId string `json:"batchId,omitempty"`
+}
+
+type BatchTransferItemResult struct {
+	ItemSeqId      string         `json:"itemSeqId"`
+	TransferNo     string         `json:"transferNo"`
+	TransferAmount Money          `json:"transferAmount"`
+	FeeAmount      Money          `json:"feeAmount"`
+	TransferStatus TransferStatus `json:"transferStatus"`
+	FailReason     string         `json:"failReason"`
+	FinishTime     HaozTime       `json:"finishTime"`
+}
+
+type QueryBatchTransferResponse struct {
+	MerchantNo      string                    `json:"merchantNo"`
+	BatchNo         string                    `json:"batchNo"`
+	BatchId         string                    `json:"batchId"`
+	BatchStatus     BatchTransferStatus       `json:"batchStatus"`
+	BatchStatusDesc string                    `json:"batchStatusDesc"`
+	TotalAmount     Money                     `json:"totalAmount"`
+	TotalCount      int                       `json:"totalCount"`
+	SuccessAmount   Money                     `json:"successAmount"`
+	SuccessCount    int                       `json:"successCount"`
+	FailedAmount    Money                     `json:"failedAmount"`
+	FailedCount     int                       `json:"failedCount"`
+	Items           []BatchTransferItemResult `json:"items"`
+	CreateTime      HaozTime                  `json:"createTime"`
+	FinishTime      HaozTime                  `json:"finishTime"`
+	RejectReason    string                    `json:"rejectReason"`
+}
+
+type ApplyReceiptRequest struct {
+	OrderNo string `json:"orderNo"`
+}
+
+type QueryReceiptRequest struct {
+	OrderNo   string `json:"orderNo"`
+	ReceiptNo string `json:"receiptNo,omitempty"`
+}
+
+type ReceiptResponse struct {
+	OrderNo       string        `json:"orderNo"`
+	ReceiptNo     string        `json:"receiptNo"`
+	ReceiptStatus ReceiptStatus `json:"receiptStatus"`
+	FileName      string        `json:"fileName"`
+	FileToken     string        `json:"fileToken"`
+	DownloadUrl   string        `json:"downloadUrl"`
+	FileSize      int64         `json:"fileSize"`
+	FileHash      string        `json:"fileHash"`
+	HashType      string        `json:"hashType"`
+	ExpireTime    HaozTime      `json:"expireTime"`
+	FailReason    string        `json:"failReason"`
+}
+
+type ApplyInvoiceRequest struct {
+	OutInvoiceNo string           `json:"outInvoiceNo"`
+	OrderNo      string           `json:"orderNo"`
+	InvoiceType  InvoiceType      `json:"invoiceType"`
+	TitleType    InvoiceTitleType `json:"titleType"`
+	Title        string           `json:"title"`
+	TaxNo        string           `json:"taxNo,omitempty"`
+	Amount       Money            `json:"amount,omitempty"`
+	BuyerEmail   string           `json:"buyerEmail,omitempty"`
+	BuyerMobile  string           `json:"buyerMobile,omitempty"`
+	Remark       string           `json:"remark,omitempty"`
+	NotifyUrl    string           `json:"notifyUrl,omitempty"`
+}
+
+type RedFlushInvoiceRequest struct {
+	InvoiceNo string `json:"invoiceNo"`
+	Reason    string `json:"reason"`
+	NotifyUrl string `json:"notifyUrl,omitempty"`
+}
+
+type QueryInvoiceRequest struct {
+	InvoiceNo    string `json:"invoiceNo,omitempty"`
+	OutInvoiceNo string `json:"outInvoiceNo,omitempty"`
+}
+
+type InvoiceResponse struct {
+	MerchantNo    string           `json:"merchantNo"`
+	InvoiceNo     string           `json:"invoiceNo"`
+	OutInvoiceNo  string           `json:"outInvoiceNo"`
+	OrderNo       string           `json:"orderNo"`
+	InvoiceType   InvoiceType      `json:"invoiceType"`
+	TitleType     InvoiceTitleType `json:"titleType"`
+	Title         string           `json:"title"`
+	InvoiceStatus InvoiceStatus    `json:"invoiceStatus"`
+	InvoiceCode   string           `json:"invoiceCode"`
+	InvoiceNumber string           `json:"invoiceNumber"`
+	Amount        Money            `json:"amount"`
+	TaxAmount     Money            `json:"taxAmount"`
+	PdfUrl        string           `json:"pdfUrl"`
+	IssueTime     HaozTime         `json:"issueTime"`
+	RedFlushTime  HaozTime         `json:"redFlushTime"`
+	FailReason    string           `json:"failReason"`
+}
+
+type CreateCouponActivityRequest struct {
+	OutActivityNo     string     `json:"outActivityNo"`
+	ActivityName      string     `json:"activityName"`
+	CouponType        CouponType `json:"couponType"`
+	CouponAmount      Money      `json:"couponAmount,omitempty"`
+	DiscountPercent   int        `json:"discountPercent,omitempty"`
+	MaxDiscountAmount Money      `json:"maxDiscountAmount,omitempty"`
+	ThresholdAmount   Money      `json:"thresholdAmount,omitempty"`
+	TotalCount        int        `json:"totalCount"`
+	PerUserLimit      int        `json:"perUserLimit,omitempty"`
+	StartTime         string     `json:"startTime"`
+	EndTime           string     `json:"endTime"`
+	Description       string     `json:"description,omitempty"`
+}
+
+type QueryCouponActivityRequest struct {
+	ActivityNo    string `json:"activityNo,omitempty"`
+	OutActivityNo string `json:"outActivityNo,omitempty"`
+}
+
+type CouponActivityResponse struct {
+	MerchantNo      string               `json:"merchantNo"`
+	ActivityNo      string               `json:"activityNo"`
+	OutActivityNo   string               `json:"outActivityNo"`
+	ActivityName    string               `json:"activityName"`
+	CouponType      CouponType           `json:"couponType"`
+	ActivityStatus  CouponActivityStatus `json:"activityStatus"`
+	CouponAmount    Money                `json:"couponAmount"`
+	DiscountPercent int                  `json:"discountPercent"`
+	ThresholdAmount Money                `json:"thresholdAmount"`
+	TotalCount      int                  `json:"totalCount"`
+	IssuedCount     int                  `json:"issuedCount"`
+	UsedCount       int                  `json:"usedCount"`
+	StartTime       HaozTime             `json:"startTime"`
+	EndTime         HaozTime             `json:"endTime"`
+	CreateTime      HaozTime             `json:"createTime"`
+}
+
+type QueryOrderCouponsRequest struct {
+	OrderNo string `json:"orderNo"`
+}
+
+type OrderCouponsResponse struct {
+	OrderNo          string            `json:"orderNo"`
+	OrderAmount      Money             `json:"orderAmount"`
+	DiscountAmount   Money             `json:"discountAmount"`
+	PaidAmount       Money             `json:"paidAmount"`
+	PromotionDetails []PromotionDetail `json:"promotionDetails"`
+}
+
+type ApplyStatementRequest struct {
+	BillDate string   `json:"billDate"`
+	BillType BillType `json:"billType"`
+}
+
+type StatementFileResponse struct {
+	MerchantNo  string   `json:"merchantNo"`
+	BillDate    string   `json:"billDate"`
+	BillType    BillType `json:"billType"`
+	FileName    string   `json:"fileName"`
+	FileToken   string   `json:"fileToken"`
+	DownloadUrl string   `json:"downloadUrl"`
+	RecordCount int      `json:"recordCount"`
+	FileSize    int64    `json:"fileSize"`
+	FileHash    string   `json:"fileHash"`
+	HashType    string   `json:"hashType"`
+	ExpireTime  HaozTime `json:"expireTime"`
+}
+
+type ListOrdersRequest struct {
+	StartTime   string       `json:"startTime,omitempty"`
+	EndTime     string       `json:"endTime,omitempty"`
+	OrderStatus *OrderStatus `json:"orderStatus,omitempty"`
+	PayType     *PayType     `json:"payType,omitempty"`
+	PageSize    int          `json:"pageSize,omitempty"`
+	PageToken   string       `json:"pageToken,omitempty"`
+}
+
+type ListOrdersResponse struct {
+	Orders        []QueryOrderResponse `json:"orders"`
+	NextPageToken string               `json:"nextPageToken"`
+	Total         int                  `json:"total"`
+}
+
+type ListRefundsRequest struct {
+	OrderNo string `json:"orderNo"`
+}
+
+type ListRefundsResponse struct {
+	MerchantNo        string                `json:"merchantNo"`
+	OrderNo           string                `json:"orderNo"`
+	OrderAmount       Money                 `json:"orderAmount"`
+	Currency          Currency              `json:"currency"`
+	TotalRefundAmount Money                 `json:"totalRefundAmount"`
+	RefundCount       int                   `json:"refundCount"`
+	Refunds           []QueryRefundResponse `json:"refunds"`
+}
+
+type SignContractRequest struct {
+	PlanId         string  `json:"planId"`
+	ContractCode   string  `json:"contractCode"`
+	PayType        PayType `json:"payType"`
+	DisplayAccount string  `json:"displayAccount,omitempty"`
+	NotifyUrl      string  `json:"notifyUrl"`
+	ReturnUrl      string  `json:"returnUrl,omitempty"`
+	ClientIp       string  `json:"clientIp,omitempty"`
+}
+
+type SignContractResponse struct {
+	MerchantNo     string         `json:"merchantNo"`
+	ContractCode   string         `json:"contractCode"`
+	PlanId         string         `json:"planId"`
+	PayType        PayType        `json:"payType"`
+	SignUrl        string         `json:"signUrl"`
+	ContractStatus ContractStatus `json:"contractStatus"`
+}
+
+type QueryContractRequest struct {
+	ContractId   string `json:"contractId,omitempty"`
+	ContractCode string `json:"contractCode,omitempty"`
+}
+
+type QueryContractResponse struct {
+	MerchantNo         string         `json:"merchantNo"`
+	ContractId         string         `json:"contractId"`
+	ContractCode       string         `json:"contractCode"`
+	PlanId             string         `json:"planId"`
+	PayType            PayType        `json:"payType"`
+	DisplayAccount     string         `json:"displayAccount"`
+	ContractStatus     ContractStatus `json:"contractStatus"`
+	ContractStatusDesc string         `json:"contractStatusDesc"`
+	SignTime           HaozTime       `json:"signTime"`
+	ExpireTime         HaozTime       `json:"expireTime"`
+	TerminateTime      HaozTime       `json:"terminateTime"`
+	TerminateReason    string         `json:"terminateReason"`
+}
+
+type TerminateContractRequest struct {
+	ContractId      string `json:"contractId,omitempty"`
+	ContractCode    string `json:"contractCode,omitempty"`
+	TerminateReason string `json:"terminateReason"`
+}
+
+type CreateDeductOrderRequest struct {
+	ContractId  string `json:"contractId"`
+	OrderTitle  string `json:"orderTitle"`
+	OrderAmount Money  `json:"orderAmount"`
+	NotifyUrl   string `json:"notifyUrl"`
+	Remark      string `json:"remark,omitempty"`
+}
+
+type DeductOrderResponse struct {
+	MerchantNo      string      `json:"merchantNo"`
+	OrderNo         string      `json:"orderNo"`
+	MerchantOrderNo string      `json:"merchantOrderNo"`
+	SeqId           string      `json:"seqId"`
+	ContractId      string      `json:"contractId"`
+	PayType         PayType     `json:"payType"`
+	OrderTitle      string      `json:"orderTitle"`
+	OrderAmount     Money       `json:"orderAmount"`
+	OrderStatus     OrderStatus `json:"orderStatus"`
+}
+
+type CreatePreAuthRequest struct {
+	OrderTitle    string  `json:"orderTitle"`
+	FreezeAmount  Money   `json:"freezeAmount"`
+	PayType       PayType `json:"payType"`
+	ExpireMinutes int     `json:"expireMinutes,omitempty"`
+	NotifyUrl     string  `json:"notifyUrl"`
+	Remark        string  `json:"remark,omitempty"`
+}
+
+type PreAuthResponse struct {
+	MerchantNo     string        `json:"merchantNo"`
+	AuthNo         string        `json:"authNo"`
+	MerchantAuthNo string        `json:"merchantAuthNo"`
+	SeqId          string        `json:"seqId"`
+	PayType        PayType       `json:"payType"`
+	OrderTitle     string        `json:"orderTitle"`
+	FreezeAmount   Money         `json:"freezeAmount"`
+	PayInfo        string        `json:"payInfo"`
+	AuthStatus     PreAuthStatus `json:"authStatus"`
+	AuthExpireTime HaozTime      `json:"authExpireTime"`
+}
+
+type CapturePreAuthRequest struct {
+	AuthNo        string `json:"authNo"`
+	CaptureAmount Money  `json:"captureAmount"`
+	OrderTitle    string `json:"orderTitle,omitempty"`
+	NotifyUrl     string `json:"notifyUrl,omitempty"`
+	ReleaseRemain bool   `json:"releaseRemain"`
+}
+
+type CapturePreAuthResponse struct {
+	MerchantNo      string        `json:"merchantNo"`
+	AuthNo          string        `json:"authNo"`
+	OrderNo         string        `json:"orderNo"`
+	SeqId           string        `json:"seqId"`
+	CaptureAmount   Money         `json:"captureAmount"`
+	CapturedAmount  Money         `json:"capturedAmount"`
+	ReleasedAmount  Money         `json:"releasedAmount"`
+	RemainingAmount Money         `json:"remainingAmount"`
+	OrderStatus     OrderStatus   `json:"orderStatus"`
+	AuthStatus      PreAuthStatus `json:"authStatus"`
+}
+
+type ReleasePreAuthRequest struct {
+	AuthNo        string `json:"authNo"`
+	ReleaseAmount Money  `json:"releaseAmount,omitempty"`
+	Remark        string `json:"remark,omitempty"`
+}
+
+type ReleasePreAuthResponse struct {
+	MerchantNo      string        `json:"merchantNo"`
+	AuthNo          string        `json:"authNo"`
+	SeqId           string        `json:"seqId"`
+	ReleaseAmount   Money         `json:"releaseAmount"`
+	ReleasedAmount  Money         `json:"releasedAmount"`
+	RemainingAmount Money         `json:"remainingAmount"`
+	AuthStatus      PreAuthStatus `json:"authStatus"`
+}
+
+type QueryPreAuthRequest struct {
+	AuthNo string `json:"authNo"`
+}
+
+type QueryPreAuthResponse struct {
+	MerchantNo      string        `json:"merchantNo"`
+	AuthNo          string        `json:"authNo"`
+	MerchantAuthNo  string        `json:"merchantAuthNo"`
+	SeqId           string        `json:"seqId"`
+	ChannelType     string        `json:"channelType"`
+	ChannelAuthNo   string        `json:"channelAuthNo"`
+	PayType         PayType       `json:"payType"`
+	OrderTitle      string        `json:"orderTitle"`
+	FreezeAmount    Money         `json:"freezeAmount"`
+	CapturedAmount  Money         `json:"capturedAmount"`
+	ReleasedAmount  Money         `json:"releasedAmount"`
+	RemainingAmount Money         `json:"remainingAmount"`
+	AuthStatus      PreAuthStatus `json:"authStatus"`
+	AuthStatusDesc  string        `json:"authStatusDesc"`
+	AuthTime        HaozTime      `json:"authTime"`
+	AuthExpireTime  HaozTime      `json:"authExpireTime"`
+	FinishTime      HaozTime      `json:"finishTime"`
+}
+
+type CreateSubMerchantRequest struct {
+	OutApplyNo          string                `json:"outApplyNo"`
+	MerchantType        SubMerchantType       `json:"merchantType"`
+	MerchantName        string                `json:"merchantName"`
+	MerchantShortName   string                `json:"merchantShortName"`
+	BusinessLicenseNo   string                `json:"businessLicenseNo,omitempty"`
+	BusinessScope       string                `json:"businessScope,omitempty"`
+	BusinessAddress     string                `json:"businessAddress,omitempty"`
+	LegalPersonName     string                `json:"legalPersonName" haozpay:"encrypt"`
+	LegalPersonIdCardNo string                `json:"legalPersonIdCardNo" haozpay:"encrypt"`
+	ContactName         string                `json:"contactName" haozpay:"encrypt"`
+	ContactMobile       string                `json:"contactMobile" haozpay:"encrypt"`
+	ContactEmail        string                `json:"contactEmail,omitempty" haozpay:"encrypt"`
+	SettleAccountType   SettlementAccountType `json:"settleAccountType"`
+	SettleAccountName   string                `json:"settleAccountName" haozpay:"encrypt"`
+	SettleAccountNo     string                `json:"settleAccountNo" haozpay:"encrypt"`
+	SettleBankCode      string                `json:"settleBankCode,omitempty"`
+	SettleBankName      string                `json:"settleBankName"`
+	SettleBankBranch    string                `json:"settleBankBranch,omitempty"`
+	NotifyUrl           string                `json:"notifyUrl,omitempty"`
+}
+
+type SubMerchantResponse struct {
+	MerchantNo    string            `json:"merchantNo"`
+	ApplyNo       string            `json:"applyNo"`
+	OutApplyNo    string            `json:"outApplyNo"`
+	SubMerchantNo string            `json:"subMerchantNo"`
+	ApplyStatus   SubMerchantStatus `json:"applyStatus"`
+}
+
+type UploadQualificationRequest struct {
+	ApplyNo           string            `json:"applyNo"`
+	QualificationType QualificationType `json:"qualificationType"`
+	FileName          string            `json:"fileName"`
+	FileContent       string            `json:"fileContent"`
+}
+
+type UploadQualificationFileRequest struct {
+	ApplyNo           string            `json:"applyNo"`
+	QualificationType QualificationType `json:"qualificationType"`
+	FileName          string            `json:"fileName"`
+	File              io.ReadSeeker     `json:"-"`
+}
+
+type QualificationResponse struct {
+	ApplyNo           string            `json:"applyNo"`
+	QualificationType QualificationType `json:"qualificationType"`
+	MediaId           string            `json:"mediaId"`
+}
+
+type QuerySubMerchantRequest struct {
+	ApplyNo    string `json:"applyNo,omitempty"`
+	OutApplyNo string `json:"outApplyNo,omitempty"`
+}
+
+type QuerySubMerchantResponse struct {
+	MerchantNo      string            `json:"merchantNo"`
+	ApplyNo         string            `json:"applyNo"`
+	OutApplyNo      string            `json:"outApplyNo"`
+	SubMerchantNo   string            `json:"subMerchantNo"`
+	MerchantName    string            `json:"merchantName"`
+	ApplyStatus     SubMerchantStatus `json:"applyStatus"`
+	ApplyStatusDesc string            `json:"applyStatusDesc"`
+	RejectReason    string            `json:"rejectReason"`
+	SignUrl         string            `json:"signUrl"`
+	AuditTime       HaozTime          `json:"auditTime"`
+	FinishTime      HaozTime          `json:"finishTime"`
+}
+
+type ModifySettlementAccountRequest struct {
+	SubMerchantNo     string                `json:"subMerchantNo"`
+	SettleAccountType SettlementAccountType `json:"settleAccountType"`
+	SettleAccountName string                `json:"settleAccountName" haozpay:"encrypt"`
+	SettleAccountNo   string                `json:"settleAccountNo" haozpay:"encrypt"`
+	SettleBankCode    string                `json:"settleBankCode,omitempty"`
+	SettleBankName    string                `json:"settleBankName"`
+	SettleBankBranch  string                `json:"settleBankBranch,omitempty"`
+}
+
+type ModifySettlementAccountResponse struct {
+	SubMerchantNo string            `json:"subMerchantNo"`
+	ModifyApplyNo string            `json:"modifyApplyNo"`
+	ApplyStatus   SubMerchantStatus `json:"applyStatus"`
+}
+
+type QueryExchangeRateRequest struct {
+	BaseCurrency  Currency `json:"baseCurrency"`
+	QuoteCurrency Currency `json:"quoteCurrency"`
+	RateDate      string   `json:"rateDate"`
+}
+
+type ExchangeRateResponse struct {
+	BaseCurrency  Currency `json:"baseCurrency"`
+	QuoteCurrency Currency `json:"quoteCurrency"`
+	RateDate      string   `json:"rateDate"`
+	Rate          Rate     `json:"rate"`
+	RateSource    string   `json:"rateSource"`
+	UpdateTime    HaozTime `json:"updateTime"`
+}
+
+type CheckKeysRequest struct {
+	Probe string `json:"probe"`
+}
+
+type CheckKeysResponse struct {
+	MerchantNo string   `json:"merchantNo"`
+	SignType   SignType `json:"signType"`
+	Probe      string   `json:"probe"`
+}
+
+type ServerTimeRequest struct{}
+
+type ServerTimeResponse struct {
+	ServerTime int64 `json:"serverTime"`
+}
+
+type QueryOrderRiskRequest struct {
+	OrderNo string `json:"orderNo"`
+}
+
+type OrderRiskResponse struct {
+	MerchantNo   string         `json:"merchantNo"`
+	OrderNo      string         `json:"orderNo"`
+	RiskScore    int            `json:"riskScore"`
+	RiskLevel    RiskLevel      `json:"riskLevel"`
+	HoldStatus   RiskHoldStatus `json:"holdStatus"`
+	HoldReason   string         `json:"holdReason"`
+	HitRules     []RiskRule     `json:"hitRules"`
+	DecisionTime HaozTime       `json:"decisionTime"`
+	HoldTime     HaozTime       `json:"holdTime"`
+	ReleaseTime  HaozTime       `json:"releaseTime"`
+}
+
+type RiskRule struct {
+	RuleCode    string `json:"ruleCode"`
+	RuleName    string `json:"ruleName"`
+	Description string `json:"description"`
+}
+
+type QueryMerchantConfigRequest struct{}
+
+type MerchantConfigResponse struct {
+	MerchantNo string                  `json:"merchantNo"`
+	Channels   []MerchantChannelConfig `json:"channels"`
+	UpdateTime HaozTime                `json:"updateTime"`
+}
+
+type MerchantChannelConfig struct {
+	PayType     PayType    `json:"payType"`
+	PayTypeDesc string     `json:"payTypeDesc"`
+	Enabled     bool       `json:"enabled"`
+	FeeRate     Rate       `json:"feeRate"`
+	FixedFee    Money      `json:"fixedFee"`
+	MinAmount   Money      `json:"minAmount"`
+	MaxAmount   Money      `json:"maxAmount"`
+	DailyLimit  Money      `json:"dailyLimit"`
+	Currencies  []Currency `json:"currencies"`
+}
+
+type QueryChannelStatusRequest struct{}
+
+type ChannelStatusResponse struct {
+	Channels  []ChannelStatus `json:"channels"`
+	QueryTime HaozTime        `json:"queryTime"`
+}
+
+type ChannelStatus struct {
+	PayType              PayType      `json:"payType"`
+	PayTypeDesc          string       `json:"payTypeDesc"`
+	Status               ChannelState `json:"status"`
+	StatusDesc           string       `json:"statusDesc"`
+	MaintenanceStartTime HaozTime     `json:"maintenanceStartTime"`
+	MaintenanceEndTime   HaozTime     `json:"maintenanceEndTime"`
+	Notice               string       `json:"notice"`
+}
+
+type QueryBankListRequest struct{}
+
+type BankListResponse struct {
+	Banks      []BankInfo `json:"banks"`
+	UpdateTime HaozTime   `json:"updateTime"`
+}
+
+type BankInfo struct {
+	BankCode        string `json:"bankCode"`
+	BankName        string `json:"bankName"`
+	ShortName       string `json:"shortName"`
+	LogoUrl         string `json:"logoUrl"`
+	DebitSupported  bool   `json:"debitSupported"`
+	CreditSupported bool   `json:"creditSupported"`
+}
+
+type QueryCardBINRequest struct {
+	CardBin string `json:"cardBin"`
+}
+
+type CardBINResponse struct {
+	CardBin    string       `json:"cardBin"`
+	BankCode   string       `json:"bankCode"`
+	BankName   string       `json:"bankName"`
+	CardName   string       `json:"cardName"`
+	CardType   BankCardType `json:"cardType"`
+	CardLength int          `json:"cardLength"`
+}
+
+type BindWithdrawCardRequest struct {
+	SubMerchantNo string                `json:"subMerchantNo,omitempty"`
+	ReqSeqId      string                `json:"reqSeqId"`
+	AccountType   SettlementAccountType `json:"accountType"`
+	AccountName   string                `json:"accountName" haozpay:"encrypt"`
+	CardNo        string                `json:"cardNo" haozpay:"encrypt"`
+	IdCardNo      string                `json:"idCardNo,omitempty" haozpay:"encrypt"`
+	Mobile        string                `json:"mobile,omitempty" haozpay:"encrypt"`
+	BankCode      string                `json:"bankCode,omitempty"`
+	BankName      string                `json:"bankName"`
+	BankBranch    string                `json:"bankBranch,omitempty"`
+	IsDefault     bool                  `json:"isDefault,omitempty"`
+}
+
+type BindWithdrawCardResponse struct {
+	SubMerchantNo  string         `json:"subMerchantNo"`
+	ReqSeqId       string         `json:"reqSeqId"`
+	BindId         string         `json:"bindId"`
+	CardNoMasked   string         `json:"cardNoMasked"`
+	BindStatus     BindCardStatus `json:"bindStatus"`
+	BindStatusDesc string         `json:"bindStatusDesc"`
+	FailReason     string         `json:"failReason"`
+}
+
+type UnbindWithdrawCardRequest struct {
+	SubMerchantNo string `json:"subMerchantNo,omitempty"`
+	BindId        string `json:"bindId"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+type UnbindWithdrawCardResponse struct {
+	SubMerchantNo string         `json:"subMerchantNo"`
+	BindId        string         `json:"bindId"`
+	BindStatus    BindCardStatus `json:"bindStatus"`
+	UnbindTime    HaozTime       `json:"unbindTime"`
+}
+
+type ListBoundCardsRequest struct {
+	SubMerchantNo string `json:"subMerchantNo,omitempty"`
+}
+
+type BoundCardListResponse struct {
+	SubMerchantNo string      `json:"subMerchantNo"`
+	Cards         []BoundCard `json:"cards"`
+}
+
+type BoundCard struct {
+	BindId            string                `json:"bindId"`
+	AccountType       SettlementAccountType `json:"accountType"`
+	AccountNameMasked string                `json:"accountNameMasked"`
+	CardNoMasked      string                `json:"cardNoMasked"`
+	CardType          BankCardType          `json:"cardType"`
+	BankCode          string                `json:"bankCode"`
+	BankName          string                `json:"bankName"`
+	BankBranch        string                `json:"bankBranch"`
+	IsDefault         bool                  `json:"isDefault"`
+	BindStatus        BindCardStatus        `json:"bindStatus"`
+	BindTime          HaozTime              `json:"bindTime"`
+}
+
+type ListTransactionsRequest struct {
+	SubMerchantNo   string                  `json:"subMerchantNo,omitempty"`
+	StartTime       string                  `json:"startTime,omitempty"`
+	EndTime         string                  `json:"endTime,omitempty"`
+	TransactionType *AccountTransactionType `json:"transactionType,omitempty"`
+	PageSize        int                     `json:"pageSize,omitempty"`
+	PageToken       string                  `json:"pageToken,omitempty"`
+}
+
+type ListTransactionsResponse struct {
+	Transactions  []AccountTransaction `json:"transactions"`
+	NextPageToken string               `json:"nextPageToken"`
+	Total         int                  `json:"total"`
+}
+
+type AccountTransaction struct {
+	TransactionNo   string                 `json:"transactionNo"`
+	TransactionType AccountTransactionType `json:"transactionType"`
+	Amount          Money                  `json:"amount"`
+	BalanceAfter    Money                  `json:"balanceAfter"`
+	Currency        Currency               `json:"currency"`
+	OrderNo         string                 `json:"orderNo"`
+	ReqSeqId        string                 `json:"reqSeqId"`
+	Remark          string                 `json:"remark"`
+	TransactionTime HaozTime               `json:"transactionTime"`
 }
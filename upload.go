@@ -0,0 +1,93 @@
+package haozpay
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// uploadQualificationMeta 资质材料上传的签名参数
+// 文件内容不参与签名，由 fileSize 和 fileDigest（SHA256 HEX）确保平台收到的文件未被篡改
+type uploadQualificationMeta struct {
+	ApplyNo           string            `json:"applyNo"`
+	QualificationType QualificationType `json:"qualificationType"`
+	FileName          string            `json:"fileName"`
+	FileSize          int64             `json:"fileSize"`
+	FileDigest        string            `json:"fileDigest"`
+}
+
+type UploadService struct {
+	executor *apiExecutor
+}
+
+func NewUploadService(client *resty.Client, config *Config) *UploadService {
+	return &UploadService{
+		executor: newAPIExecutor(client, config),
+	}
+}
+
+func (s *UploadService) UploadQualification(ctx context.Context, req *UploadQualificationFileRequest, opts ...RequestOption) (*QualificationResponse, error) {
+	if !req.QualificationType.IsValid() {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("invalid qualificationType: %d", req.QualificationType),
+			StatusCode: 0,
+		}
+	}
+	if req.File == nil || req.FileName == "" {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    "file and fileName are required",
+			StatusCode: 0,
+		}
+	}
+
+	size, digest, err := fileDigest(req.File, MaxQualificationFileSize)
+	if err != nil {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("failed to read qualification file: %v", err),
+			StatusCode: 0,
+			Err:        err,
+		}
+	}
+	if size == 0 || size > MaxQualificationFileSize {
+		return nil, &SDKError{
+			Code:       ErrInvalidParameter.Code,
+			Message:    fmt.Sprintf("qualification file size must be between 1 and %d bytes, got %d", MaxQualificationFileSize, size),
+			StatusCode: 0,
+		}
+	}
+
+	meta := &uploadQualificationMeta{
+		ApplyNo:           req.ApplyNo,
+		QualificationType: req.QualificationType,
+		FileName:          req.FileName,
+		FileSize:          size,
+		FileDigest:        digest,
+	}
+	file := &uploadFile{field: "file", name: req.FileName, content: req.File}
+
+	var resp *QualificationResponse
+	if err := s.executor.post(ctx, "/pay-core/upload/qualification", meta, &resp, "failed to upload qualification", append(opts, withUploadFile(file))...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// fileDigest 从文件开头流式计算 SHA256 摘要
+// 最多读取 limit+1 字节，超出时返回的 size 大于 limit，由调用方判断
+func fileDigest(file io.ReadSeeker, limit int64) (size int64, digest string, err error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return 0, "", err
+	}
+	hash := sha256.New()
+	size, err = io.Copy(hash, io.LimitReader(file, limit+1))
+	if err != nil {
+		return 0, "", err
+	}
+	return size, fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
@@ -0,0 +1,145 @@
+package haozpay
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// WaitOptions 轮询订单状态的退避参数
+// 各字段为零值时使用默认值
+type WaitOptions struct {
+	// InitialInterval 首次查询前的等待时间，默认 1 秒
+	InitialInterval time.Duration
+	// MaxInterval 两次查询之间的最大等待时间，默认 30 秒
+	MaxInterval time.Duration
+	// Multiplier 每次查询后等待时间的增长倍数，默认 2
+	Multiplier float64
+	// Jitter 等待时间的随机抖动比例（0~1），默认 0.2，即在 ±20% 范围内随机
+	// 避免大量订单同时下单后在同一时刻集中查询
+	Jitter float64
+}
+
+const (
+	defaultWaitInitialInterval = time.Second
+	defaultWaitMaxInterval     = 30 * time.Second
+	defaultWaitMultiplier      = 2.0
+	defaultWaitJitter          = 0.2
+)
+
+// withDefaults 返回补全默认值后的退避参数
+func (o *WaitOptions) withDefaults() WaitOptions {
+	var opts WaitOptions
+	if o != nil {
+		opts = *o
+	}
+	if opts.InitialInterval <= 0 {
+		opts.InitialInterval = defaultWaitInitialInterval
+	}
+	if opts.MaxInterval <= 0 {
+		opts.MaxInterval = defaultWaitMaxInterval
+	}
+	if opts.MaxInterval < opts.InitialInterval {
+		opts.MaxInterval = opts.InitialInterval
+	}
+	if opts.Multiplier < 1 {
+		opts.Multiplier = defaultWaitMultiplier
+	}
+	if opts.Jitter <= 0 || opts.Jitter > 1 {
+		opts.Jitter = defaultWaitJitter
+	}
+	return opts
+}
+
+// next 计算下一次等待时间（不含抖动）
+func (o *WaitOptions) next(interval time.Duration) time.Duration {
+	next := time.Duration(float64(interval) * o.Multiplier)
+	if next > o.MaxInterval {
+		return o.MaxInterval
+	}
+	return next
+}
+
+// jittered 在等待时间上叠加随机抖动
+func (o *WaitOptions) jittered(interval time.Duration) time.Duration {
+	delta := float64(interval) * o.Jitter
+	return time.Duration(float64(interval) - delta + rand.Float64()*2*delta)
+}
+
+// WaitForPayment 轮询订单状态，直到订单进入终态或 ctx 结束
+//
+// 使用指数退避和随机抖动控制查询频率；查询遇到网络错误、HTTP 429 或 5xx 时继续轮询，
+// 其他错误（例如订单不存在、验签失败）立即返回
+// 设置了 Config.OrderStore 时，每次查询到的订单状态保存为本地快照，保存失败时停止轮询并返回错误
+//
+// 参数:
+//   - ctx: 上下文，用于控制最长等待时间
+//   - orderNo: 平台订单号
+//   - opts: 退避参数，为 nil 时使用默认值
+//   - reqOpts: 每次查询使用的请求选项
+//
+// 返回:
+//   - *QueryOrderResponse: 进入终态的订单；ctx 结束时为最后一次查询到的订单（可能为 nil）
+//   - error: ctx 结束时返回 ctx.Err()，查询失败且无法通过重试恢复时返回对应错误
+//
+// 示例:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+//	defer cancel()
+//
+//	order, err := client.Payment.WaitForPayment(ctx, orderNo, nil)
+//	if err != nil {
+//	    return err
+//	}
+//	if order.OrderStatus.IsSuccess() {
+//	    // 支付成功
+//	}
+func (s *PaymentService) WaitForPayment(ctx context.Context, orderNo string, opts *WaitOptions, reqOpts ...RequestOption) (*QueryOrderResponse, error) {
+	options := opts.withDefaults()
+	interval := options.InitialInterval
+
+	var last *QueryOrderResponse
+	for {
+		if err := sleepContext(ctx, options.jittered(interval)); err != nil {
+			return last, err
+		}
+
+		order, err := s.QueryOrder(ctx, &QueryOrderRequest{OrderNo: orderNo}, reqOpts...)
+		switch {
+		case err == nil && order != nil:
+			last = order
+			if err := s.executor.saveOrderSnapshot(ctx, order); err != nil {
+				return last, err
+			}
+			if order.OrderStatus.IsFinal() {
+				return order, nil
+			}
+		case err != nil && ctx.Err() != nil:
+			return last, ctx.Err()
+		case err != nil && !isTransientError(err):
+			return last, err
+		}
+
+		interval = options.next(interval)
+	}
+}
+
+// isTransientError 判断接口调用错误是否为暂时性错误（网络错误、超时、HTTP 429、5xx）
+func isTransientError(err error) bool {
+	var sdkErr *SDKError
+	return errors.As(err, &sdkErr) && sdkErr.Retryable()
+}
+
+// sleepContext 等待指定时间，ctx 结束时提前返回 ctx.Err()
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,54 @@
+// Package haozpayzap 提供皓臻支付 SDK 日志接口的 zap 适配
+//
+// 示例:
+//
+//	logger, _ := zap.NewProduction()
+//	config := haozpay.DefaultConfig().
+//	    WithLogger(haozpayzap.New(logger))
+package haozpayzap
+
+import (
+	"go.uber.org/zap"
+
+	haozpay "github.com/haoz-cloud/haozpay-sdk"
+)
+
+// New 使用 zap.Logger 创建 SDK 日志
+//
+// 参数:
+//   - logger: zap 日志实例，为 nil 时使用 zap.L()
+//
+// 返回:
+//   - haozpay.Logger: SDK 日志实例
+func New(logger *zap.Logger) haozpay.Logger {
+	if logger == nil {
+		logger = zap.L()
+	}
+	return &zapLogger{sugar: logger.WithOptions(zap.AddCallerSkip(1)).Sugar()}
+}
+
+// NewSugared 使用 zap.SugaredLogger 创建 SDK 日志
+func NewSugared(sugar *zap.SugaredLogger) haozpay.Logger {
+	return &zapLogger{sugar: sugar.WithOptions(zap.AddCallerSkip(1))}
+}
+
+// zapLogger zap 适配
+type zapLogger struct {
+	sugar *zap.SugaredLogger
+}
+
+func (l *zapLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.sugar.Debugw(msg, keysAndValues...)
+}
+
+func (l *zapLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.sugar.Infow(msg, keysAndValues...)
+}
+
+func (l *zapLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.sugar.Warnw(msg, keysAndValues...)
+}
+
+func (l *zapLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.sugar.Errorw(msg, keysAndValues...)
+}